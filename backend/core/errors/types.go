@@ -32,6 +32,7 @@ var (
 	BadInput     = register(&Type{httpCode: http.StatusBadRequest, meta: "bad-input"})
 	Unauthorized = register(&Type{httpCode: http.StatusUnauthorized, meta: "unauthorized"})
 	Forbidden    = register(&Type{httpCode: http.StatusForbidden, meta: "forbidden"})
+	Conflict     = register(&Type{httpCode: http.StatusConflict, meta: "conflict"})
 	Internal     = register(&Type{httpCode: http.StatusInternalServerError, meta: "internal"})
 	Timeout      = register(&Type{httpCode: http.StatusGatewayTimeout, meta: "timeout"})
 
@@ -32,6 +32,20 @@ const (
 	LOG_ERROR = LogLevel(logrus.ErrorLevel)
 )
 
+// Names of the structured fields correlating log entries with pipelines, tasks and api requests
+const (
+	FIELD_PIPELINE_ID   = "pipeline_id"
+	FIELD_TASK_ID       = "task_id"
+	FIELD_PLUGIN        = "plugin"
+	FIELD_SUBTASK       = "subtask"
+	FIELD_CONNECTION_ID = "connection_id"
+	FIELD_SCOPE         = "scope"
+	FIELD_URL           = "url"
+)
+
+// Fields are structured fields attached to each log entry, they are written as separated fields in the json format
+type Fields map[string]interface{}
+
 // Logger General logger interface, can be used anywhere
 type Logger interface {
 	IsLevelEnabled(level LogLevel) bool
@@ -46,6 +60,9 @@ type Logger interface {
 	// Nested return a new logger instance. `name` is the extra prefix to be prepended to each message. Leaving it blank
 	// will add no additional prefix. The new Logger will inherit the properties of the original.
 	Nested(name string) Logger
+	// WithFields returns a new logger instance attaching the fields to each entry in addition to the ones of the
+	// original. The text format leaves them out since they are presented by the prefixes already.
+	WithFields(fields Fields) Logger
 	// GetConfig Returns a copy of the LoggerConfig associated with this Logger. This is meant to be used by the framework.
 	GetConfig() *LoggerConfig
 	// SetStream sets the output of this Logger. This is meant to be used by the framework.
@@ -62,4 +79,5 @@ type LoggerStreamConfig struct {
 type LoggerConfig struct {
 	Path   string
 	Prefix string
+	Fields Fields
 }
@@ -0,0 +1,156 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "devlake"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	pipelinesFinished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pipelines_finished_total",
+		Help:      "Number of finished pipelines by status.",
+	}, []string{"status"})
+	pipelineDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "pipeline_duration_seconds",
+		Help:      "Duration of finished pipelines by status.",
+		Buckets:   prometheus.ExponentialBuckets(30, 2, 12),
+	}, []string{"status"})
+	tasksFinished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_finished_total",
+		Help:      "Number of finished tasks by plugin and status.",
+	}, []string{"plugin", "status"})
+	taskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "Duration of finished tasks by plugin and status.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 14),
+	}, []string{"plugin", "status"})
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_requests_total",
+		Help:      "Number of outbound api requests by plugin, method and status code.",
+	}, []string{"plugin", "method", "code"})
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "api_request_duration_seconds",
+		Help:      "Latency of outbound api requests by plugin.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"plugin"})
+	apiRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_retries_total",
+		Help:      "Number of retried outbound api requests by plugin.",
+	}, []string{"plugin"})
+	batchSaveFlushSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "batch_save_flush_size",
+		Help:      "Number of records written by each BatchSave flush by table.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{"table"})
+	leader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "leader",
+		Help:      "Whether the instance is the leader running the blueprint cron and the pipeline queue.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		pipelinesFinished,
+		pipelineDuration,
+		tasksFinished,
+		taskDuration,
+		apiRequests,
+		apiRequestDuration,
+		apiRetries,
+		batchSaveFlushSize,
+		leader,
+	)
+}
+
+// Handler returns the http.Handler serving all metrics in Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Namespace returns the prefix of all metrics exposed by DevLake
+func Namespace() string {
+	return namespace
+}
+
+// Register adds extra collectors, i.e. the ones which read statistics from database at scraping time
+func Register(collector prometheus.Collector) error {
+	return registry.Register(collector)
+}
+
+// ObservePipelineFinished records a finished pipeline
+func ObservePipelineFinished(status string, duration time.Duration) {
+	pipelinesFinished.WithLabelValues(status).Inc()
+	pipelineDuration.WithLabelValues(status).Observe(duration.Seconds())
+}
+
+// ObserveTaskFinished records a finished task
+func ObserveTaskFinished(plugin string, status string, duration time.Duration) {
+	tasksFinished.WithLabelValues(plugin, status).Inc()
+	taskDuration.WithLabelValues(plugin, status).Observe(duration.Seconds())
+}
+
+// ObserveApiRequest records an outbound api request, statusCode should be 0 if no response was received
+func ObserveApiRequest(plugin string, method string, statusCode int, duration time.Duration) {
+	code := "error"
+	if statusCode > 0 {
+		code = strconv.Itoa(statusCode)
+	}
+	apiRequests.WithLabelValues(plugin, method, code).Inc()
+	apiRequestDuration.WithLabelValues(plugin).Observe(duration.Seconds())
+}
+
+// IncApiRetries records a retried outbound api request
+func IncApiRetries(plugin string) {
+	apiRetries.WithLabelValues(plugin).Inc()
+}
+
+// ObserveBatchSaveFlush records the number of records written by a BatchSave flush
+func ObserveBatchSaveFlush(table string, size int) {
+	batchSaveFlushSize.WithLabelValues(table).Observe(float64(size))
+}
+
+// SetLeader records whether the instance holds the leader lease
+func SetLeader(isLeader bool) {
+	if isLeader {
+		leader.Set(1)
+	} else {
+		leader.Set(0)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	ObservePipelineFinished("TASK_COMPLETED", 2*time.Minute)
+	ObserveTaskFinished("github", "TASK_FAILED", time.Minute)
+	ObserveApiRequest("github", "GET", 200, time.Second)
+	ObserveApiRequest("github", "GET", 0, time.Second)
+	IncApiRetries("github")
+	ObserveBatchSaveFlush("_tool_github_issues", 500)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	assert.Nil(t, err)
+	output := string(body)
+	assert.Contains(t, output, `devlake_pipelines_finished_total{status="TASK_COMPLETED"} 1`)
+	assert.Contains(t, output, `devlake_tasks_finished_total{plugin="github",status="TASK_FAILED"} 1`)
+	assert.Contains(t, output, `devlake_api_requests_total{code="200",method="GET",plugin="github"} 1`)
+	assert.Contains(t, output, `devlake_api_requests_total{code="error",method="GET",plugin="github"} 1`)
+	assert.Contains(t, output, `devlake_api_retries_total{plugin="github"} 1`)
+	assert.Contains(t, output, `devlake_batch_save_flush_size_count{table="_tool_github_issues"} 1`)
+	assert.Contains(t, output, "go_goroutines")
+}
@@ -20,6 +20,7 @@ package migration
 import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"sort"
@@ -31,6 +32,14 @@ type scriptWithComment struct {
 	comment string
 }
 
+func (swc *scriptWithComment) key() string {
+	return scriptKey(swc.script.Name(), swc.script.Version())
+}
+
+func scriptKey(name string, version uint64) string {
+	return fmt.Sprintf("%s:%d", name, version)
+}
+
 type migratorImpl struct {
 	sync.Mutex
 	basicRes context.BasicRes
@@ -46,15 +55,20 @@ func (m *migratorImpl) loadExecuted() errors.Error {
 	if err != nil {
 		return errors.Default.Wrap(err, "error performing migrations")
 	}
-	// load executed scripts into memory
+	return m.reloadExecuted()
+}
+
+// reloadExecuted loads the executed scripts into memory
+func (m *migratorImpl) reloadExecuted() errors.Error {
+	db := m.basicRes.GetDal()
 	m.executed = make(map[string]bool)
 	var records []MigrationHistory
-	err = db.All(&records)
+	err := db.All(&records)
 	if err != nil {
 		return errors.Default.Wrap(err, "error finding migration history records")
 	}
 	for _, record := range records {
-		m.executed[fmt.Sprintf("%s:%d", record.ScriptName, record.ScriptVersion)] = true
+		m.executed[scriptKey(record.ScriptName, record.ScriptVersion)] = true
 	}
 	return nil
 }
@@ -64,13 +78,12 @@ func (m *migratorImpl) Register(scripts []plugin.MigrationScript, comment string
 	m.Lock()
 	defer m.Unlock()
 	for _, script := range scripts {
-		key := fmt.Sprintf("%s:%d", script.Name(), script.Version())
 		swc := &scriptWithComment{
 			script:  script,
 			comment: comment,
 		}
 		m.scripts = append(m.scripts, swc)
-		if !m.executed[key] {
+		if !m.executed[swc.key()] {
 			m.pending = append(m.pending, swc)
 		}
 	}
@@ -78,6 +91,20 @@ func (m *migratorImpl) Register(scripts []plugin.MigrationScript, comment string
 
 // Execute all registered migration script in order and mark them as executed in migration_history table
 func (m *migratorImpl) Execute() errors.Error {
+	m.Lock()
+	defer m.Unlock()
+	// the scripts might have been executed by another instance sharing the database since they were registered
+	err := m.reloadExecuted()
+	if err != nil {
+		return err
+	}
+	pending := make([]*scriptWithComment, 0, len(m.pending))
+	for _, swc := range m.pending {
+		if !m.executed[swc.key()] {
+			pending = append(pending, swc)
+		}
+	}
+	m.pending = pending
 	// sort the scripts by version
 	sort.Slice(m.pending, func(i, j int) bool {
 		return m.pending[i].script.Version() < m.pending[j].script.Version()
@@ -100,7 +127,7 @@ func (m *migratorImpl) Execute() errors.Error {
 		if err != nil {
 			return errors.Default.Wrap(err, fmt.Sprintf("failed to execute migration script %s", scriptId))
 		}
-		m.executed[scriptId] = true
+		m.executed[swc.key()] = true
 		m.pending = m.pending[1:]
 	}
 	return nil
@@ -111,6 +138,105 @@ func (m *migratorImpl) HasPendingScripts() bool {
 	return len(m.executed) > 0 && len(m.pending) > 0
 }
 
+// Plan simulates all pending scripts in order without writing to the database: reads are passed through while
+// writes are recorded to find out the tables each script would touch and whether it would drop or delete anything.
+// A script failed during simulation, i.e. reading a table created by a previous pending script, is reported with
+// the SimulationError and whatever it recorded before the failure
+func (m *migratorImpl) Plan() ([]*plugin.MigrationPlanItem, errors.Error) {
+	m.Lock()
+	defer m.Unlock()
+	pending := make([]*scriptWithComment, len(m.pending))
+	copy(pending, m.pending)
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].script.Version() < pending[j].script.Version()
+	})
+	plan := make([]*plugin.MigrationPlanItem, 0, len(pending))
+	for _, swc := range pending {
+		planDb := newPlanDal(m.basicRes.GetDal())
+		_, reversible := swc.script.(plugin.ReversibleMigrationScript)
+		item := &plugin.MigrationPlanItem{
+			Version:    swc.script.Version(),
+			Name:       swc.script.Name(),
+			Comment:    swc.comment,
+			Reversible: reversible,
+		}
+		err := simulate(swc.script, &planBasicRes{BasicRes: m.basicRes, db: planDb})
+		if err != nil {
+			item.SimulationError = err.Error()
+		}
+		item.Tables = planDb.tables
+		item.Destructive = planDb.destructive
+		plan = append(plan, item)
+	}
+	return plan, nil
+}
+
+func simulate(script plugin.MigrationScript, basicRes *planBasicRes) (err errors.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Default.New(fmt.Sprintf("panic: %v", r))
+		}
+	}()
+	return script.Up(basicRes)
+}
+
+// Rollback reverts executed scripts newer than the specified version by calling their Down in reverse order and
+// removes them from the migration_history table. Nothing would be reverted if any of them is not registered or
+// doesn't implement the ReversibleMigrationScript
+func (m *migratorImpl) Rollback(version uint64) errors.Error {
+	m.Lock()
+	defer m.Unlock()
+	db := m.basicRes.GetDal()
+	logger := m.basicRes.GetLogger().Nested("migrator")
+	var records []MigrationHistory
+	err := db.All(&records, dal.Where("script_version > ?", version))
+	if err != nil {
+		return errors.Default.Wrap(err, "error finding migration history records")
+	}
+	registered := make(map[string]*scriptWithComment, len(m.scripts))
+	for _, swc := range m.scripts {
+		registered[swc.key()] = swc
+	}
+	targets := make([]*scriptWithComment, 0, len(records))
+	for _, record := range records {
+		swc := registered[scriptKey(record.ScriptName, record.ScriptVersion)]
+		if swc == nil {
+			return errors.BadInput.New(fmt.Sprintf(
+				"migration script %d-%s is not registered in this version, it could not be rolled back",
+				record.ScriptVersion, record.ScriptName,
+			))
+		}
+		if _, ok := swc.script.(plugin.ReversibleMigrationScript); !ok {
+			return errors.BadInput.New(fmt.Sprintf(
+				"migration script %d-%s is not reversible, the earliest version to roll back to is %d",
+				record.ScriptVersion, record.ScriptName, record.ScriptVersion,
+			))
+		}
+		targets = append(targets, swc)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].script.Version() > targets[j].script.Version()
+	})
+	for _, swc := range targets {
+		scriptId := fmt.Sprintf("%d-%s", swc.script.Version(), swc.script.Name())
+		logger.Info("rolling back migration script %s", scriptId)
+		err = swc.script.(plugin.ReversibleMigrationScript).Down(m.basicRes)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to roll back migration script %s", scriptId))
+		}
+		err = db.Delete(
+			&MigrationHistory{},
+			dal.Where("script_version = ? AND script_name = ?", swc.script.Version(), swc.script.Name()),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to remove migration history of %s", scriptId))
+		}
+		delete(m.executed, swc.key())
+		m.pending = append(m.pending, swc)
+	}
+	return nil
+}
+
 // NewMigrator returns a new Migrator instance, which
 // implemented based on migration_history from the same database
 func NewMigrator(basicRes context.BasicRes) (plugin.Migrator, errors.Error) {
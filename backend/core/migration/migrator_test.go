@@ -18,6 +18,7 @@ limitations under the License.
 package migration
 
 import (
+	corecontext "github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
@@ -45,7 +46,7 @@ func TestHasPendingScripts(t *testing.T) {
 			{ScriptName: "C", ScriptVersion: 3, Comment: "UniTest", CreatedAt: time.Now()},
 		}
 		return nil
-	}).Once()
+	}).Twice()
 	mockDal.On("Create", &MigrationHistory{
 		ScriptName:    "E",
 		ScriptVersion: 4,
@@ -85,3 +86,172 @@ func TestHasPendingScripts(t *testing.T) {
 	// make sure all method got called
 	mockDal.AssertExpectations(t)
 }
+
+func TestExecuteSkipsScriptsExecutedByOthers(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Return(func(i interface{}, _ ...dal.Clause) errors.Error {
+		*i.(*[]MigrationHistory) = []MigrationHistory{{ScriptName: "A", ScriptVersion: 1}}
+		return nil
+	}).Once()
+	// another instance applied B while this one was waiting for the migration lock
+	mockDal.On("All", mock.Anything, mock.Anything).Return(func(i interface{}, _ ...dal.Clause) errors.Error {
+		*i.(*[]MigrationHistory) = []MigrationHistory{{ScriptName: "A", ScriptVersion: 1}, {ScriptName: "B", ScriptVersion: 2}}
+		return nil
+	}).Once()
+	mockDal.On("Create", &MigrationHistory{ScriptName: "C", ScriptVersion: 3, Comment: "UnitTest"}, mock.Anything).Return(nil).Once()
+
+	basicRes := context.NewDefaultBasicRes(viper.New(), unithelper.DummyLogger(), mockDal)
+	migrator, err := NewMigrator(basicRes)
+	assert.Nil(t, err)
+	scriptB := new(mockplugin.MigrationScript)
+	scriptB.On("Version").Return(uint64(2))
+	scriptB.On("Name").Return("B")
+	scriptC := new(mockplugin.MigrationScript)
+	scriptC.On("Up", mock.Anything).Return(nil).Once()
+	scriptC.On("Version").Return(uint64(3))
+	scriptC.On("Name").Return("C")
+	migrator.Register([]plugin.MigrationScript{scriptB, scriptC}, "UnitTest")
+
+	assert.Nil(t, migrator.Execute())
+	assert.False(t, migrator.HasPendingScripts())
+	scriptB.AssertNotCalled(t, "Up", mock.Anything)
+	mockDal.AssertExpectations(t)
+}
+
+type planTestTable struct {
+	Name string
+}
+
+func (planTestTable) TableName() string {
+	return "_tool_plan_tests"
+}
+
+type planTestScript struct{}
+
+func (planTestScript) Up(basicRes corecontext.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.AutoMigrate(&planTestTable{})
+	if err != nil {
+		return err
+	}
+	err = db.UpdateColumn(&planTestTable{}, "name", "", dal.Where("name IS NULL"))
+	if err != nil {
+		return err
+	}
+	return db.DropColumns("_tool_plan_olds", "name")
+}
+
+func (planTestScript) Version() uint64 {
+	return 4
+}
+
+func (planTestScript) Name() string {
+	return "P"
+}
+
+func newTestMigrator(t *testing.T, mockDal *mockdal.Dal) plugin.Migrator {
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Return(func(i interface{}, _ ...dal.Clause) errors.Error {
+		precords := i.(*[]MigrationHistory)
+		*precords = []MigrationHistory{
+			{ScriptName: "A", ScriptVersion: 1, Comment: "UniTest", CreatedAt: time.Now()},
+			{ScriptName: "B", ScriptVersion: 2, Comment: "UniTest", CreatedAt: time.Now()},
+			{ScriptName: "C", ScriptVersion: 3, Comment: "UniTest", CreatedAt: time.Now()},
+		}
+		return nil
+	}).Once()
+	basicRes := context.NewDefaultBasicRes(viper.New(), unithelper.DummyLogger(), mockDal)
+	migrator, err := NewMigrator(basicRes)
+	assert.Nil(t, err)
+	return migrator
+}
+
+func TestPlan(t *testing.T) {
+	// writes must not reach the database, any unexpected call fails the mock
+	mockDal := new(mockdal.Dal)
+	migrator := newTestMigrator(t, mockDal)
+
+	scriptA := new(mockplugin.MigrationScript)
+	scriptA.On("Version").Return(uint64(1))
+	scriptA.On("Name").Return("A")
+	migrator.Register([]plugin.MigrationScript{scriptA, planTestScript{}}, "UnitTest")
+
+	plan, err := migrator.Plan()
+	assert.Nil(t, err)
+	assert.Len(t, plan, 1)
+	assert.Equal(t, uint64(4), plan[0].Version)
+	assert.Equal(t, "P", plan[0].Name)
+	assert.Equal(t, []string{"_tool_plan_tests", "_tool_plan_olds"}, plan[0].Tables)
+	assert.True(t, plan[0].Destructive)
+	assert.False(t, plan[0].Reversible)
+	assert.Empty(t, plan[0].SimulationError)
+
+	// the plan must not execute anything
+	assert.True(t, migrator.HasPendingScripts())
+	mockDal.AssertExpectations(t)
+}
+
+func TestRollback(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	migrator := newTestMigrator(t, mockDal)
+	mockDal.On("All", mock.Anything, mock.Anything).Return(func(i interface{}, _ ...dal.Clause) errors.Error {
+		precords := i.(*[]MigrationHistory)
+		*precords = []MigrationHistory{
+			{ScriptName: "B", ScriptVersion: 2, Comment: "UniTest", CreatedAt: time.Now()},
+			{ScriptName: "C", ScriptVersion: 3, Comment: "UniTest", CreatedAt: time.Now()},
+		}
+		return nil
+	}).Once()
+	mockDal.On("Delete", &MigrationHistory{}, mock.Anything).Return(nil).Twice()
+
+	var rolledBack []string
+	scriptA := new(mockplugin.MigrationScript)
+	scriptA.On("Version").Return(uint64(1))
+	scriptA.On("Name").Return("A")
+	scriptB := new(mockplugin.ReversibleMigrationScript)
+	scriptB.On("Version").Return(uint64(2))
+	scriptB.On("Name").Return("B")
+	scriptB.On("Down", mock.Anything).Run(func(mock.Arguments) { rolledBack = append(rolledBack, "B") }).Return(nil).Once()
+	scriptC := new(mockplugin.ReversibleMigrationScript)
+	scriptC.On("Version").Return(uint64(3))
+	scriptC.On("Name").Return("C")
+	scriptC.On("Down", mock.Anything).Run(func(mock.Arguments) { rolledBack = append(rolledBack, "C") }).Return(nil).Once()
+	migrator.Register([]plugin.MigrationScript{scriptA, scriptB, scriptC}, "UnitTest")
+	assert.False(t, migrator.HasPendingScripts())
+
+	assert.Nil(t, migrator.Rollback(1))
+	// newer scripts get rolled back first, and become pending again
+	assert.Equal(t, []string{"C", "B"}, rolledBack)
+	assert.True(t, migrator.HasPendingScripts())
+	mockDal.AssertExpectations(t)
+	scriptB.AssertExpectations(t)
+	scriptC.AssertExpectations(t)
+}
+
+func TestRollbackIrreversible(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	migrator := newTestMigrator(t, mockDal)
+	mockDal.On("All", mock.Anything, mock.Anything).Return(func(i interface{}, _ ...dal.Clause) errors.Error {
+		precords := i.(*[]MigrationHistory)
+		*precords = []MigrationHistory{
+			{ScriptName: "A", ScriptVersion: 1, Comment: "UniTest", CreatedAt: time.Now()},
+			{ScriptName: "B", ScriptVersion: 2, Comment: "UniTest", CreatedAt: time.Now()},
+		}
+		return nil
+	}).Once()
+
+	scriptA := new(mockplugin.MigrationScript)
+	scriptA.On("Version").Return(uint64(1))
+	scriptA.On("Name").Return("A")
+	scriptB := new(mockplugin.ReversibleMigrationScript)
+	scriptB.On("Version").Return(uint64(2))
+	scriptB.On("Name").Return("B")
+	migrator.Register([]plugin.MigrationScript{scriptA, scriptB}, "UnitTest")
+
+	// nothing should be rolled back since A is not reversible
+	err := migrator.Rollback(0)
+	assert.NotNil(t, err)
+	assert.Equal(t, errors.BadInput, err.GetType())
+	scriptB.AssertNotCalled(t, "Down", mock.Anything)
+}
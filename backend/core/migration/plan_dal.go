@@ -0,0 +1,262 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"gorm.io/gorm/schema"
+)
+
+var destructiveSqlPattern = regexp.MustCompile(`(?i)\b(drop|delete|truncate|rename)\b`)
+var sqlTablePattern = regexp.MustCompile("(?i)\\b(?:table|into|update|from|join)\\s+(?:if\\s+(?:not\\s+)?exists\\s+)?[`\"]?([\\w.]+)")
+
+// planDal passes reads through to the underlying Dal and records writes instead of executing them,
+// so migration scripts could be simulated to find out the tables they would touch
+type planDal struct {
+	dal.Dal
+	tables      []string
+	destructive bool
+}
+
+var _ dal.Dal = (*planDal)(nil)
+
+func newPlanDal(db dal.Dal) *planDal {
+	return &planDal{Dal: db}
+}
+
+func (p *planDal) touch(destructive bool, tables ...string) {
+	p.destructive = p.destructive || destructive
+	for _, table := range tables {
+		if table == "" {
+			continue
+		}
+		found := false
+		for _, t := range p.tables {
+			if t == table {
+				found = true
+				break
+			}
+		}
+		if !found {
+			p.tables = append(p.tables, table)
+		}
+	}
+}
+
+// AutoMigrate records the table to be created or altered
+func (p *planDal) AutoMigrate(entity interface{}, clauses ...dal.Clause) errors.Error {
+	p.touch(false, tableNameOf(entity, clauses))
+	return nil
+}
+
+// AddColumn records the table to be altered
+func (p *planDal) AddColumn(table, _ string, _ dal.ColumnType) errors.Error {
+	p.touch(false, table)
+	return nil
+}
+
+// DropColumns records the table to lose columns
+func (p *planDal) DropColumns(table string, _ ...string) errors.Error {
+	p.touch(true, table)
+	return nil
+}
+
+// Exec records tables mentioned by the sql statement
+func (p *planDal) Exec(query string, _ ...interface{}) errors.Error {
+	var tables []string
+	for _, match := range sqlTablePattern.FindAllStringSubmatch(query, -1) {
+		tables = append(tables, match[1])
+	}
+	p.touch(destructiveSqlPattern.MatchString(query), tables...)
+	return nil
+}
+
+// Create records the table to be written
+func (p *planDal) Create(entity interface{}, clauses ...dal.Clause) errors.Error {
+	p.touch(false, tableNameOf(entity, clauses))
+	return nil
+}
+
+// CreateWithMap records the table to be written
+func (p *planDal) CreateWithMap(entity interface{}, _ map[string]interface{}) errors.Error {
+	p.touch(false, tableNameOf(entity, nil))
+	return nil
+}
+
+// Update records the table to be written
+func (p *planDal) Update(entity interface{}, clauses ...dal.Clause) errors.Error {
+	p.touch(false, tableNameOf(entity, clauses))
+	return nil
+}
+
+// UpdateColumn records the table to be written
+func (p *planDal) UpdateColumn(entityOrTable interface{}, _ string, _ interface{}, clauses ...dal.Clause) errors.Error {
+	p.touch(false, tableNameOf(entityOrTable, clauses))
+	return nil
+}
+
+// UpdateColumns records the table to be written
+func (p *planDal) UpdateColumns(entityOrTable interface{}, _ []dal.DalSet, clauses ...dal.Clause) errors.Error {
+	p.touch(false, tableNameOf(entityOrTable, clauses))
+	return nil
+}
+
+// UpdateAllColumn records the table to be written
+func (p *planDal) UpdateAllColumn(entity interface{}, clauses ...dal.Clause) errors.Error {
+	p.touch(false, tableNameOf(entity, clauses))
+	return nil
+}
+
+// CreateOrUpdate records the table to be written
+func (p *planDal) CreateOrUpdate(entity interface{}, clauses ...dal.Clause) errors.Error {
+	p.touch(false, tableNameOf(entity, clauses))
+	return nil
+}
+
+// CreateIfNotExist records the table to be written
+func (p *planDal) CreateIfNotExist(entity interface{}, clauses ...dal.Clause) errors.Error {
+	p.touch(false, tableNameOf(entity, clauses))
+	return nil
+}
+
+// Delete records the table to lose records
+func (p *planDal) Delete(entity interface{}, clauses ...dal.Clause) errors.Error {
+	p.touch(true, tableNameOf(entity, clauses))
+	return nil
+}
+
+// DropTables records the tables to be dropped
+func (p *planDal) DropTables(dst ...interface{}) errors.Error {
+	for _, entity := range dst {
+		p.touch(true, tableNameOf(entity, nil))
+	}
+	return nil
+}
+
+// RenameTable records both table names, renaming is considered destructive since queries relying on it would break
+func (p *planDal) RenameTable(oldName, newName string) errors.Error {
+	p.touch(true, oldName, newName)
+	return nil
+}
+
+// RenameColumn records the table to be altered, renaming is considered destructive since queries relying on it would break
+func (p *planDal) RenameColumn(table, _, _ string) errors.Error {
+	p.touch(true, table)
+	return nil
+}
+
+// DropIndexes records the table to be altered
+func (p *planDal) DropIndexes(table string, _ ...string) errors.Error {
+	p.touch(false, table)
+	return nil
+}
+
+// Session returns the planDal itself so writes of the session get recorded as well
+func (p *planDal) Session(_ dal.SessionConfig) dal.Dal {
+	return p
+}
+
+// Begin returns a transaction which records writes to the planDal
+func (p *planDal) Begin() dal.Transaction {
+	return &planTx{planDal: p}
+}
+
+type planTx struct {
+	*planDal
+}
+
+// Rollback does nothing since nothing was written
+func (t *planTx) Rollback() errors.Error {
+	return nil
+}
+
+// Commit does nothing since nothing was written
+func (t *planTx) Commit() errors.Error {
+	return nil
+}
+
+// planBasicRes replaces the Dal of BasicRes with a planDal
+type planBasicRes struct {
+	context.BasicRes
+	db *planDal
+}
+
+// GetDal returns the planDal
+func (r *planBasicRes) GetDal() dal.Dal {
+	return r.db
+}
+
+// NestedLogger returns a new planBasicRes with a new nested logger
+func (r *planBasicRes) NestedLogger(name string) context.BasicRes {
+	return &planBasicRes{BasicRes: r.BasicRes.NestedLogger(name), db: r.db}
+}
+
+// ReplaceLogger returns a new planBasicRes with the specified logger
+func (r *planBasicRes) ReplaceLogger(logger log.Logger) context.BasicRes {
+	return &planBasicRes{BasicRes: r.BasicRes.ReplaceLogger(logger), db: r.db}
+}
+
+// tableNameOf resolves the table name the same way dalgorm does: the From clause comes first, then the entity
+func tableNameOf(entityOrTable interface{}, clauses []dal.Clause) string {
+	for _, c := range clauses {
+		if c.Type != dal.FromClause {
+			continue
+		}
+		switch d := c.Data.(type) {
+		case string:
+			return firstWord(d)
+		case dal.DalClause:
+			return firstWord(d.Expr)
+		case dal.ClauseTable:
+			return d.Name
+		default:
+			return tableNameOf(d, nil)
+		}
+	}
+	switch e := entityOrTable.(type) {
+	case nil:
+		return ""
+	case string:
+		return firstWord(e)
+	case dal.Tabler:
+		return e.TableName()
+	}
+	t := reflect.TypeOf(entityOrTable)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if tabler, ok := reflect.New(t).Interface().(dal.Tabler); ok {
+		return tabler.TableName()
+	}
+	return schema.NamingStrategy{}.TableName(t.Name())
+}
+
+func firstWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], "`\"")
+}
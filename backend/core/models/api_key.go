@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// Roles of ApiKey, a role is granted all permissions of the roles before it
+const (
+	// API_KEY_ROLE_VIEWER may read everything except connections, notification channels and api keys
+	API_KEY_ROLE_VIEWER = "viewer"
+	// API_KEY_ROLE_OPERATOR may trigger, rerun, pause, resume and cancel pipelines as well
+	API_KEY_ROLE_OPERATOR = "operator"
+	// API_KEY_ROLE_ADMIN may do anything, including managing connections, migrations and pushing data
+	API_KEY_ROLE_ADMIN = "admin"
+)
+
+// ApiKey is a token used to authenticate requests to the REST server, only the sha256 hash of the token
+// is stored. A key bound to Projects may only access those projects along with their blueprints and pipelines.
+type ApiKey struct {
+	common.Model
+	Name       string     `json:"name" gorm:"type:varchar(255)" validate:"required"`
+	Role       string     `json:"role" gorm:"type:varchar(20)" validate:"required,oneof=viewer operator admin"`
+	TokenHash  string     `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+	Projects   []string   `json:"projects" gorm:"-"`
+	ExpiredAt  *time.Time `json:"expiredAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	// Token is the plain token, which is only available in the response of the creation
+	Token string `json:"token,omitempty" gorm:"-"`
+}
+
+func (ApiKey) TableName() string {
+	return "_devlake_api_keys"
+}
+
+// ApiKeyProject binds an ApiKey to a project
+type ApiKeyProject struct {
+	ApiKeyId    uint64 `json:"apiKeyId" gorm:"primaryKey"`
+	ProjectName string `json:"projectName" gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (ApiKeyProject) TableName() string {
+	return "_devlake_api_key_projects"
+}
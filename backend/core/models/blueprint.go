@@ -31,6 +31,16 @@ const (
 	BLUEPRINT_MODE_ADVANCED = "ADVANCED"
 )
 
+// Policies on triggering a blueprint while its previous pipeline is not finished yet
+const (
+	// BLUEPRINT_CONCURRENCY_ALLOW creates the new pipeline regardless
+	BLUEPRINT_CONCURRENCY_ALLOW = "ALLOW"
+	// BLUEPRINT_CONCURRENCY_SKIP records the trigger as skipped, it is the default policy
+	BLUEPRINT_CONCURRENCY_SKIP = "SKIP_IF_RUNNING"
+	// BLUEPRINT_CONCURRENCY_REPLACE cancels the previous pipeline and creates the new one
+	BLUEPRINT_CONCURRENCY_REPLACE = "REPLACE_RUNNING"
+)
+
 // @Description CronConfig
 type Blueprint struct {
 	Name        string          `json:"name" validate:"required"`
@@ -45,6 +55,9 @@ type Blueprint struct {
 	Labels       []string        `json:"labels" gorm:"-"`
 	Settings     json.RawMessage `json:"settings" swaggertype:"array,string" example:"please check api: /blueprints/<PLUGIN_NAME>/blueprint-setting" gorm:"serializer:encdec"`
 	common.Model `swaggerignore:"true"`
+
+	// ConcurrencyPolicy is one of ALLOW, SKIP_IF_RUNNING and REPLACE_RUNNING, defaults to SKIP_IF_RUNNING
+	ConcurrencyPolicy string `json:"concurrencyPolicy" gorm:"type:varchar(20)" validate:"omitempty,oneof=ALLOW SKIP_IF_RUNNING REPLACE_RUNNING"`
 }
 
 type BlueprintSettings struct {
@@ -79,3 +92,20 @@ type DbBlueprintLabel struct {
 func (DbBlueprintLabel) TableName() string {
 	return "_devlake_blueprint_labels"
 }
+
+// SkippedBlueprintTrigger records a trigger of the blueprint that didn't create a pipeline because of
+// the ConcurrencyPolicy
+type SkippedBlueprintTrigger struct {
+	ID          uint64    `json:"id" gorm:"primaryKey"`
+	CreatedAt   time.Time `json:"createdAt"`
+	BlueprintId uint64    `json:"blueprintId" gorm:"index"`
+	// TriggeredBy is either cron or api
+	TriggeredBy string `json:"triggeredBy" gorm:"type:varchar(20)"`
+	// PipelineId is the unfinished pipeline which caused the skipping
+	PipelineId uint64 `json:"pipelineId"`
+	Reason     string `json:"reason"`
+}
+
+func (SkippedBlueprintTrigger) TableName() string {
+	return "_devlake_blueprint_skipped_triggers"
+}
@@ -33,3 +33,41 @@ type CollectorLatestState struct {
 func (CollectorLatestState) TableName() string {
 	return "_devlake_collector_latest_state"
 }
+
+// CollectorCheckpoint marks an ApiCollector run which has not finished yet, the next run would resume from the
+// finished CollectorCheckpointUnits instead of starting over if it runs in the same mode
+type CollectorCheckpoint struct {
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	RawDataParams string    `gorm:"primaryKey;column:raw_data_params;type:varchar(255);index" json:"raw_data_params"`
+	RawDataTable  string    `gorm:"primaryKey;column:raw_data_table;type:varchar(255)" json:"raw_data_table"`
+	// CollectorKey tells apart multiple collectors sharing the same raw table, i.e. the finalizable ones
+	CollectorKey string `gorm:"primaryKey;type:varchar(64)" json:"collectorKey"`
+	Incremental  bool   `json:"incremental"`
+}
+
+func (CollectorCheckpoint) TableName() string {
+	return "_devlake_collector_checkpoints"
+}
+
+// CollectorCheckpointUnit is a unit of work, a page or an input, finished by an ApiCollector
+type CollectorCheckpointUnit struct {
+	CreatedAt     time.Time `json:"createdAt"`
+	RawDataParams string    `gorm:"primaryKey;column:raw_data_params;type:varchar(255);index" json:"raw_data_params"`
+	RawDataTable  string    `gorm:"primaryKey;column:raw_data_table;type:varchar(255)" json:"raw_data_table"`
+	CollectorKey  string    `gorm:"primaryKey;type:varchar(64)" json:"collectorKey"`
+	// InputKey is the hash of the input, empty if the collector has no input
+	InputKey string `gorm:"primaryKey;type:varchar(64)" json:"inputKey"`
+	// Page is the page number, 0 means the input is finished as a whole
+	Page  int `gorm:"primaryKey;autoIncrement:false" json:"page"`
+	Items int `json:"items"`
+	// Finished is false for the pages saved by an input or a page that has not finished yet, their raw rows
+	// would be removed before resuming since they are going to be fetched again
+	Finished bool `json:"finished"`
+	// RawIds are the comma separated ids of the raw rows saved by the unfinished page
+	RawIds string `gorm:"type:text" json:"rawIds"`
+}
+
+func (CollectorCheckpointUnit) TableName() string {
+	return "_devlake_collector_checkpoint_units"
+}
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// Release is a published version of a repo, identified by the tag it was cut from.
+// Releases carry no link to issues: the issues of a Jira version are linked through ticket.IssueVersion,
+// and the issues shipped by a release can be derived by refdiff between its tag and the previous one,
+// see crossdomain.RefsIssuesDiffs whose refs are identified by `{RepoId}:refs/tags/{TagName}`
+type Release struct {
+	domainlayer.DomainEntity
+	RepoId        string `gorm:"index;type:varchar(255)"`
+	Name          string `gorm:"type:varchar(255)"`
+	TagName       string `gorm:"type:varchar(255)"`
+	CommitSha     string `gorm:"type:varchar(40)"`
+	Description   string
+	Url           string `gorm:"type:varchar(255)"`
+	AuthorId      string `gorm:"type:varchar(255)"`
+	AuthorName    string `gorm:"type:varchar(255)"`
+	IsDraft       bool
+	IsPrerelease  bool
+	CreatedDate   time.Time
+	PublishedDate *time.Time
+}
+
+func (Release) TableName() string {
+	return "releases"
+}
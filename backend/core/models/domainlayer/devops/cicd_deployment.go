@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// CicdDeployment is a deployment reported natively by the tool, e.g. GitHub Deployments or GitLab Environments,
+// rather than a CICDTask recognized as a deployment by its name
+type CicdDeployment struct {
+	domainlayer.DomainEntity
+	CicdScopeId         string `gorm:"index;type:varchar(255)"`
+	CicdPipelineId      string `gorm:"type:varchar(255)"`
+	Name                string `gorm:"type:varchar(255)"`
+	Result              string `gorm:"type:varchar(100)"`
+	Status              string `gorm:"type:varchar(100)"`
+	OriginalStatus      string `gorm:"type:varchar(100)"`
+	Environment         string `gorm:"type:varchar(255)"`
+	OriginalEnvironment string `gorm:"type:varchar(255)"`
+	RefName             string `gorm:"type:varchar(255)"`
+	RepoId              string `gorm:"index;type:varchar(255)"`
+	CommitSha           string `gorm:"type:varchar(255)"`
+	DeployerId          string `gorm:"type:varchar(255)"`
+	DeployerName        string `gorm:"type:varchar(255)"`
+	Url                 string `gorm:"type:varchar(255)"`
+	CreatedDate         time.Time
+	StartedDate         *time.Time
+	FinishedDate        *time.Time
+	DurationSec         uint64
+}
+
+func (CicdDeployment) TableName() string {
+	return "cicd_deployments"
+}
+
+// GetEnvironment guesses the standard environment from the name of an environment defined in the tool,
+// it returns an empty string if the name looks like none of them
+func GetEnvironment(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "prod"):
+		return PRODUCTION
+	case strings.Contains(name, "stag"):
+		return STAGING
+	case strings.Contains(name, "test"), strings.Contains(name, "qa"):
+		return TESTING
+	}
+	return ""
+}
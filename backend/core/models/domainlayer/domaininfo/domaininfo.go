@@ -41,6 +41,7 @@ func GetDomainTablesInfo() []Tabler {
 		&code.PullRequestCommit{},
 		&code.PullRequestLabel{},
 		&code.Ref{},
+		&code.Release{},
 		&code.CommitsDiff{},
 		&code.RefCommit{},
 		&code.FinishedCommitsDiff{},
@@ -63,6 +64,7 @@ func GetDomainTablesInfo() []Tabler {
 		// devops
 		&devops.CICDPipeline{},
 		&devops.CICDTask{},
+		&devops.CicdDeployment{},
 		// didgen no table
 		// ticket
 		&ticket.Board{},
@@ -72,8 +74,11 @@ func GetDomainTablesInfo() []Tabler {
 		&ticket.IssueChangelogs{},
 		&ticket.IssueComment{},
 		&ticket.IssueLabel{},
+		&ticket.IssueRelationship{},
+		&ticket.IssueVersion{},
 		&ticket.IssueWorklog{},
 		&ticket.Sprint{},
 		&ticket.SprintIssue{},
+		&ticket.Version{},
 	}
 }
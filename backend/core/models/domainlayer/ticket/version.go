@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+const (
+	VERSION_RELEASED   = "RELEASED"
+	VERSION_UNRELEASED = "UNRELEASED"
+	VERSION_ARCHIVED   = "ARCHIVED"
+)
+
+// Version is a planned or shipped release of the work tracked on a board
+type Version struct {
+	domainlayer.DomainEntity
+	BoardId     string `gorm:"index;type:varchar(255)"`
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Url         string `gorm:"type:varchar(255)"`
+	Status      string `gorm:"type:varchar(100)"`
+	StartDate   *time.Time
+	ReleaseDate *time.Time
+}
+
+func (Version) TableName() string {
+	return "versions"
+}
+
+const (
+	ISSUE_VERSION_FIX     = "FIX"
+	ISSUE_VERSION_AFFECTS = "AFFECTS"
+)
+
+// IssueVersion links an issue to a version it is fixed in (FIX) or found in (AFFECTS)
+type IssueVersion struct {
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	VersionId string `gorm:"primaryKey;type:varchar(255)"`
+	Type      string `gorm:"primaryKey;type:varchar(100)"`
+	common.NoPKModel
+}
+
+func (IssueVersion) TableName() string {
+	return "issue_versions"
+}
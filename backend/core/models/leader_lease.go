@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "time"
+
+const LEADER_LEASE_SCHEDULER = "scheduler"
+
+// LeaderLease elects the devlake instance in charge of the blueprint cron and the pipeline queue when multiple
+// instances share the same database. Instances compete for the lease by taking it over conditionally once it
+// expired, and the holder has to renew it before ExpiresAt or it would be taken over by a standby instance
+type LeaderLease struct {
+	Name       string    `gorm:"primaryKey;type:varchar(100)" json:"name"`
+	HolderId   string    `gorm:"type:varchar(255)" json:"holderId"`
+	HolderHost string    `gorm:"type:varchar(255)" json:"holderHost"`
+	Version    string    `gorm:"type:varchar(100)" json:"version"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	RenewedAt  time.Time `json:"renewedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+func (LeaderLease) TableName() string {
+	return "_devlake_leader_leases"
+}
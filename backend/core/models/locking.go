@@ -28,6 +28,8 @@ import "time"
 //
 // NOTE: it works IFF all devlake instances obey the principle described above, in other words, this mechanism can
 // not prevent older versions from sharing the same database
+//
+// Deprecated: instances share the database now and elect the leader by the LeaderLease
 type LockingHistory struct {
 	ID        uint64 `gorm:"primaryKey" json:"id"`
 	HostName  string
@@ -42,6 +44,8 @@ func (LockingHistory) TableName() string {
 }
 
 // LockingStub does nothing but offer a locking target
+//
+// Deprecated: instances share the database now and elect the leader by the LeaderLease
 type LockingStub struct {
 	Stub string
 }
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addNotificationChannels)(nil)
+
+type notification20230301 struct {
+	ChannelId   uint64 `gorm:"index"`
+	PipelineId  uint64 `gorm:"index"`
+	Status      string `gorm:"type:varchar(20);index"`
+	Attempts    int
+	NextRetryAt *time.Time `gorm:"index"`
+	LastError   string
+}
+
+func (notification20230301) TableName() string {
+	return "_devlake_notifications"
+}
+
+type notificationChannel20230301 struct {
+	archived.Model
+	Name        string `gorm:"type:varchar(255)"`
+	Type        string `gorm:"type:varchar(20)"`
+	ProjectName string `gorm:"type:varchar(255);index"`
+	BlueprintId uint64 `gorm:"index"`
+	Endpoint    string
+	Secret      string
+	Settings    string
+	Events      string `gorm:"type:text"`
+	Enable      bool
+}
+
+func (notificationChannel20230301) TableName() string {
+	return "_devlake_notification_channels"
+}
+
+type addNotificationChannels struct{}
+
+func (script *addNotificationChannels) Up(basicRes context.BasicRes) errors.Error {
+	err := migrationhelper.AutoMigrateTables(basicRes, &notification20230301{}, &notificationChannel20230301{})
+	if err != nil {
+		return err
+	}
+	// notifications sent before were attempted exactly once, whether they were delivered is unknown, so their
+	// status is left unset and they would not be retried either
+	return basicRes.GetDal().UpdateColumn(
+		&notification20230301{},
+		"attempts", 1,
+		dal.Where("attempts IS NULL OR attempts = 0"),
+	)
+}
+
+func (script *addNotificationChannels) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropTables(&notificationChannel20230301{})
+	if err != nil {
+		return err
+	}
+	return db.DropColumns(
+		notification20230301{}.TableName(),
+		"channel_id", "pipeline_id", "status", "attempts", "next_retry_at", "last_error",
+	)
+}
+
+func (*addNotificationChannels) Version() uint64 {
+	return 20230301091500
+}
+
+func (*addNotificationChannels) Name() string {
+	return "add _devlake_notification_channels and retrying columns to _devlake_notifications"
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"gorm.io/datatypes"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addMetricsToSubtasks)(nil)
+
+type subtask20230302 struct {
+	Status          string `gorm:"type:varchar(20)"`
+	Message         string
+	FinishedRecords int64
+	TotalRecords    int64
+	RowsWritten     datatypes.JSON
+	ApiRequests     int64
+	ApiRetries      int64
+	RateLimitWaits  int64
+}
+
+func (subtask20230302) TableName() string {
+	return "_devlake_subtasks"
+}
+
+type addMetricsToSubtasks struct{}
+
+func (script *addMetricsToSubtasks) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &subtask20230302{})
+}
+
+func (script *addMetricsToSubtasks) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(
+		subtask20230302{}.TableName(),
+		"status", "message", "finished_records", "total_records",
+		"rows_written", "api_requests", "api_retries", "rate_limit_waits",
+	)
+}
+
+func (*addMetricsToSubtasks) Version() uint64 {
+	return 20230302103000
+}
+
+func (*addMetricsToSubtasks) Name() string {
+	return "add execution metrics to _devlake_subtasks"
+}
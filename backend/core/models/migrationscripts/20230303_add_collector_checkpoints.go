@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addCollectorCheckpoints)(nil)
+
+type collectorCheckpoint20230303 struct {
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	RawDataParams string `gorm:"primaryKey;column:raw_data_params;type:varchar(255);index"`
+	RawDataTable  string `gorm:"primaryKey;column:raw_data_table;type:varchar(255)"`
+	CollectorKey  string `gorm:"primaryKey;type:varchar(64)"`
+	Incremental   bool
+}
+
+func (collectorCheckpoint20230303) TableName() string {
+	return "_devlake_collector_checkpoints"
+}
+
+type collectorCheckpointUnit20230303 struct {
+	CreatedAt     time.Time
+	RawDataParams string `gorm:"primaryKey;column:raw_data_params;type:varchar(255);index"`
+	RawDataTable  string `gorm:"primaryKey;column:raw_data_table;type:varchar(255)"`
+	CollectorKey  string `gorm:"primaryKey;type:varchar(64)"`
+	InputKey      string `gorm:"primaryKey;type:varchar(64)"`
+	Page          int    `gorm:"primaryKey;autoIncrement:false"`
+	Items         int
+	Finished      bool
+	RawIds        string `gorm:"type:text"`
+}
+
+func (collectorCheckpointUnit20230303) TableName() string {
+	return "_devlake_collector_checkpoint_units"
+}
+
+type addCollectorCheckpoints struct{}
+
+func (script *addCollectorCheckpoints) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&collectorCheckpoint20230303{},
+		&collectorCheckpointUnit20230303{},
+	)
+}
+
+func (script *addCollectorCheckpoints) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&collectorCheckpoint20230303{}, &collectorCheckpointUnit20230303{})
+}
+
+func (*addCollectorCheckpoints) Version() uint64 {
+	return 20230303100000
+}
+
+func (*addCollectorCheckpoints) Name() string {
+	return "add collector checkpoints for resumable collection"
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addPauseRequestedToPipelines)(nil)
+
+type pipeline20230304Pause struct {
+	PauseRequested bool
+}
+
+func (pipeline20230304Pause) TableName() string {
+	return "_devlake_pipelines"
+}
+
+type addPauseRequestedToPipelines struct{}
+
+func (script *addPauseRequestedToPipelines) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &pipeline20230304Pause{})
+}
+
+func (script *addPauseRequestedToPipelines) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(pipeline20230304Pause{}.TableName(), "pause_requested")
+}
+
+func (*addPauseRequestedToPipelines) Version() uint64 {
+	return 20230304110000
+}
+
+func (*addPauseRequestedToPipelines) Name() string {
+	return "add pause_requested to _devlake_pipelines"
+}
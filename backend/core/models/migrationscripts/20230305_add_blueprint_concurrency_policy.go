@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addBlueprintConcurrencyPolicy)(nil)
+
+type blueprint20230305 struct {
+	ConcurrencyPolicy string `gorm:"type:varchar(20)"`
+}
+
+func (blueprint20230305) TableName() string {
+	return "_devlake_blueprints"
+}
+
+type skippedBlueprintTrigger20230305 struct {
+	ID          uint64 `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	BlueprintId uint64 `gorm:"index"`
+	TriggeredBy string `gorm:"type:varchar(20)"`
+	PipelineId  uint64
+	Reason      string
+}
+
+func (skippedBlueprintTrigger20230305) TableName() string {
+	return "_devlake_blueprint_skipped_triggers"
+}
+
+type addBlueprintConcurrencyPolicy struct{}
+
+func (script *addBlueprintConcurrencyPolicy) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &blueprint20230305{}, &skippedBlueprintTrigger20230305{})
+}
+
+func (script *addBlueprintConcurrencyPolicy) Down(basicRes context.BasicRes) errors.Error {
+	err := basicRes.GetDal().DropTables(&skippedBlueprintTrigger20230305{})
+	if err != nil {
+		return err
+	}
+	return basicRes.GetDal().DropColumns(blueprint20230305{}.TableName(), "concurrency_policy")
+}
+
+func (*addBlueprintConcurrencyPolicy) Version() uint64 {
+	return 20230305100000
+}
+
+func (*addBlueprintConcurrencyPolicy) Name() string {
+	return "add concurrency_policy to _devlake_blueprints and _devlake_blueprint_skipped_triggers"
+}
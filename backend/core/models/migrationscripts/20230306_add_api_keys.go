@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addApiKeys)(nil)
+
+type apiKey20230306 struct {
+	archived.Model
+	Name       string `gorm:"type:varchar(255)"`
+	Role       string `gorm:"type:varchar(20)"`
+	TokenHash  string `gorm:"type:varchar(64);uniqueIndex"`
+	ExpiredAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+func (apiKey20230306) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type apiKeyProject20230306 struct {
+	ApiKeyId    uint64 `gorm:"primaryKey"`
+	ProjectName string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (apiKeyProject20230306) TableName() string {
+	return "_devlake_api_key_projects"
+}
+
+type addApiKeys struct{}
+
+func (script *addApiKeys) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &apiKey20230306{}, &apiKeyProject20230306{})
+}
+
+func (script *addApiKeys) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&apiKey20230306{}, &apiKeyProject20230306{})
+}
+
+func (*addApiKeys) Version() uint64 {
+	return 20230306100000
+}
+
+func (*addApiKeys) Name() string {
+	return "add _devlake_api_keys and _devlake_api_key_projects"
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addLeaderLeases)(nil)
+
+type leaderLease20230307 struct {
+	Name       string `gorm:"primaryKey;type:varchar(100)"`
+	HolderId   string `gorm:"type:varchar(255)"`
+	HolderHost string `gorm:"type:varchar(255)"`
+	Version    string `gorm:"type:varchar(100)"`
+	AcquiredAt time.Time
+	RenewedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+func (leaderLease20230307) TableName() string {
+	return "_devlake_leader_leases"
+}
+
+type pipeline20230307Cancel struct {
+	CancelRequested bool
+}
+
+func (pipeline20230307Cancel) TableName() string {
+	return "_devlake_pipelines"
+}
+
+type addLeaderLeases struct{}
+
+func (script *addLeaderLeases) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &leaderLease20230307{}, &pipeline20230307Cancel{})
+}
+
+func (script *addLeaderLeases) Down(basicRes context.BasicRes) errors.Error {
+	err := basicRes.GetDal().DropColumns(pipeline20230307Cancel{}.TableName(), "cancel_requested")
+	if err != nil {
+		return err
+	}
+	return basicRes.GetDal().DropTables(&leaderLease20230307{})
+}
+
+func (*addLeaderLeases) Version() uint64 {
+	return 20230307100000
+}
+
+func (*addLeaderLeases) Name() string {
+	return "add _devlake_leader_leases"
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addIssueRelationships)(nil)
+
+type issueRelationship20230311 struct {
+	SourceIssueId string `gorm:"primaryKey;type:varchar(255)"`
+	TargetIssueId string `gorm:"primaryKey;type:varchar(255)"`
+	Type          string `gorm:"primaryKey;type:varchar(100)"`
+	OriginalType  string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (issueRelationship20230311) TableName() string {
+	return "issue_relationships"
+}
+
+type addIssueRelationships struct{}
+
+func (script *addIssueRelationships) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &issueRelationship20230311{})
+}
+
+func (script *addIssueRelationships) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&issueRelationship20230311{})
+}
+
+func (*addIssueRelationships) Version() uint64 {
+	return 20230311000001
+}
+
+func (*addIssueRelationships) Name() string {
+	return "add issue_relationships"
+}
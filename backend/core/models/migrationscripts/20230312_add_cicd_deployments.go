@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addCicdDeployments)(nil)
+
+type cicdDeployment20230312 struct {
+	archived.DomainEntity
+	CicdScopeId         string `gorm:"index;type:varchar(255)"`
+	CicdPipelineId      string `gorm:"type:varchar(255)"`
+	Name                string `gorm:"type:varchar(255)"`
+	Result              string `gorm:"type:varchar(100)"`
+	Status              string `gorm:"type:varchar(100)"`
+	OriginalStatus      string `gorm:"type:varchar(100)"`
+	Environment         string `gorm:"type:varchar(255)"`
+	OriginalEnvironment string `gorm:"type:varchar(255)"`
+	RefName             string `gorm:"type:varchar(255)"`
+	RepoId              string `gorm:"index;type:varchar(255)"`
+	CommitSha           string `gorm:"type:varchar(255)"`
+	DeployerId          string `gorm:"type:varchar(255)"`
+	DeployerName        string `gorm:"type:varchar(255)"`
+	Url                 string `gorm:"type:varchar(255)"`
+	CreatedDate         time.Time
+	StartedDate         *time.Time
+	FinishedDate        *time.Time
+	DurationSec         uint64
+}
+
+func (cicdDeployment20230312) TableName() string {
+	return "cicd_deployments"
+}
+
+type addCicdDeployments struct{}
+
+func (script *addCicdDeployments) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &cicdDeployment20230312{})
+}
+
+func (script *addCicdDeployments) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&cicdDeployment20230312{})
+}
+
+func (*addCicdDeployments) Version() uint64 {
+	return 20230312000001
+}
+
+func (*addCicdDeployments) Name() string {
+	return "add cicd_deployments"
+}
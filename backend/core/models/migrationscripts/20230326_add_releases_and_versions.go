@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addReleasesAndVersions)(nil)
+
+type release20230326 struct {
+	archived.DomainEntity
+	RepoId        string `gorm:"index;type:varchar(255)"`
+	Name          string `gorm:"type:varchar(255)"`
+	TagName       string `gorm:"type:varchar(255)"`
+	CommitSha     string `gorm:"type:varchar(40)"`
+	Description   string
+	Url           string `gorm:"type:varchar(255)"`
+	AuthorId      string `gorm:"type:varchar(255)"`
+	AuthorName    string `gorm:"type:varchar(255)"`
+	IsDraft       bool
+	IsPrerelease  bool
+	CreatedDate   time.Time
+	PublishedDate *time.Time
+}
+
+func (release20230326) TableName() string {
+	return "releases"
+}
+
+type version20230326 struct {
+	archived.DomainEntity
+	BoardId     string `gorm:"index;type:varchar(255)"`
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Url         string `gorm:"type:varchar(255)"`
+	Status      string `gorm:"type:varchar(100)"`
+	StartDate   *time.Time
+	ReleaseDate *time.Time
+}
+
+func (version20230326) TableName() string {
+	return "versions"
+}
+
+type issueVersion20230326 struct {
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	VersionId string `gorm:"primaryKey;type:varchar(255)"`
+	Type      string `gorm:"primaryKey;type:varchar(100)"`
+	archived.NoPKModel
+}
+
+func (issueVersion20230326) TableName() string {
+	return "issue_versions"
+}
+
+type addReleasesAndVersions struct{}
+
+func (script *addReleasesAndVersions) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&release20230326{},
+		&version20230326{},
+		&issueVersion20230326{},
+	)
+}
+
+func (script *addReleasesAndVersions) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(
+		&release20230326{},
+		&version20230326{},
+		&issueVersion20230326{},
+	)
+}
+
+func (*addReleasesAndVersions) Version() uint64 {
+	return 20230326000001
+}
+
+func (*addReleasesAndVersions) Name() string {
+	return "add releases, versions and issue_versions"
+}
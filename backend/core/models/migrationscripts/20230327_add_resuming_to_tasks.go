@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addResumingToTasks)(nil)
+
+type task20230327 struct {
+	Resuming bool
+}
+
+func (task20230327) TableName() string {
+	return "_devlake_tasks"
+}
+
+type addResumingToTasks struct{}
+
+func (script *addResumingToTasks) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &task20230327{})
+}
+
+func (script *addResumingToTasks) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(task20230327{}.TableName(), "resuming")
+}
+
+func (*addResumingToTasks) Version() uint64 {
+	return 20230327000001
+}
+
+func (*addResumingToTasks) Name() string {
+	return "add resuming to _devlake_tasks"
+}
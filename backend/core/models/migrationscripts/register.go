@@ -75,5 +75,17 @@ func All() []plugin.MigrationScript {
 		new(addCommitShaIndex),
 		new(removeCreatedDateAfterFromCollectorMeta20230223),
 		new(addHostNamespaceRepoName),
+		new(addNotificationChannels),
+		new(addMetricsToSubtasks),
+		new(addCollectorCheckpoints),
+		new(addMaxConcurrentTasksToPipelines),
+		new(addPauseRequestedToPipelines),
+		new(addBlueprintConcurrencyPolicy),
+		new(addApiKeys),
+		new(addLeaderLeases),
+		new(addIssueRelationships),
+		new(addCicdDeployments),
+		new(addReleasesAndVersions),
+		new(addResumingToTasks),
 	}
 }
@@ -18,6 +18,9 @@ limitations under the License.
 package models
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/apache/incubator-devlake/core/models/common"
 )
 
@@ -25,19 +28,85 @@ type NotificationType string
 
 const (
 	NotificationPipelineStatusChanged NotificationType = "PipelineStatusChanged"
+	NotificationPipelineStarted       NotificationType = "PipelineStarted"
+	NotificationTaskFailed            NotificationType = "TaskFailed"
+	NotificationPipelineFinished      NotificationType = "PipelineFinished"
+)
+
+const (
+	NOTIFICATION_PENDING   = "PENDING"
+	NOTIFICATION_SUCCEEDED = "SUCCEEDED"
+	NOTIFICATION_FAILED    = "FAILED"
 )
 
-// Notification records notifications sent by lake
+const (
+	NOTIFICATION_CHANNEL_WEBHOOK = "WEBHOOK"
+	NOTIFICATION_CHANNEL_SLACK   = "SLACK"
+	NOTIFICATION_CHANNEL_TEAMS   = "TEAMS"
+	NOTIFICATION_CHANNEL_EMAIL   = "EMAIL"
+)
+
+// Notification records notifications sent by lake, a record stays PENDING until it was delivered
+// successfully or the maximum number of attempts was reached
 type Notification struct {
 	common.Model
 	Type         NotificationType
+	ChannelId    uint64 `gorm:"index"`
+	PipelineId   uint64 `gorm:"index"`
 	Endpoint     string
 	Nonce        string
 	ResponseCode int
 	Response     string
 	Data         string
+	Status       string `gorm:"type:varchar(20);index"`
+	Attempts     int
+	NextRetryAt  *time.Time `gorm:"index"`
+	LastError    string
 }
 
 func (Notification) TableName() string {
 	return "_devlake_notifications"
 }
+
+// NotificationChannel is a destination that notifications would be delivered to. A channel could be bound to
+// a project or a blueprint, and it would receive notifications of all pipelines when neither was set.
+type NotificationChannel struct {
+	common.Model
+	Name        string          `json:"name" gorm:"type:varchar(255)" validate:"required"`
+	Type        string          `json:"type" gorm:"type:varchar(20)" validate:"required,oneof=WEBHOOK SLACK TEAMS EMAIL"`
+	ProjectName string          `json:"projectName" gorm:"type:varchar(255);index"`
+	BlueprintId uint64          `json:"blueprintId" gorm:"index"`
+	Endpoint    string          `json:"endpoint" gorm:"serializer:encdec"`
+	Secret      string          `json:"secret" gorm:"serializer:encdec"`
+	Settings    json.RawMessage `json:"settings" gorm:"serializer:encdec"`
+	Events      []string        `json:"events" gorm:"type:text;serializer:json"`
+	Enable      bool            `json:"enable"`
+}
+
+func (NotificationChannel) TableName() string {
+	return "_devlake_notification_channels"
+}
+
+// Subscribes returns if the channel would like to receive the specified type of notification,
+// all events are subscribed when Events is empty
+func (channel *NotificationChannel) Subscribes(notificationType NotificationType) bool {
+	if len(channel.Events) == 0 {
+		return true
+	}
+	for _, event := range channel.Events {
+		if event == string(notificationType) {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailChannelSettings holds the SMTP settings for the EMAIL channel
+type EmailChannelSettings struct {
+	Host     string   `json:"host" validate:"required"`
+	Port     int      `json:"port" validate:"required"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	From     string   `json:"from" validate:"required"`
+	To       []string `json:"to" validate:"required"`
+}
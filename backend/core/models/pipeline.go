@@ -41,16 +41,27 @@ type Pipeline struct {
 	Stage         int             `json:"stage"`
 	Labels        []string        `json:"labels" gorm:"-"`
 	SkipOnFail    bool            `json:"skipOnFail"`
+	// MaxConcurrentTasks limits how many tasks of the pipeline could run at the same time, 0 means unlimited
+	MaxConcurrentTasks int `json:"maxConcurrentTasks"`
+	// PauseRequested asks the running tasks to stop at the next subtask boundary
+	PauseRequested bool `json:"pauseRequested"`
+	// CancelRequested asks the leader to cancel the pipeline running on it, for requests received by other instances
+	CancelRequested bool `json:"cancelRequested"`
 }
 
 // We use a 2D array because the request body must be an array of a set of tasks
 // to be executed concurrently, while each set is to be executed sequentially.
+// Tasks may declare `dependsOn` to start as soon as the referred tasks are finished
+// instead of waiting for the whole previous set.
 type NewPipeline struct {
-	Name        string              `json:"name"`
-	Plan        plugin.PipelinePlan `json:"plan" swaggertype:"array,string" example:"please check api /pipelines/<PLUGIN_NAME>/pipeline-plan"`
-	Labels      []string            `json:"labels"`
-	SkipOnFail  bool                `json:"skipOnFail"`
-	BlueprintId uint64
+	Name               string              `json:"name"`
+	Plan               plugin.PipelinePlan `json:"plan" swaggertype:"array,string" example:"please check api /pipelines/<PLUGIN_NAME>/pipeline-plan"`
+	Labels             []string            `json:"labels"`
+	SkipOnFail         bool                `json:"skipOnFail"`
+	MaxConcurrentTasks int                 `json:"maxConcurrentTasks"`
+	BlueprintId        uint64
+	// TriggeredBy tells what created the pipeline of the blueprint, cron or api
+	TriggeredBy string `json:"-"`
 }
 
 func (Pipeline) TableName() string {
@@ -34,6 +34,7 @@ const (
 	TASK_FAILED    = "TASK_FAILED"
 	TASK_CANCELLED = "TASK_CANCELLED"
 	TASK_PARTIAL   = "TASK_PARTIAL"
+	TASK_PAUSED    = "TASK_PAUSED"
 )
 
 var PendingTaskStatus = []string{TASK_CREATED, TASK_RERUN, TASK_RUNNING}
@@ -57,6 +58,7 @@ type Task struct {
 	ErrorName      string              `json:"errorName"`
 	Progress       float32             `json:"progress"`
 	ProgressDetail *TaskProgressDetail `json:"progressDetail" gorm:"-"`
+	SubtaskDetails []*Subtask          `json:"subtaskDetails,omitempty" gorm:"-"`
 
 	FailedSubTask string     `json:"failedSubTask"`
 	PipelineId    uint64     `json:"pipelineId" gorm:"index"`
@@ -65,6 +67,9 @@ type Task struct {
 	BeganAt       *time.Time `json:"beganAt"`
 	FinishedAt    *time.Time `json:"finishedAt" gorm:"index"`
 	SpentSeconds  int        `json:"spentSeconds"`
+	// Resuming is set when a paused or interrupted task gets queued again, the subtasks finished by
+	// the previous run would be skipped only in this case
+	Resuming bool `json:"resuming"`
 }
 
 type NewTask struct {
@@ -78,12 +83,20 @@ type NewTask struct {
 
 type Subtask struct {
 	common.Model
-	TaskID       uint64     `json:"task_id" gorm:"index"`
-	Name         string     `json:"name" gorm:"index"`
-	Number       int        `json:"number"`
-	BeganAt      *time.Time `json:"beganAt"`
-	FinishedAt   *time.Time `json:"finishedAt" gorm:"index"`
-	SpentSeconds int64      `json:"spentSeconds"`
+	TaskID          uint64         `json:"task_id" gorm:"index"`
+	Name            string         `json:"name" gorm:"index"`
+	Number          int            `json:"number"`
+	BeganAt         *time.Time     `json:"beganAt"`
+	FinishedAt      *time.Time     `json:"finishedAt" gorm:"index"`
+	SpentSeconds    int64          `json:"spentSeconds"`
+	Status          string         `json:"status" gorm:"type:varchar(20)"`
+	Message         string         `json:"message"`
+	FinishedRecords int64          `json:"finishedRecords"`
+	TotalRecords    int64          `json:"totalRecords"`
+	RowsWritten     datatypes.JSON `json:"rowsWritten"`
+	ApiRequests     int64          `json:"apiRequests"`
+	ApiRetries      int64          `json:"apiRetries"`
+	RateLimitWaits  int64          `json:"rateLimitWaits"`
 }
 
 func (Task) TableName() string {
@@ -28,6 +28,8 @@ type ApiResourceInput struct {
 	Params  map[string]string      // path variables
 	Query   url.Values             // query string
 	Body    map[string]interface{} // json body
+	RawBody []byte                 // json body before decoding, for verifying signatures
+	Header  http.Header
 	Request *http.Request
 }
 
@@ -19,6 +19,7 @@ package plugin
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -30,14 +31,93 @@ type PipelineTask struct {
 	Plugin   string                 `json:"plugin" binding:"required"`
 	Subtasks []string               `json:"subtasks"`
 	Options  map[string]interface{} `json:"options"`
+	// Id identifies the task inside the plan so other tasks could depend on it, optional
+	Id string `json:"id,omitempty"`
+	// DependsOn lists the Ids of tasks to be finished before this one starts, the task would
+	// depend on all tasks of the previous stage if it was left empty
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 // PipelineStage consist of multiple PipelineTasks, they will be executed in parallel
 type PipelineStage []*PipelineTask
 
 // PipelinePlan consist of multiple PipelineStages, they will be executed in sequential order
+// unless tasks declare their own dependencies
 type PipelinePlan []PipelineStage
 
+// PipelineTaskPosition locates a PipelineTask inside a PipelinePlan, both Row and Col start from 1
+type PipelineTaskPosition struct {
+	Row int
+	Col int
+}
+
+// Dependencies returns positions of the tasks each task of the plan has to wait for. Tasks with
+// `DependsOn` wait for the referred tasks only, the others wait for all tasks of the previous
+// non-empty stage. Duplicated or unknown ids and circular dependencies are reported as BadInput
+func (plan PipelinePlan) Dependencies() (map[PipelineTaskPosition][]PipelineTaskPosition, errors.Error) {
+	ids := make(map[string]PipelineTaskPosition)
+	for i, stage := range plan {
+		for j, task := range stage {
+			if task == nil || task.Id == "" {
+				continue
+			}
+			if _, ok := ids[task.Id]; ok {
+				return nil, errors.BadInput.New(fmt.Sprintf("duplicated task id %s in the plan", task.Id))
+			}
+			ids[task.Id] = PipelineTaskPosition{Row: i + 1, Col: j + 1}
+		}
+	}
+	deps := make(map[PipelineTaskPosition][]PipelineTaskPosition)
+	var previous []PipelineTaskPosition
+	for i, stage := range plan {
+		current := make([]PipelineTaskPosition, 0, len(stage))
+		for j, task := range stage {
+			position := PipelineTaskPosition{Row: i + 1, Col: j + 1}
+			current = append(current, position)
+			if task == nil || len(task.DependsOn) == 0 {
+				deps[position] = previous
+				continue
+			}
+			deps[position] = make([]PipelineTaskPosition, 0, len(task.DependsOn))
+			for _, id := range task.DependsOn {
+				dependency, ok := ids[id]
+				if !ok {
+					return nil, errors.BadInput.New(fmt.Sprintf("task %s depends on unknown task %s", task.Plugin, id))
+				}
+				deps[position] = append(deps[position], dependency)
+			}
+		}
+		if len(current) > 0 {
+			previous = current
+		}
+	}
+	// tasks are removed once all their dependencies are removed, those left behind form a cycle
+	resolved := make(map[PipelineTaskPosition]bool, len(deps))
+	for progress := true; progress; {
+		progress = false
+		for position, dependencies := range deps {
+			if resolved[position] {
+				continue
+			}
+			ready := true
+			for _, dependency := range dependencies {
+				if !resolved[dependency] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				resolved[position] = true
+				progress = true
+			}
+		}
+	}
+	if len(resolved) < len(deps) {
+		return nil, errors.BadInput.New("circular dependencies found between tasks of the plan")
+	}
+	return deps, nil
+}
+
 // PluginBlueprintV100 is used to support Blueprint Normal model, for Plugin and Blueprint to
 // collaboarte and generate a sophisticated Pipeline Plan based on User Settings.
 // V100 doesn't support Project, and being deprecated, please use PluginBlueprintV200 instead
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelinePlanDependencies(t *testing.T) {
+	plan := PipelinePlan{
+		{
+			{Plugin: "jira", Id: "jira"},
+			{Plugin: "github", Id: "github"},
+		},
+		{},
+		{
+			{Plugin: "refdiff"},
+			{Plugin: "dora", DependsOn: []string{"github"}},
+		},
+	}
+	deps, err := plan.Dependencies()
+	assert.Nil(t, err)
+	assert.Empty(t, deps[PipelineTaskPosition{Row: 1, Col: 1}])
+	assert.Empty(t, deps[PipelineTaskPosition{Row: 1, Col: 2}])
+	// implicit dependencies skip the empty stage
+	assert.Equal(t, []PipelineTaskPosition{{Row: 1, Col: 1}, {Row: 1, Col: 2}}, deps[PipelineTaskPosition{Row: 3, Col: 1}])
+	assert.Equal(t, []PipelineTaskPosition{{Row: 1, Col: 2}}, deps[PipelineTaskPosition{Row: 3, Col: 2}])
+}
+
+func TestPipelinePlanDependenciesInvalid(t *testing.T) {
+	plans := map[string]PipelinePlan{
+		"duplicated": {{{Plugin: "a", Id: "x"}, {Plugin: "b", Id: "x"}}},
+		"unknown":    {{{Plugin: "a", DependsOn: []string{"y"}}}},
+		"circular": {
+			{{Plugin: "a", Id: "a", DependsOn: []string{"b"}}},
+			{{Plugin: "b", Id: "b", DependsOn: []string{"a"}}},
+		},
+		"self": {{{Plugin: "a", Id: "a", DependsOn: []string{"a"}}}},
+	}
+	for name, plan := range plans {
+		_, err := plan.Dependencies()
+		assert.NotNil(t, err, name)
+		assert.Equal(t, errors.BadInput, err.GetType(), name)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+const (
+	// EncodeKeyIdEnvStr is the id of ENCODE_KEY, values encrypted by a key with id are prefixed by `<id>$`
+	EncodeKeyIdEnvStr = "ENCODE_KEY_ID"
+	// EncodePreviousKeysEnvStr lists keys used before in the format of `id1:key1,id2:key2`, they are
+	// only used for decryption
+	EncodePreviousKeysEnvStr = "ENCODE_PREVIOUS_KEYS"
+)
+
+const encKeyIdSeparator = "$"
+
+var encKeyIdPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// EncKeyring holds the keys for encryption/decryption, new values are always encrypted by the primary key
+// while the previous keys are kept so values encrypted before the rotation are still readable
+type EncKeyring struct {
+	primaryId string
+	keys      map[string]string
+	// ids are ordered by priority, the primary key comes first
+	ids []string
+}
+
+// NewEncKeyring creates a keyring, primaryId could be empty for the legacy format without key id
+func NewEncKeyring(primaryId, primaryKey string, previousKeys map[string]string, previousIds []string) (*EncKeyring, errors.Error) {
+	keyring := &EncKeyring{
+		primaryId: primaryId,
+		keys:      map[string]string{primaryId: primaryKey},
+		ids:       []string{primaryId},
+	}
+	if primaryId != "" && !encKeyIdPattern.MatchString(primaryId) {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid encryption key id %s", primaryId))
+	}
+	for _, id := range previousIds {
+		if !encKeyIdPattern.MatchString(id) {
+			return nil, errors.BadInput.New(fmt.Sprintf("invalid encryption key id %s", id))
+		}
+		if _, ok := keyring.keys[id]; ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("duplicated encryption key id %s", id))
+		}
+		keyring.keys[id] = previousKeys[id]
+		keyring.ids = append(keyring.ids, id)
+	}
+	return keyring, nil
+}
+
+// NewEncKeyringFromConfig creates the keyring from ENCODE_KEY, ENCODE_KEY_ID and ENCODE_PREVIOUS_KEYS
+func NewEncKeyringFromConfig(cfg config.ConfigReader) (*EncKeyring, errors.Error) {
+	previousKeys := make(map[string]string)
+	previousIds := make([]string, 0)
+	for _, item := range strings.Split(cfg.GetString(EncodePreviousKeysEnvStr), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, key, found := strings.Cut(item, ":")
+		if !found || key == "" {
+			return nil, errors.BadInput.New(fmt.Sprintf("%s must be in the format of id1:key1,id2:key2", EncodePreviousKeysEnvStr))
+		}
+		previousKeys[id] = key
+		previousIds = append(previousIds, id)
+	}
+	return NewEncKeyring(
+		strings.TrimSpace(cfg.GetString(EncodeKeyIdEnvStr)),
+		cfg.GetString(EncodeKeyEnvStr),
+		previousKeys,
+		previousIds,
+	)
+}
+
+// PrimaryId returns id of the primary key
+func (k *EncKeyring) PrimaryId() string {
+	return k.primaryId
+}
+
+// Encrypt encrypts the value by the primary key
+func (k *EncKeyring) Encrypt(plainText string) (string, errors.Error) {
+	encrypted, err := Encrypt(k.keys[k.primaryId], plainText)
+	if err != nil {
+		return encrypted, err
+	}
+	if k.primaryId == "" {
+		return encrypted, nil
+	}
+	return k.primaryId + encKeyIdSeparator + encrypted, nil
+}
+
+// Decrypt decrypts the value by the key it was encrypted with, values in the legacy format are tried with
+// all keys by priority
+func (k *EncKeyring) Decrypt(encryptedText string) (string, errors.Error) {
+	if id, encrypted, found := strings.Cut(encryptedText, encKeyIdSeparator); found {
+		key, ok := k.keys[id]
+		if !ok {
+			return encryptedText, errors.Default.New(fmt.Sprintf("encryption key %s is not configured", id))
+		}
+		return Decrypt(key, encrypted)
+	}
+	var lastErr errors.Error
+	for _, id := range k.ids {
+		decrypted, err := Decrypt(k.keys[id], encryptedText)
+		if err == nil {
+			return decrypted, nil
+		}
+		lastErr = err
+	}
+	return encryptedText, lastErr
+}
+
+// IsEncryptedByPrimary tells whether the value was encrypted by the primary key with id, it is always false
+// when the primary key has no id
+func (k *EncKeyring) IsEncryptedByPrimary(encryptedText string) bool {
+	return k.primaryId != "" && strings.HasPrefix(encryptedText, k.primaryId+encKeyIdSeparator)
+}
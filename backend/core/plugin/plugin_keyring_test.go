@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncKeyringRotation(t *testing.T) {
+	legacy, err := NewEncKeyring("", "OLDKEY", nil, nil)
+	assert.Nil(t, err)
+	legacyValue, err := legacy.Encrypt("secret")
+	assert.Nil(t, err)
+	assert.False(t, legacy.IsEncryptedByPrimary(legacyValue))
+
+	v := viper.New()
+	v.Set(EncodeKeyEnvStr, "NEWKEY")
+	v.Set(EncodeKeyIdEnvStr, "k2")
+	v.Set(EncodePreviousKeysEnvStr, "k1:OLDKEY")
+	keyring, err := NewEncKeyringFromConfig(v)
+	assert.Nil(t, err)
+
+	// legacy values are readable by the previous key
+	decrypted, err := keyring.Decrypt(legacyValue)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	newValue, err := keyring.Encrypt("secret")
+	assert.Nil(t, err)
+	assert.Regexp(t, `^k2\$`, newValue)
+	assert.True(t, keyring.IsEncryptedByPrimary(newValue))
+	decrypted, err = keyring.Decrypt(newValue)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	// values of unknown keys are rejected
+	_, err = keyring.Decrypt("k3$" + legacyValue)
+	assert.NotNil(t, err)
+}
+
+func TestNewEncKeyringFromConfigInvalid(t *testing.T) {
+	v := viper.New()
+	v.Set(EncodeKeyEnvStr, "NEWKEY")
+	v.Set(EncodeKeyIdEnvStr, "k1")
+	v.Set(EncodePreviousKeysEnvStr, "k1:OLDKEY")
+	_, err := NewEncKeyringFromConfig(v)
+	assert.NotNil(t, err)
+
+	v.Set(EncodePreviousKeysEnvStr, "OLDKEY")
+	_, err = NewEncKeyringFromConfig(v)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SubTaskMetrics holds the execution statistics of a running subtask, it is safe for concurrent use.
+// All methods are no-op on a nil receiver, so callers don't have to check whether metrics are available.
+type SubTaskMetrics struct {
+	apiRequests     int64
+	apiRetries      int64
+	rateLimitWaits  int64
+	finishedRecords int64
+	totalRecords    int64
+	mu              sync.Mutex
+	rowsWritten     map[string]int64
+}
+
+// SubTaskMetricsHolder is implemented by ExecContexts which keep track of the statistics of subtasks.
+// A SubTaskContext returns its own metrics while a TaskContext returns the metrics of the subtask being executed.
+type SubTaskMetricsHolder interface {
+	GetSubTaskMetrics() *SubTaskMetrics
+}
+
+// GetSubTaskMetrics returns the SubTaskMetrics of the context if it was supported, nil otherwise
+func GetSubTaskMetrics(ctx interface{}) *SubTaskMetrics {
+	if holder, ok := ctx.(SubTaskMetricsHolder); ok {
+		return holder.GetSubTaskMetrics()
+	}
+	return nil
+}
+
+// NewSubTaskMetrics creates an empty SubTaskMetrics
+func NewSubTaskMetrics() *SubTaskMetrics {
+	return &SubTaskMetrics{rowsWritten: make(map[string]int64)}
+}
+
+// IncApiRequests counts an outbound api request
+func (m *SubTaskMetrics) IncApiRequests() {
+	if m != nil {
+		atomic.AddInt64(&m.apiRequests, 1)
+	}
+}
+
+// IncApiRetries counts a retried api request
+func (m *SubTaskMetrics) IncApiRetries() {
+	if m != nil {
+		atomic.AddInt64(&m.apiRetries, 1)
+	}
+}
+
+// IncRateLimitWaits counts a request that was throttled by the remote server
+func (m *SubTaskMetrics) IncRateLimitWaits() {
+	if m != nil {
+		atomic.AddInt64(&m.rateLimitWaits, 1)
+	}
+}
+
+// SetRecords records the progress of the subtask
+func (m *SubTaskMetrics) SetRecords(finished int, total int) {
+	if m != nil {
+		atomic.StoreInt64(&m.finishedRecords, int64(finished))
+		atomic.StoreInt64(&m.totalRecords, int64(total))
+	}
+}
+
+// AddRowsWritten counts rows written into the table
+func (m *SubTaskMetrics) AddRowsWritten(table string, rows int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rowsWritten == nil {
+		m.rowsWritten = make(map[string]int64)
+	}
+	m.rowsWritten[table] += int64(rows)
+}
+
+// ApiRequests returns number of outbound api requests
+func (m *SubTaskMetrics) ApiRequests() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.apiRequests)
+}
+
+// ApiRetries returns number of retried api requests
+func (m *SubTaskMetrics) ApiRetries() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.apiRetries)
+}
+
+// RateLimitWaits returns number of requests throttled by the remote server
+func (m *SubTaskMetrics) RateLimitWaits() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.rateLimitWaits)
+}
+
+// FinishedRecords returns number of records processed
+func (m *SubTaskMetrics) FinishedRecords() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.finishedRecords)
+}
+
+// TotalRecords returns number of records to be processed, -1 if unknown
+func (m *SubTaskMetrics) TotalRecords() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.totalRecords)
+}
+
+// RowsWritten returns a copy of rows written per table
+func (m *SubTaskMetrics) RowsWritten() map[string]int64 {
+	rowsWritten := make(map[string]int64)
+	if m == nil {
+		return rowsWritten
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for table, rows := range m.rowsWritten {
+		rowsWritten[table] = rows
+	}
+	return rowsWritten
+}
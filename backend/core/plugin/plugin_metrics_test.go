@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubTaskMetrics(t *testing.T) {
+	metrics := NewSubTaskMetrics()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			metrics.IncApiRequests()
+			metrics.AddRowsWritten("issues", 5)
+		}()
+	}
+	wg.Wait()
+	metrics.IncApiRetries()
+	metrics.IncRateLimitWaits()
+	metrics.SetRecords(7, 10)
+
+	assert.Equal(t, int64(10), metrics.ApiRequests())
+	assert.Equal(t, int64(1), metrics.ApiRetries())
+	assert.Equal(t, int64(1), metrics.RateLimitWaits())
+	assert.Equal(t, int64(7), metrics.FinishedRecords())
+	assert.Equal(t, int64(10), metrics.TotalRecords())
+	assert.Equal(t, map[string]int64{"issues": 50}, metrics.RowsWritten())
+}
+
+func TestNilSubTaskMetrics(t *testing.T) {
+	metrics := GetSubTaskMetrics(struct{}{})
+	assert.Nil(t, metrics)
+	metrics.IncApiRequests()
+	metrics.AddRowsWritten("issues", 1)
+	assert.Equal(t, int64(0), metrics.ApiRequests())
+	assert.Empty(t, metrics.RowsWritten())
+}
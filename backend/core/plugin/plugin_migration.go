@@ -29,11 +29,33 @@ type MigrationScript interface {
 	Name() string
 }
 
+// ReversibleMigrationScript is implemented by migration scripts that could be rolled back, Down should revert
+// whatever Up did to the database
+type ReversibleMigrationScript interface {
+	MigrationScript
+	Down(basicRes context.BasicRes) errors.Error
+}
+
+// MigrationPlanItem describes a pending migration script and what it would do to the database
+type MigrationPlanItem struct {
+	Version         uint64   `json:"version"`
+	Name            string   `json:"name"`
+	Comment         string   `json:"comment"`
+	Tables          []string `json:"tables"`
+	Destructive     bool     `json:"destructive"`
+	Reversible      bool     `json:"reversible"`
+	SimulationError string   `json:"simulationError,omitempty"`
+}
+
 // Migrator is responsible for making sure the registered scripts get applied to database and only once
 type Migrator interface {
 	Register(scripts []MigrationScript, comment string)
 	Execute() errors.Error
 	HasPendingScripts() bool
+	// Plan simulates all pending scripts without writing to the database and returns what each of them would do
+	Plan() ([]*MigrationPlanItem, errors.Error)
+	// Rollback reverts executed scripts newer than the specified version in reverse order
+	Rollback(version uint64) errors.Error
 }
 
 // PluginMigration is implemented by the plugin to declare all migration script that have to be applied to the database
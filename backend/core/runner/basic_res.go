@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
 	"github.com/apache/incubator-devlake/impls/dalgorm"
 	"github.com/apache/incubator-devlake/impls/logruslog"
@@ -49,7 +50,14 @@ func CreateAppBasicRes() context.BasicRes {
 	if err != nil {
 		panic(err)
 	}
-	dalgorm.Init(cfg.GetString(plugin.EncodeKeyEnvStr))
+	keyring, err := plugin.NewEncKeyringFromConfig(cfg)
+	if err != nil {
+		panic(err)
+	}
+	dalgorm.Init(keyring)
+	if err := tracing.Init(cfg, logger); err != nil {
+		panic(err)
+	}
 	return CreateBasicRes(cfg, logger, db)
 }
 
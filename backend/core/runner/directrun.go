@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
 	"github.com/spf13/cobra"
 	"io"
 	"os"
@@ -48,6 +49,7 @@ func RunCmd(cmd *cobra.Command) {
 // options: plugin config
 func DirectRun(cmd *cobra.Command, args []string, pluginTask plugin.PluginTask, options map[string]interface{}) {
 	basicRes := CreateAppBasicRes()
+	defer tracing.Shutdown()
 	tasks, err := cmd.Flags().GetStringSlice("subtasks")
 	if err != nil {
 		panic(err)
@@ -19,69 +19,208 @@ package runner
 
 import (
 	gocontext "context"
+	"encoding/json"
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"sort"
 	"time"
 )
 
-// RunPipeline FIXME ...
+// RunPipeline runs the tasks of the pipeline wave by wave, a wave consists of the tasks whose dependencies
+// were all satisfied by the previous waves and is handed over to `runTasks` at once. It is meant for executors
+// which can't start tasks individually, use RunPipelineDag otherwise
 func RunPipeline(
 	basicRes context.BasicRes,
 	pipelineId uint64,
 	runTasks func([]uint64) errors.Error,
 ) errors.Error {
-	// load tasks for pipeline
+	dbPipeline, tasks, deps, err := loadPipelineDag(basicRes, pipelineId)
+	if err != nil {
+		return err
+	}
+	return runPipelineTasks(basicRes, dbPipeline, groupTasksIntoWaves(dbPipeline, tasks, deps), runTasks)
+}
+
+// RunPipelineDag runs each task of the pipeline as soon as all tasks it depends on are finished,
+// no more than `pipeline.MaxConcurrentTasks` tasks would be running at the same time.
+// No more tasks would be started once the pipeline was asked to pause
+func RunPipelineDag(
+	basicRes context.BasicRes,
+	pipelineId uint64,
+	runTask func(uint64) errors.Error,
+) errors.Error {
+	dbPipeline, tasks, deps, err := loadPipelineDag(basicRes, pipelineId)
+	if err != nil {
+		return err
+	}
+	return runPipelineDag(basicRes, dbPipeline, tasks, deps, runTask)
+}
+
+// loadPipelineDag loads the pipeline along with its tasks to be run, and the ids of tasks each of them depends on
+func loadPipelineDag(basicRes context.BasicRes, pipelineId uint64) (*models.Pipeline, []*models.Task, map[uint64][]uint64, errors.Error) {
 	db := basicRes.GetDal()
-	var tasks []models.Task
-	err := db.All(
+	dbPipeline := &models.Pipeline{}
+	err := db.First(dbPipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var tasks []*models.Task
+	err = db.All(
 		&tasks,
 		dal.Where("pipeline_id = ? AND status in ?", pipelineId, []string{models.TASK_CREATED, models.TASK_RERUN}),
 		dal.Orderby("pipeline_row, pipeline_col"),
 	)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
+	}
+	var plan plugin.PipelinePlan
+	if len(dbPipeline.Plan) > 0 {
+		err = errors.Convert(json.Unmarshal(dbPipeline.Plan, &plan))
+		if err != nil {
+			return nil, nil, nil, errors.Default.Wrap(err, "failed to parse the plan of pipeline")
+		}
+	}
+	deps, err := resolveTaskDependencies(plan, tasks)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return dbPipeline, tasks, deps, nil
+}
+
+// resolveTaskDependencies maps the dependencies declared by the plan to the tasks to be run. Dependencies
+// which are not going to run (i.e. completed tasks when rerunning a pipeline) are replaced by their own
+// dependencies, and tasks unknown to the plan wait for all tasks of the previous rows.
+func resolveTaskDependencies(plan plugin.PipelinePlan, tasks []*models.Task) (map[uint64][]uint64, errors.Error) {
+	positions, err := plan.Dependencies()
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[plugin.PipelineTaskPosition]uint64, len(tasks))
+	for _, task := range tasks {
+		pending[plugin.PipelineTaskPosition{Row: task.PipelineRow, Col: task.PipelineCol}] = task.ID
+	}
+	upstream := func(position plugin.PipelineTaskPosition) []plugin.PipelineTaskPosition {
+		if dependencies, ok := positions[position]; ok {
+			return dependencies
+		}
+		dependencies := make([]plugin.PipelineTaskPosition, 0)
+		for _, task := range tasks {
+			if task.PipelineRow < position.Row {
+				dependencies = append(dependencies, plugin.PipelineTaskPosition{Row: task.PipelineRow, Col: task.PipelineCol})
+			}
+		}
+		return dependencies
+	}
+	resolved := make(map[plugin.PipelineTaskPosition][]uint64)
+	var resolve func(position plugin.PipelineTaskPosition) []uint64
+	resolve = func(position plugin.PipelineTaskPosition) []uint64 {
+		if taskIds, ok := resolved[position]; ok {
+			return taskIds
+		}
+		taskIds := make([]uint64, 0)
+		seen := make(map[uint64]bool)
+		for _, dependency := range upstream(position) {
+			dependencyTaskIds, ok := []uint64{pending[dependency]}, true
+			if _, ok = pending[dependency]; !ok {
+				dependencyTaskIds = resolve(dependency)
+			}
+			for _, taskId := range dependencyTaskIds {
+				if !seen[taskId] {
+					seen[taskId] = true
+					taskIds = append(taskIds, taskId)
+				}
+			}
+		}
+		resolved[position] = taskIds
+		return taskIds
+	}
+	deps := make(map[uint64][]uint64, len(tasks))
+	for _, task := range tasks {
+		deps[task.ID] = resolve(plugin.PipelineTaskPosition{Row: task.PipelineRow, Col: task.PipelineCol})
+	}
+	return deps, nil
+}
+
+// groupTasksIntoWaves puts every task into the wave right after the last wave of its dependencies,
+// waves are split further to respect `pipeline.MaxConcurrentTasks`
+func groupTasksIntoWaves(dbPipeline *models.Pipeline, tasks []*models.Task, deps map[uint64][]uint64) [][]*models.Task {
+	levels := make(map[uint64]int, len(tasks))
+	var levelOf func(taskId uint64) int
+	levelOf = func(taskId uint64) int {
+		if level, ok := levels[taskId]; ok {
+			return level
+		}
+		level := 0
+		for _, dependency := range deps[taskId] {
+			if l := levelOf(dependency) + 1; l > level {
+				level = l
+			}
+		}
+		levels[taskId] = level
+		return level
 	}
-	taskIds := make([][]uint64, 0)
+	waves := make([][]*models.Task, 0)
 	for _, task := range tasks {
-		for len(taskIds) < task.PipelineRow {
-			taskIds = append(taskIds, make([]uint64, 0))
+		level := levelOf(task.ID)
+		for len(waves) <= level {
+			waves = append(waves, make([]*models.Task, 0))
+		}
+		waves[level] = append(waves[level], task)
+	}
+	if dbPipeline.MaxConcurrentTasks <= 0 {
+		return waves
+	}
+	limited := make([][]*models.Task, 0, len(waves))
+	for _, wave := range waves {
+		for len(wave) > dbPipeline.MaxConcurrentTasks {
+			limited = append(limited, wave[:dbPipeline.MaxConcurrentTasks])
+			wave = wave[dbPipeline.MaxConcurrentTasks:]
 		}
-		taskIds[task.PipelineRow-1] = append(taskIds[task.PipelineRow-1], task.ID)
+		limited = append(limited, wave)
 	}
-	return runPipelineTasks(basicRes, pipelineId, taskIds, runTasks)
+	return limited
 }
 
 func runPipelineTasks(
 	basicRes context.BasicRes,
-	pipelineId uint64,
-	taskIds [][]uint64,
+	dbPipeline *models.Pipeline,
+	waves [][]*models.Task,
 	runTasks func([]uint64) errors.Error,
 ) errors.Error {
 	db := basicRes.GetDal()
 	log := basicRes.GetLogger()
-	// load pipeline from db
-	dbPipeline := &models.Pipeline{}
-	err := db.First(dbPipeline, dal.Where("id = ?", pipelineId))
-	if err != nil {
-		return err
-	}
+	var err errors.Error
 
-	// This double for loop executes each set of tasks sequentially while
-	// executing the set of tasks concurrently.
-	for i, row := range taskIds {
+	// This double for loop executes each wave of tasks sequentially while
+	// executing the tasks of a wave concurrently.
+	for _, wave := range waves {
+		if isPipelinePauseRequested(basicRes, dbPipeline.ID) {
+			log.Info("pipeline paused")
+			return nil
+		}
 		// update stage
 		err = db.UpdateColumns(dbPipeline, []dal.DalSet{
 			{ColumnName: "status", Value: models.TASK_RUNNING},
-			{ColumnName: "stage", Value: i + 1},
+			{ColumnName: "stage", Value: wave[len(wave)-1].PipelineRow},
 		})
 		if err != nil {
 			log.Error(err, "update pipeline state failed")
 			break
 		}
+		taskIds := make([]uint64, len(wave))
+		for i, task := range wave {
+			taskIds[i] = task.ID
+		}
 		// run tasks in parallel
-		err = runTasks(row)
+		err = runTasks(taskIds)
+		if err != nil && isPipelinePauseRequested(basicRes, dbPipeline.ID) {
+			// the paused tasks are to be resumed, the status of each task was saved by itself
+			log.Info("pipeline paused: %v", err)
+			return nil
+		}
 		if err != nil {
 			log.Error(err, "run tasks failed")
 			if errors.Is(err, gocontext.Canceled) || !dbPipeline.SkipOnFail {
@@ -93,3 +232,99 @@ func runPipelineTasks(
 	log.Info("pipeline finished in %d ms: %v", time.Now().UnixMilli()-dbPipeline.BeganAt.UnixMilli(), err)
 	return err
 }
+
+type taskResult struct {
+	task *models.Task
+	err  errors.Error
+}
+
+func runPipelineDag(
+	basicRes context.BasicRes,
+	dbPipeline *models.Pipeline,
+	tasks []*models.Task,
+	deps map[uint64][]uint64,
+	runTask func(uint64) errors.Error,
+) errors.Error {
+	db := basicRes.GetDal()
+	log := basicRes.GetLogger()
+
+	waiting := make(map[uint64]int, len(tasks))
+	dependents := make(map[uint64][]*models.Task)
+	ready := make([]*models.Task, 0)
+	for _, task := range tasks {
+		waiting[task.ID] = len(deps[task.ID])
+		for _, dependency := range deps[task.ID] {
+			dependents[dependency] = append(dependents[dependency], task)
+		}
+		if waiting[task.ID] == 0 {
+			ready = append(ready, task)
+		}
+	}
+
+	var err errors.Error
+	results := make(chan taskResult)
+	running, stage, stopped := 0, 0, false
+	for {
+		// start as many ready tasks as allowed, tasks from the earlier stages first
+		for !stopped && len(ready) > 0 && (dbPipeline.MaxConcurrentTasks <= 0 || running < dbPipeline.MaxConcurrentTasks) {
+			if isPipelinePauseRequested(basicRes, dbPipeline.ID) {
+				log.Info("pipeline paused, no more tasks would be started")
+				stopped = true
+				break
+			}
+			task := ready[0]
+			if task.PipelineRow > stage {
+				e := db.UpdateColumns(dbPipeline, []dal.DalSet{
+					{ColumnName: "status", Value: models.TASK_RUNNING},
+					{ColumnName: "stage", Value: task.PipelineRow},
+				})
+				if e != nil {
+					log.Error(e, "update pipeline state failed")
+					err, stopped = e, true
+					break
+				}
+				stage = task.PipelineRow
+			}
+			ready = ready[1:]
+			running++
+			go func() {
+				results <- taskResult{task: task, err: runTask(task.ID)}
+			}()
+		}
+		if running == 0 {
+			break
+		}
+		result := <-results
+		running--
+		if result.err != nil && isTaskPaused(basicRes, result.task.ID) {
+			log.Info("task #%d paused", result.task.ID)
+			stopped = true
+			continue
+		}
+		if result.err != nil {
+			log.Error(result.err, "run task #%d failed", result.task.ID)
+			err = result.err
+			if errors.Is(err, gocontext.Canceled) || !dbPipeline.SkipOnFail {
+				// wait for the running tasks but start no more
+				stopped = true
+				continue
+			}
+		}
+		for _, dependent := range dependents[result.task.ID] {
+			waiting[dependent.ID]--
+			if waiting[dependent.ID] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+		sort.SliceStable(ready, func(i, j int) bool {
+			if ready[i].PipelineRow != ready[j].PipelineRow {
+				return ready[i].PipelineRow < ready[j].PipelineRow
+			}
+			return ready[i].PipelineCol < ready[j].PipelineCol
+		})
+	}
+	if dbPipeline.BeganAt != nil {
+		log.Info("pipeline finished in %d ms: %v", time.Now().UnixMilli()-dbPipeline.BeganAt.UnixMilli(), err)
+	}
+	return err
+}
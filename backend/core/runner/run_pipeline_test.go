@@ -0,0 +1,183 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// jira(1) github(2) in stage 1, refdiff(3) waits for the whole stage while dora(4) waits for github only
+var testPlan = plugin.PipelinePlan{
+	{
+		{Plugin: "jira", Id: "jira"},
+		{Plugin: "github", Id: "github"},
+	},
+	{
+		{Plugin: "refdiff"},
+		{Plugin: "dora", DependsOn: []string{"github"}},
+	},
+}
+
+func testTasks() []*models.Task {
+	return []*models.Task{
+		{Model: common.Model{ID: 1}, PipelineRow: 1, PipelineCol: 1},
+		{Model: common.Model{ID: 2}, PipelineRow: 1, PipelineCol: 2},
+		{Model: common.Model{ID: 3}, PipelineRow: 2, PipelineCol: 1},
+		{Model: common.Model{ID: 4}, PipelineRow: 2, PipelineCol: 2},
+	}
+}
+
+func TestResolveTaskDependencies(t *testing.T) {
+	deps, err := resolveTaskDependencies(testPlan, testTasks())
+	assert.Nil(t, err)
+	assert.Equal(t, map[uint64][]uint64{1: {}, 2: {}, 3: {1, 2}, 4: {2}}, deps)
+
+	// rerunning jira and dora only, dora doesn't need to wait for the completed github task
+	tasks := testTasks()
+	deps, err = resolveTaskDependencies(testPlan, []*models.Task{tasks[0], tasks[3]})
+	assert.Nil(t, err)
+	assert.Equal(t, map[uint64][]uint64{1: {}, 4: {}}, deps)
+
+	// without a plan the rows are executed one after another
+	deps, err = resolveTaskDependencies(nil, testTasks())
+	assert.Nil(t, err)
+	assert.Equal(t, map[uint64][]uint64{1: {}, 2: {}, 3: {1, 2}, 4: {1, 2}}, deps)
+}
+
+func TestGroupTasksIntoWaves(t *testing.T) {
+	tasks := testTasks()
+	deps, err := resolveTaskDependencies(testPlan, tasks)
+	assert.Nil(t, err)
+	waves := groupTasksIntoWaves(&models.Pipeline{}, tasks, deps)
+	assert.Equal(t, [][]*models.Task{{tasks[0], tasks[1]}, {tasks[2], tasks[3]}}, waves)
+	waves = groupTasksIntoWaves(&models.Pipeline{MaxConcurrentTasks: 1}, tasks, deps)
+	assert.Len(t, waves, 4)
+}
+
+func TestRunPipelineDag(t *testing.T) {
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("UpdateColumns", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockDal.On("Count", mock.Anything).Return(int64(0), nil)
+	})
+	tasks := testTasks()
+	deps, err := resolveTaskDependencies(testPlan, tasks)
+	assert.Nil(t, err)
+
+	// jira is slow, dora must not wait for it while refdiff must
+	var mu sync.Mutex
+	finished := make([]uint64, 0)
+	running, maxRunning := 0, 0
+	err = runPipelineDag(basicRes, &models.Pipeline{MaxConcurrentTasks: 2}, tasks, deps, func(taskId uint64) errors.Error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		if taskId == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		mu.Lock()
+		running--
+		finished = append(finished, taskId)
+		mu.Unlock()
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 4, 1, 3}, finished)
+	assert.LessOrEqual(t, maxRunning, 2)
+}
+
+func TestRunPipelineDagFailure(t *testing.T) {
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("UpdateColumns", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockDal.On("Count", mock.Anything).Return(int64(0), nil)
+	})
+	tasks := testTasks()
+	deps, err := resolveTaskDependencies(testPlan, tasks)
+	assert.Nil(t, err)
+
+	run := func(skipOnFail bool) []uint64 {
+		var mu sync.Mutex
+		started := make([]uint64, 0)
+		err := runPipelineDag(basicRes, &models.Pipeline{SkipOnFail: skipOnFail, MaxConcurrentTasks: 1}, tasks, deps, func(taskId uint64) errors.Error {
+			mu.Lock()
+			started = append(started, taskId)
+			mu.Unlock()
+			if taskId == 2 {
+				return errors.Default.New("github failed")
+			}
+			return nil
+		})
+		assert.NotNil(t, err)
+		return started
+	}
+	assert.Equal(t, []uint64{1, 2}, run(false))
+	assert.Equal(t, []uint64{1, 2, 3, 4}, run(true))
+}
+
+func TestRunPipelineDagPause(t *testing.T) {
+	var pauseRequested, pausedTask atomic.Uint64
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("UpdateColumns", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockDal.On("Count", mock.Anything).Return(func(clauses ...dal.Clause) int64 {
+			if _, ok := clauses[0].Data.(*models.Pipeline); ok {
+				return int64(pauseRequested.Load())
+			}
+			taskId := clauses[1].Data.(dal.DalClause).Params[0].(uint64)
+			if taskId == pausedTask.Load() {
+				return 1
+			}
+			return 0
+		}, nil)
+	})
+	tasks := testTasks()
+	deps, err := resolveTaskDependencies(testPlan, tasks)
+	assert.Nil(t, err)
+
+	// github gets paused while jira is running, none of the stage 2 tasks should be started
+	var mu sync.Mutex
+	started := make([]uint64, 0)
+	err = runPipelineDag(basicRes, &models.Pipeline{}, tasks, deps, func(taskId uint64) errors.Error {
+		mu.Lock()
+		started = append(started, taskId)
+		mu.Unlock()
+		if taskId == 2 {
+			pauseRequested.Store(1)
+			pausedTask.Store(2)
+			return ErrTaskPaused
+		}
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []uint64{1, 2}, started)
+}
@@ -19,13 +19,16 @@ package runner
 
 import (
 	gocontext "context"
+	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
@@ -33,6 +36,15 @@ import (
 	"time"
 )
 
+// ErrTaskPaused is returned by RunPluginSubTasks when the pipeline was asked to pause, the task
+// would skip the subtasks finished so far once it is resumed
+var ErrTaskPaused = errors.Default.New("task paused")
+
+// contextSetter is implemented by the exec contexts whose go context could be replaced
+type contextSetter interface {
+	SetContext(ctx gocontext.Context)
+}
+
 // RunTask FIXME ...
 func RunTask(
 	ctx gocontext.Context,
@@ -56,6 +68,16 @@ func RunTask(
 	if err != nil {
 		return err
 	}
+	ctx, span := tracing.Start(
+		ctx,
+		fmt.Sprintf("task %s", task.Plugin),
+		tracing.ATTR_PIPELINE_ID.Int64(int64(task.PipelineId)),
+		tracing.ATTR_TASK_ID.Int64(int64(task.ID)),
+		tracing.ATTR_PLUGIN.String(task.Plugin),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
 	beganAt := time.Now()
 	// make sure task status always correct even if it panicked
 	defer func() {
@@ -73,6 +95,28 @@ func RunTask(
 		}
 		finishedAt := time.Now()
 		spentSeconds := finishedAt.Unix() - beganAt.Unix()
+		if err != nil && errors.Is(err, ErrTaskPaused) {
+			metrics.ObserveTaskFinished(task.Plugin, models.TASK_PAUSED, finishedAt.Sub(beganAt))
+			dbe := db.UpdateColumns(task, []dal.DalSet{
+				{ColumnName: "status", Value: models.TASK_PAUSED},
+				{ColumnName: "spent_seconds", Value: spentSeconds},
+			})
+			if dbe != nil {
+				logger.Error(dbe, "failed to finalize task status into db (task paused)")
+			}
+			// the task is not finished yet, leave pipeline.finished_tasks alone
+			return
+		}
+		// tasks stopped by the user are accounted separately from the failed ones in the metrics only, they are
+		// still persisted as failed
+		metricStatus := models.TASK_COMPLETED
+		if err != nil {
+			metricStatus = models.TASK_FAILED
+			if errors.Is(err, gocontext.Canceled) {
+				metricStatus = models.TASK_CANCELLED
+			}
+		}
+		metrics.ObserveTaskFinished(task.Plugin, metricStatus, finishedAt.Sub(beganAt))
 		if err != nil {
 			lakeErr := errors.AsLakeErrorType(err)
 			subTaskName := "unknown"
@@ -88,6 +132,7 @@ func RunTask(
 				{ColumnName: "message", Value: lakeErr.Error()},
 				{ColumnName: "error_name", Value: lakeErr.Messages().Format()},
 				{ColumnName: "finished_at", Value: finishedAt},
+				{ColumnName: "resuming", Value: false},
 				{ColumnName: "spent_seconds", Value: spentSeconds},
 				{ColumnName: "failed_sub_task", Value: subTaskName},
 			})
@@ -99,6 +144,7 @@ func RunTask(
 				{ColumnName: "status", Value: models.TASK_COMPLETED},
 				{ColumnName: "message", Value: ""},
 				{ColumnName: "finished_at", Value: finishedAt},
+				{ColumnName: "resuming", Value: false},
 				{ColumnName: "spent_seconds", Value: spentSeconds},
 			})
 			if dbe != nil {
@@ -241,6 +287,13 @@ func RunPluginSubTasks(
 	}
 	taskCtx.SetData(taskData)
 
+	// subtasks finished before the pipeline was paused or the process was terminated are not to be executed again
+	// when the task is resumed
+	finishedSubtasks, err := getFinishedSubtasks(basicRes.GetDal(), task)
+	if err != nil {
+		return err
+	}
+
 	// execute subtasks in order
 	taskCtx.SetProgress(0, steps)
 	subtaskNumber := 0
@@ -254,6 +307,16 @@ func RunPluginSubTasks(
 			// subtask was disabled
 			continue
 		}
+		if finishedSubtasks[subtaskMeta.Name] {
+			logger.Info("skipping subtask %s which was finished before", subtaskMeta.Name)
+			subtaskNumber++
+			taskCtx.IncProgress(1)
+			continue
+		}
+		if isPipelinePauseRequested(basicRes, task.PipelineId) {
+			logger.Info("pipeline paused before subtask %s", subtaskMeta.Name)
+			return ErrTaskPaused
+		}
 
 		// run subtask
 		logger.Info("executing subtask %s", subtaskMeta.Name)
@@ -265,7 +328,7 @@ func RunPluginSubTasks(
 				SubTaskNumber: subtaskNumber,
 			}
 		}
-		err = runSubtask(basicRes, subtaskCtx, task.ID, subtaskNumber, subtaskMeta.EntryPoint)
+		err = runSubtask(basicRes, subtaskCtx, task, subtaskNumber, subtaskMeta.EntryPoint)
 		if err != nil {
 			err = errors.SubtaskErr.Wrap(err, fmt.Sprintf("subtask %s ended unexpectedly", subtaskMeta.Name), errors.WithData(&subtaskMeta))
 			logger.Error(err, "")
@@ -307,34 +370,161 @@ func UpdateProgressDetail(basicRes context.BasicRes, taskId uint64, progressDeta
 func runSubtask(
 	basicRes context.BasicRes,
 	ctx plugin.SubTaskContext,
-	parentID uint64,
+	task *models.Task,
 	subtaskNumber int,
 	entryPoint plugin.SubTaskEntryPoint,
-) errors.Error {
+) (err errors.Error) {
+	spanCtx, span := tracing.Start(
+		ctx.GetContext(),
+		fmt.Sprintf("subtask %s", ctx.GetName()),
+		tracing.ATTR_TASK_ID.Int64(int64(task.ID)),
+		tracing.ATTR_PLUGIN.String(task.Plugin),
+		tracing.ATTR_SUBTASK.String(ctx.GetName()),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+	// let the requests and flushes of the subtask be children of its span
+	if setter, ok := ctx.(contextSetter); ok {
+		setter.SetContext(spanCtx)
+	}
 	beginAt := time.Now()
 	subtask := &models.Subtask{
 		Name:    ctx.GetName(),
-		TaskID:  parentID,
+		TaskID:  task.ID,
 		Number:  subtaskNumber,
 		BeganAt: &beginAt,
+		Status:  models.TASK_RUNNING,
 	}
+	recordSubtask(basicRes, subtask)
 	defer func() {
 		finishedAt := time.Now()
 		subtask.FinishedAt = &finishedAt
 		subtask.SpentSeconds = finishedAt.Unix() - beginAt.Unix()
-		recordSubtask(basicRes, subtask)
+		subtask.Status = models.TASK_COMPLETED
+		r := recover()
+		if r != nil {
+			subtask.Status = models.TASK_FAILED
+			subtask.Message = fmt.Sprintf("%v", r)
+		} else if err != nil {
+			subtask.Status = models.TASK_FAILED
+			if errors.Is(err, gocontext.Canceled) {
+				subtask.Status = models.TASK_CANCELLED
+			}
+			subtask.Message = err.Error()
+		}
+		fillSubtaskMetrics(subtask, plugin.GetSubTaskMetrics(ctx))
+		finalizeSubtask(basicRes, subtask)
+		if r != nil {
+			panic(r)
+		}
 	}()
 	return entryPoint(ctx)
 }
 
+func getFinishedSubtasks(db dal.Dal, task *models.Task) (map[string]bool, errors.Error) {
+	finished := make(map[string]bool)
+	// tasks executed directly are not persisted, and a task runs from scratch unless it is resumed
+	if task.ID == 0 || !task.Resuming {
+		return finished, nil
+	}
+	var names []string
+	err := db.Pluck("name", &names,
+		dal.From(&models.Subtask{}),
+		dal.Where("task_id = ? AND status = ?", task.ID, models.TASK_COMPLETED),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading finished subtasks")
+	}
+	for _, name := range names {
+		finished[name] = true
+	}
+	return finished, nil
+}
+
+func isPipelinePauseRequested(basicRes context.BasicRes, pipelineId uint64) bool {
+	if pipelineId == 0 {
+		return false
+	}
+	count, err := basicRes.GetDal().Count(
+		dal.From(&models.Pipeline{}),
+		dal.Where("id = ? AND pause_requested = ?", pipelineId, true),
+	)
+	if err != nil {
+		basicRes.GetLogger().Error(err, "failed to check if pipeline #%d was paused", pipelineId)
+		return false
+	}
+	return count > 0
+}
+
+func isTaskPaused(basicRes context.BasicRes, taskId uint64) bool {
+	count, err := basicRes.GetDal().Count(
+		dal.From(&models.Task{}),
+		dal.Where("id = ? AND status = ?", taskId, models.TASK_PAUSED),
+	)
+	if err != nil {
+		basicRes.GetLogger().Error(err, "failed to check if task #%d was paused", taskId)
+		return false
+	}
+	return count > 0
+}
+
+func fillSubtaskMetrics(subtask *models.Subtask, metrics *plugin.SubTaskMetrics) {
+	subtask.FinishedRecords = metrics.FinishedRecords()
+	subtask.TotalRecords = metrics.TotalRecords()
+	subtask.ApiRequests = metrics.ApiRequests()
+	subtask.ApiRetries = metrics.ApiRetries()
+	subtask.RateLimitWaits = metrics.RateLimitWaits()
+	rowsWritten, err := json.Marshal(metrics.RowsWritten())
+	if err == nil {
+		subtask.RowsWritten = rowsWritten
+	}
+}
+
 func recordSubtask(basicRes context.BasicRes, subtask *models.Subtask) {
 	if err := basicRes.GetDal().Create(subtask); err != nil {
-		basicRes.GetLogger().Error(err, "error writing subtask %d status to DB: %v", subtask.ID)
+		basicRes.GetLogger().Error(err, "error writing subtask %s status to DB", subtask.Name)
+	}
+}
+
+func finalizeSubtask(basicRes context.BasicRes, subtask *models.Subtask) {
+	if subtask.ID == 0 {
+		recordSubtask(basicRes, subtask)
+		return
+	}
+	if err := basicRes.GetDal().Update(subtask); err != nil {
+		basicRes.GetLogger().Error(err, "error writing subtask %d status to DB", subtask.ID)
+	}
+}
+
+// scopeOptionKeys are the well-known task options holding the scope the task is collecting
+var scopeOptionKeys = []string{"scopeId", "fullName", "projectId", "repoId", "boardId", "projectKey", "name"}
+
+// getTaskLoggerFields returns the correlation fields attached to every log entry of the task
+func getTaskLoggerFields(task *models.Task) log.Fields {
+	fields := log.Fields{
+		log.FIELD_PIPELINE_ID: task.PipelineId,
+		log.FIELD_TASK_ID:     task.ID,
+		log.FIELD_PLUGIN:      task.Plugin,
+	}
+	options, err := task.GetOptions()
+	if err != nil {
+		return fields
+	}
+	if connectionId, ok := options["connectionId"]; ok && connectionId != nil {
+		fields[log.FIELD_CONNECTION_ID] = connectionId
+	}
+	for _, key := range scopeOptionKeys {
+		if scope, ok := options[key]; ok && scope != nil && scope != "" {
+			fields[log.FIELD_SCOPE] = scope
+			break
+		}
 	}
+	return fields
 }
 
 func getTaskLogger(parentLogger log.Logger, task *models.Task) (log.Logger, errors.Error) {
-	logger := parentLogger.Nested(fmt.Sprintf("task #%d", task.ID))
+	logger := parentLogger.Nested(fmt.Sprintf("task #%d", task.ID)).WithFields(getTaskLoggerFields(task))
 	loggingPath := logruslog.GetTaskLoggerPath(logger.GetConfig(), task)
 	stream, err := logruslog.GetFileStream(loggingPath)
 	if err != nil {
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunPluginSubTasksResume(t *testing.T) {
+	// collect was finished before the pipeline got paused, and it is asked to pause again during extract
+	pauseRequested := int64(0)
+	executed := make([]string, 0)
+	subtaskMeta := func(name string) plugin.SubTaskMeta {
+		return plugin.SubTaskMeta{
+			Name:             name,
+			EnabledByDefault: true,
+			EntryPoint: func(taskCtx plugin.SubTaskContext) errors.Error {
+				executed = append(executed, name)
+				pauseRequested = 1
+				return nil
+			},
+		}
+	}
+	pluginTask := new(mockplugin.PluginTask)
+	pluginTask.On("SubTaskMetas").Return([]plugin.SubTaskMeta{
+		subtaskMeta("collect"), subtaskMeta("extract"), subtaskMeta("convert"),
+	})
+	pluginTask.On("PrepareTaskData", mock.Anything, mock.Anything).Return(nil, nil)
+
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Pluck", "name", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(1).(*[]string) = []string{"collect"}
+		}).Return(nil)
+		mockDal.On("Count", mock.Anything).Return(func(clauses ...dal.Clause) int64 {
+			return pauseRequested
+		}, nil)
+		mockDal.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockDal.On("Update", mock.Anything, mock.Anything).Return(nil)
+	})
+	basicRes.On("NestedLogger", mock.Anything).Return(basicRes)
+	basicRes.On("ReplaceLogger", mock.Anything).Return(basicRes)
+	task := &models.Task{
+		Model:      common.Model{ID: 1},
+		Plugin:     "test",
+		Options:    "{}",
+		Subtasks:   []byte("[]"),
+		PipelineId: 1,
+		Resuming:   true,
+	}
+	err := RunPluginSubTasks(gocontext.Background(), basicRes, task, pluginTask, nil)
+	assert.Equal(t, ErrTaskPaused, err)
+	assert.Equal(t, []string{"extract"}, executed)
+}
+
+func TestRunPluginSubTasksRerun(t *testing.T) {
+	// collect was finished by the previous run, but the task is not resumed, so everything runs again
+	executed := make([]string, 0)
+	subtaskMeta := func(name string) plugin.SubTaskMeta {
+		return plugin.SubTaskMeta{
+			Name:             name,
+			EnabledByDefault: true,
+			EntryPoint: func(taskCtx plugin.SubTaskContext) errors.Error {
+				executed = append(executed, name)
+				return nil
+			},
+		}
+	}
+	pluginTask := new(mockplugin.PluginTask)
+	pluginTask.On("SubTaskMetas").Return([]plugin.SubTaskMeta{
+		subtaskMeta("collect"), subtaskMeta("extract"), subtaskMeta("convert"),
+	})
+	pluginTask.On("PrepareTaskData", mock.Anything, mock.Anything).Return(nil, nil)
+
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Pluck", "name", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(1).(*[]string) = []string{"collect"}
+		}).Return(nil)
+		mockDal.On("Count", mock.Anything).Return(int64(0), nil)
+		mockDal.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockDal.On("Update", mock.Anything, mock.Anything).Return(nil)
+	})
+	basicRes.On("NestedLogger", mock.Anything).Return(basicRes)
+	basicRes.On("ReplaceLogger", mock.Anything).Return(basicRes)
+	task := &models.Task{
+		Model:      common.Model{ID: 1},
+		Plugin:     "test",
+		Options:    "{}",
+		Subtasks:   []byte("[]"),
+		PipelineId: 1,
+	}
+	err := RunPluginSubTasks(gocontext.Background(), basicRes, task, pluginTask, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"collect", "extract", "convert"}, executed)
+}
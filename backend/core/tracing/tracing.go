@@ -0,0 +1,194 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	gocontext "context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/apache/incubator-devlake"
+
+// Attributes attached to the spans emitted by DevLake
+const (
+	ATTR_PIPELINE_ID  = attribute.Key("devlake.pipeline_id")
+	ATTR_BLUEPRINT_ID = attribute.Key("devlake.blueprint_id")
+	ATTR_TASK_ID      = attribute.Key("devlake.task_id")
+	ATTR_PLUGIN       = attribute.Key("devlake.plugin")
+	ATTR_SUBTASK      = attribute.Key("devlake.subtask")
+	ATTR_RETRY        = attribute.Key("devlake.retry")
+	ATTR_HTTP_METHOD  = attribute.Key("http.method")
+	ATTR_HTTP_URL     = attribute.Key("http.url")
+	ATTR_HTTP_STATUS  = attribute.Key("http.status_code")
+	ATTR_URL_TEMPLATE = attribute.Key("http.url_template")
+	ATTR_TABLE        = attribute.Key("db.sql.table")
+	ATTR_ROWS         = attribute.Key("devlake.rows")
+)
+
+// environment variables carrying the trace context into child processes, as proposed by the W3C trace context spec
+const (
+	ENV_TRACEPARENT = "TRACEPARENT"
+	ENV_TRACESTATE  = "TRACESTATE"
+)
+
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+var provider *sdktrace.TracerProvider
+var providerLock sync.Mutex
+
+// Init sets up the global tracer provider exporting spans by OTLP over http, the spans are discarded without
+// exporting unless TRACING_ENABLED is true
+//
+//	TRACING_ENDPOINT      the url of the OTLP/HTTP receiver, i.e. http://otel-collector:4318, the OTEL_EXPORTER_OTLP_*
+//	                      environment variables are respected if it is empty
+//	TRACING_SERVICE_NAME  the service name of the spans, defaults to devlake
+//	TRACING_SAMPLE_RATIO  the ratio of pipelines to be traced, defaults to 1
+func Init(cfg config.ConfigReader, logger log.Logger) errors.Error {
+	otel.SetTextMapPropagator(propagator)
+	if !cfg.GetBool("TRACING_ENABLED") {
+		return nil
+	}
+	providerLock.Lock()
+	defer providerLock.Unlock()
+	if provider != nil {
+		return nil
+	}
+	opts, err := exporterOptions(cfg.GetString("TRACING_ENDPOINT"))
+	if err != nil {
+		return err
+	}
+	exporter, e := otlptracehttp.New(gocontext.Background(), opts...)
+	if e != nil {
+		return errors.Default.Wrap(e, "failed to create the trace exporter")
+	}
+	serviceName := cfg.GetString("TRACING_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "devlake"
+	}
+	ratio := 1.0
+	if cfg.IsSet("TRACING_SAMPLE_RATIO") {
+		ratio = cfg.GetFloat64("TRACING_SAMPLE_RATIO")
+	}
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	logger.Info("tracing enabled, service name: %s, sample ratio: %v", serviceName, ratio)
+	return nil
+}
+
+func exporterOptions(endpoint string) ([]otlptracehttp.Option, errors.Error) {
+	if endpoint == "" {
+		return nil, nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid TRACING_ENDPOINT %s", endpoint))
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if u.Path != "" && u.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+	return opts, nil
+}
+
+// Shutdown exports the pending spans, it should be called before the process exits
+func Shutdown() {
+	providerLock.Lock()
+	defer providerLock.Unlock()
+	if provider == nil {
+		return
+	}
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), 5*time.Second)
+	defer cancel()
+	_ = provider.Shutdown(ctx)
+	provider = nil
+}
+
+// Start starts a span as a child of the span carried by ctx, the returned context carries the new span
+func Start(ctx gocontext.Context, name string, attrs ...attribute.KeyValue) (gocontext.Context, trace.Span) {
+	if ctx == nil {
+		ctx = gocontext.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, and marks it as failed if err is not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject returns the trace context carried by ctx in the form of W3C trace context headers
+func Inject(ctx gocontext.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	if ctx == nil {
+		return carrier
+	}
+	propagator.Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract returns a copy of ctx carrying the trace context injected by Inject
+func Extract(ctx gocontext.Context, carrier map[string]string) gocontext.Context {
+	return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// Environ returns the environment variables passing the trace context carried by ctx into a child process
+func Environ(ctx gocontext.Context) []string {
+	carrier := Inject(ctx)
+	env := make([]string, 0, 2)
+	if traceparent := carrier["traceparent"]; traceparent != "" {
+		env = append(env, fmt.Sprintf("%s=%s", ENV_TRACEPARENT, traceparent))
+	}
+	if tracestate := carrier["tracestate"]; tracestate != "" {
+		env = append(env, fmt.Sprintf("%s=%s", ENV_TRACESTATE, tracestate))
+	}
+	return env
+}
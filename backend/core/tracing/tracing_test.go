@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	gocontext "context"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+	return recorder
+}
+
+func TestStartEnd(t *testing.T) {
+	recorder := setupRecorder(t)
+	ctx, parent := Start(gocontext.Background(), "pipeline", ATTR_PIPELINE_ID.Int64(1))
+	_, child := Start(ctx, "task github", ATTR_TASK_ID.Int64(2))
+	End(child, errors.Default.New("boom"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "task github", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestInjectExtract(t *testing.T) {
+	setupRecorder(t)
+	ctx, span := Start(gocontext.Background(), "pipeline")
+	defer span.End()
+
+	carrier := Inject(ctx)
+	assert.NotEmpty(t, carrier["traceparent"])
+	extracted := trace.SpanContextFromContext(Extract(gocontext.Background(), carrier))
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+
+	env := Environ(ctx)
+	assert.Len(t, env, 1)
+	assert.True(t, strings.HasPrefix(env[0], ENV_TRACEPARENT+"="))
+	assert.Empty(t, Environ(gocontext.Background()))
+	assert.Empty(t, Inject(nil)) //nolint:staticcheck
+}
+
+func TestExporterOptions(t *testing.T) {
+	opts, err := exporterOptions("")
+	assert.Nil(t, err)
+	assert.Empty(t, opts)
+
+	opts, err = exporterOptions("http://otel-collector:4318")
+	assert.Nil(t, err)
+	assert.Len(t, opts, 2)
+
+	opts, err = exporterOptions("otel-collector:4318/custom/v1/traces")
+	assert.Nil(t, err)
+	assert.Len(t, opts, 2)
+
+	_, err = exporterOptions("http://")
+	assert.NotNil(t, err)
+}
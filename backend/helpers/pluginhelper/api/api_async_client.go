@@ -28,6 +28,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	coremetrics "github.com/apache/incubator-devlake/core/metrics"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/common"
@@ -45,6 +46,7 @@ type ApiAsyncClient struct {
 	maxRetry     int
 	numOfWorkers int
 	logger       log.Logger
+	taskCtx      plugin.TaskContext
 }
 
 const defaultTimeout = 120 * time.Second
@@ -75,6 +77,7 @@ func CreateAsyncApiClient(
 	}
 
 	apiClient.SetLogger(taskCtx.GetLogger())
+	apiClient.pluginName = taskCtx.GetName()
 
 	globalRateLimitPerHour, err := utils.StrToIntOr(taskCtx.GetConfig("API_REQUESTS_PER_HOUR"), 18000)
 	if err != nil {
@@ -103,11 +106,17 @@ func CreateAsyncApiClient(
 	if err != nil {
 		return nil, err
 	}
+	// the rate is shared with other clients of the same connection running at the same time
+	rateBudgetKey := apiClient.rateBudgetKey
+	if rateBudgetKey == "" {
+		rateBudgetKey = getRateBudgetKey(apiClient.GetEndpoint(), "", nil)
+	}
 
 	logger := taskCtx.GetLogger().Nested("api async client")
 	logger.Info(
-		"creating scheduler for api \"%s\", number of workers: %d, %d reqs / %s (interval: %s)",
+		"creating scheduler for api \"%s\" (rate budget %s), number of workers: %d, %d reqs / %s (interval: %s)",
 		apiClient.GetEndpoint(),
+		rateBudgetKey,
 		numOfWorkers,
 		requests,
 		duration.String(),
@@ -122,6 +131,7 @@ func CreateAsyncApiClient(
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "failed to create scheduler")
 	}
+	apiClient.rateBudget = acquireApiRateBudget(rateBudgetKey, requests, duration)
 
 	// finally, wrap around api client with async sematic
 	return &ApiAsyncClient{
@@ -130,6 +140,7 @@ func CreateAsyncApiClient(
 		retry,
 		numOfWorkers,
 		logger,
+		taskCtx,
 	}, nil
 }
 
@@ -154,6 +165,47 @@ func (apiClient *ApiAsyncClient) DoAsync(
 	header http.Header,
 	handler common.ApiAsyncCallback,
 	retry int,
+) {
+	apiClient.doAsync(method, path, "", query, body, header, handler, retry)
+}
+
+// urlTemplateApiClient is implemented by the clients that record the url template of the requests into the spans,
+// so the requests to the same api could be told apart from the resolved urls
+type urlTemplateApiClient interface {
+	doAsyncWithUrlTemplate(
+		method string,
+		path string,
+		urlTemplate string,
+		query url.Values,
+		body interface{},
+		header http.Header,
+		handler common.ApiAsyncCallback,
+	)
+}
+
+var _ urlTemplateApiClient = (*ApiAsyncClient)(nil)
+
+func (apiClient *ApiAsyncClient) doAsyncWithUrlTemplate(
+	method string,
+	path string,
+	urlTemplate string,
+	query url.Values,
+	body interface{},
+	header http.Header,
+	handler common.ApiAsyncCallback,
+) {
+	apiClient.doAsync(method, path, urlTemplate, query, body, header, handler, 0)
+}
+
+func (apiClient *ApiAsyncClient) doAsync(
+	method string,
+	path string,
+	urlTemplate string,
+	query url.Values,
+	body interface{},
+	header http.Header,
+	handler common.ApiAsyncCallback,
+	retry int,
 ) {
 	var request func() errors.Error
 	request = func() errors.Error {
@@ -161,8 +213,15 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		var res *http.Response
 		var respBody []byte
 
-		apiClient.logger.Debug("endpoint: %s  method: %s  header: %s  body: %s query: %s", path, method, header, body, query)
-		res, err = apiClient.Do(method, path, query, body, header)
+		logger := apiClient.logger
+		if uri, e := GetURIStringPointer(apiClient.GetEndpoint(), path, query); e == nil {
+			logger = logger.WithFields(log.Fields{log.FIELD_URL: *uri})
+		}
+
+		logger.Debug("endpoint: %s  method: %s  header: %s  body: %s query: %s", path, method, header, body, query)
+		metrics := plugin.GetSubTaskMetrics(apiClient.taskCtx)
+		metrics.IncApiRequests()
+		res, err = apiClient.do(method, path, urlTemplate, query, body, header, retry)
 		// make sure response body is read successfully, or we might have to retry
 		if err == nil {
 			// make sure response.Body stream will be closed to avoid running out of file handle
@@ -185,6 +244,9 @@ func (apiClient *ApiAsyncClient) DoAsync(
 			needRetry = true
 		} else if res.StatusCode >= HttpMinStatusRetryCode {
 			needRetry = true
+			if res.StatusCode == http.StatusTooManyRequests {
+				metrics.IncRateLimitWaits()
+			}
 			err = errors.HttpStatus(res.StatusCode).New(
 				fmt.Sprintf("Http DoAsync error calling [%s %s]. Response: %s", method, path, string(respBody)),
 			)
@@ -194,8 +256,10 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		if needRetry {
 			// check whether we still have retry times and not error from handler and canceled error
 			if retry < apiClient.maxRetry && err != context.Canceled {
-				apiClient.logger.Warn(err, "retry #%d calling %s", retry, path)
+				logger.Warn(err, "retry #%d calling %s", retry, path)
 				retry++
+				metrics.IncApiRetries()
+				coremetrics.IncApiRetries(apiClient.getPluginName())
 				apiClient.NextTick(func() errors.Error {
 					apiClient.SubmitBlocking(request)
 					return nil
@@ -206,7 +270,7 @@ func (apiClient *ApiAsyncClient) DoAsync(
 
 		if err != nil {
 			err = errors.Default.Wrap(err, fmt.Sprintf("retry exceeded %d times calling %s", retry, path))
-			apiClient.logger.Error(err, "")
+			logger.Error(err, "")
 			return errors.Convert(err)
 		}
 
@@ -238,6 +302,14 @@ func (apiClient *ApiAsyncClient) DoPostAsync(
 	apiClient.DoAsync(http.MethodPost, path, query, body, header, handler, 0)
 }
 
+// Release waits for the pending requests and releases the resources, including the share of the rate budget
+func (apiClient *ApiAsyncClient) Release() {
+	apiClient.WorkerScheduler.Release()
+	if apiClient.rateBudget != nil {
+		releaseApiRateBudget(apiClient.rateBudget)
+	}
+}
+
 // GetNumOfWorkers to return the Workers count if scheduler.
 func (apiClient *ApiAsyncClient) GetNumOfWorkers() int {
 	return apiClient.numOfWorkers
@@ -38,8 +38,11 @@ import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/core/tracing"
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/common"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // ErrIgnoreAndContinue is a error which should be ignored
@@ -57,6 +60,11 @@ type ApiClient struct {
 	afterResponse common.ApiClientAfterResponse
 	ctx           gocontext.Context
 	logger        log.Logger
+	// pluginName is used for labeling metrics
+	pluginName string
+	// rateBudgetKey identifies the rate budget to be shared by the async clients wrapping the client
+	rateBudgetKey string
+	rateBudget    *ApiRateBudget
 }
 
 // NewApiClientFromConnection creates ApiClient based on given connection.
@@ -68,21 +76,30 @@ func NewApiClientFromConnection(
 	if reflect.ValueOf(connection).Kind() != reflect.Ptr {
 		return nil, errors.Default.New("connection is not a pointer")
 	}
-	apiClient, err := NewApiClient(ctx, connection.GetEndpoint(), nil, 0, connection.GetProxy(), br)
+	// secrets are resolved on a copy, so they would never be saved or returned by the connection apis
+	resolved, err := resolveConnectionSecrets(connection)
 	if err != nil {
 		return nil, err
 	}
+	apiClient, err := NewApiClient(ctx, resolved.GetEndpoint(), nil, 0, resolved.GetProxy(), br)
+	if err != nil {
+		return nil, err
+	}
+	apiClient.pluginName = getPluginNameOfConnection(resolved)
+	apiClient.rateBudgetKey = getRateBudgetKey(resolved.GetEndpoint(), apiClient.pluginName, resolved)
 
 	// if connection needs to prepare the ApiClient, i.e. fetch token for future requests
-	if prepareApiClient, ok := connection.(aha.PrepareApiClient); ok {
+	if prepareApiClient, ok := resolved.(aha.PrepareApiClient); ok {
 		err = prepareApiClient.PrepareApiClient(apiClient)
 		if err != nil {
 			return nil, err
 		}
+		// callers might rely on the prepared state, i.e. the number of tokens of GitHub connections
+		copyPreparedConnection(connection, resolved)
 	}
 
 	// if connection requires authorization
-	if authenticator, ok := connection.(aha.ApiAuthenticator); ok {
+	if authenticator, ok := resolved.(aha.ApiAuthenticator); ok {
 		apiClient.SetBeforeFunction(func(req *http.Request) errors.Error {
 			return authenticator.SetupAuthentication(req)
 		})
@@ -262,16 +279,48 @@ func (apiClient *ApiClient) SetLogger(logger log.Logger) {
 	apiClient.logger = logger
 }
 
-func (apiClient *ApiClient) logDebug(format string, a ...interface{}) {
+func (apiClient *ApiClient) logDebug(uri string, format string, a ...interface{}) {
 	if apiClient.logger != nil {
-		apiClient.logger.Debug(format, a...)
+		apiClient.logger.WithFields(log.Fields{log.FIELD_URL: uri}).Debug(format, a...)
 	}
 }
 
-func (apiClient *ApiClient) logError(err error, format string, a ...interface{}) {
+func (apiClient *ApiClient) logError(uri string, err error, format string, a ...interface{}) {
 	if apiClient.logger != nil {
-		apiClient.logger.Error(err, format, a...)
+		apiClient.logger.WithFields(log.Fields{log.FIELD_URL: uri}).Error(err, format, a...)
+	}
+}
+
+func (apiClient *ApiClient) getPluginName() string {
+	if apiClient.pluginName == "" {
+		return "unknown"
 	}
+	return apiClient.pluginName
+}
+
+// getPluginNameOfConnection extracts the plugin name from the package path of the connection,
+// i.e. `github` from `github.com/apache/incubator-devlake/plugins/github/models`
+func getPluginNameOfConnection(connection interface{}) string {
+	pkgPath := reflect.TypeOf(connection).Elem().PkgPath()
+	parts := strings.Split(pkgPath, "/")
+	for i, part := range parts {
+		if part == "plugins" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// redactUrl leaves the query and the userinfo out of the url, they might carry tokens
+func redactUrl(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
 }
 
 // Do FIXME ...
@@ -282,6 +331,20 @@ func (apiClient *ApiClient) Do(
 	body interface{},
 	headers http.Header,
 ) (*http.Response, errors.Error) {
+	return apiClient.do(method, path, "", query, body, headers, 0)
+}
+
+// do sends the request in a span, urlTemplate is the template the path was resolved from if known, retry is the
+// number of attempts made before by the caller
+func (apiClient *ApiClient) do(
+	method string,
+	path string,
+	urlTemplate string,
+	query url.Values,
+	body interface{},
+	headers http.Header,
+	retry int,
+) (res *http.Response, err errors.Error) {
 	uri, err := GetURIStringPointer(apiClient.endpoint, path, query)
 	if err != nil {
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("Unable to construct URI from %s, %s, %s", apiClient.endpoint, path, query))
@@ -295,12 +358,24 @@ func (apiClient *ApiClient) Do(
 		}
 		reqBody = bytes.NewBuffer(reqJson)
 	}
-	var req *http.Request
-	if apiClient.ctx != nil {
-		req, err = errors.Convert01(http.NewRequestWithContext(apiClient.ctx, method, *uri, reqBody))
-	} else {
-		req, err = errors.Convert01(http.NewRequest(method, *uri, reqBody))
+	attrs := []attribute.KeyValue{
+		tracing.ATTR_HTTP_METHOD.String(method),
+		tracing.ATTR_HTTP_URL.String(redactUrl(*uri)),
+		tracing.ATTR_RETRY.Int(retry),
 	}
+	if urlTemplate != "" {
+		attrs = append(attrs, tracing.ATTR_URL_TEMPLATE.String(urlTemplate))
+	}
+	ctx, span := tracing.Start(apiClient.ctx, fmt.Sprintf("HTTP %s", method), attrs...)
+	defer func() {
+		if err == ErrIgnoreAndContinue {
+			tracing.End(span, nil)
+		} else {
+			tracing.End(span, err)
+		}
+	}()
+	var req *http.Request
+	req, err = errors.Convert01(http.NewRequestWithContext(ctx, method, *uri, reqBody))
 	if err != nil {
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("unable to create API request for %s", *uri))
 	}
@@ -318,7 +393,6 @@ func (apiClient *ApiClient) Do(
 		}
 	}
 
-	var res *http.Response
 	// before send
 	if apiClient.beforeRequest != nil {
 		err = apiClient.beforeRequest(req)
@@ -326,12 +400,24 @@ func (apiClient *ApiClient) Do(
 			return nil, errors.Default.Wrap(err, fmt.Sprintf("error running beforeRequest for %s", req.URL.String()))
 		}
 	}
-	apiClient.logDebug("[api-client] %v %v", method, *uri)
+	apiClient.logDebug(*uri, "[api-client] %v %v", method, *uri)
+	if apiClient.rateBudget != nil {
+		if e := apiClient.rateBudget.Wait(ctx); e != nil {
+			return nil, errors.Convert(e)
+		}
+	}
+	requestedAt := time.Now()
 	res, err = errors.Convert01(apiClient.client.Do(req))
 	if err != nil {
-		apiClient.logError(err, "[api-client] failed to request %s with error", req.URL.String())
+		metrics.ObserveApiRequest(apiClient.getPluginName(), method, 0, time.Since(requestedAt))
+		apiClient.logError(*uri, err, "[api-client] failed to request %s with error", req.URL.String())
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("error requesting %s", req.URL.String()))
 	}
+	metrics.ObserveApiRequest(apiClient.getPluginName(), method, res.StatusCode, time.Since(requestedAt))
+	span.SetAttributes(tracing.ATTR_HTTP_STATUS.Int(res.StatusCode))
+	if apiClient.rateBudget != nil {
+		apiClient.rateBudget.Observe(res)
+	}
 	// after receive
 	if apiClient.afterResponse != nil {
 		err = apiClient.afterResponse(res)
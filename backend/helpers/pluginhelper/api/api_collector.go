@@ -48,6 +48,8 @@ type RequestData struct {
 	InputJSON []byte
 	// equal to the return value from GetNextPageCustomData when PageSize>0 and not the first request
 	CustomData interface{}
+	// unit tracks the outstanding requests of the Input for checkpointing
+	unit *inputUnit
 }
 
 // AsyncResponseHandler FIXME ...
@@ -93,6 +95,7 @@ type ApiCollector struct {
 	*RawDataSubTask
 	args        *ApiCollectorArgs
 	urlTemplate *template.Template
+	checkpoint  *collectorCheckpoint
 }
 
 // NewApiCollector allocates a new ApiCollector with the given args.
@@ -136,7 +139,7 @@ func NewApiCollector(args ApiCollectorArgs) (*ApiCollector, errors.Error) {
 	return apiCollector, nil
 }
 
-// Execute will start collection
+// Execute will start collection, and resume from the checkpoint if the previous run was interrupted
 func (collector *ApiCollector) Execute() errors.Error {
 	logger := collector.args.Ctx.GetLogger()
 	logger.Info("start api collection")
@@ -148,8 +151,15 @@ func (collector *ApiCollector) Execute() errors.Error {
 		return errors.Default.Wrap(err, "error auto-migrating collector")
 	}
 
-	// flush data if not incremental collection
-	if !collector.args.Incremental {
+	// resume from the checkpoint if the previous run was interrupted
+	collectorKey := checkpointHash([]byte(collector.args.Method + " " + collector.args.UrlTemplate))
+	collector.checkpoint, err = newCollectorCheckpoint(db, logger, collector.table, collector.params, collectorKey, collector.args.Incremental)
+	if err != nil {
+		return err
+	}
+
+	// flush data if not incremental collection, the data collected by the interrupted run is kept for resuming
+	if !collector.args.Incremental && !collector.checkpoint.isResuming() {
 		err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
 		if err != nil {
 			return errors.Default.Wrap(err, "error deleting data from collector")
@@ -211,12 +221,10 @@ func (collector *ApiCollector) Execute() errors.Error {
 	err = collector.args.ApiClient.WaitAsync()
 	if err != nil {
 		logger.Error(err, "end api collection error")
-		err = errors.Default.Wrap(err, "Error waiting for async Collector execution")
-	} else {
-		logger.Info("end api collection without error")
+		return errors.Default.Wrap(err, "Error waiting for async Collector execution")
 	}
-
-	return err
+	logger.Info("end api collection without error")
+	return collector.checkpoint.clear()
 }
 
 func (collector *ApiCollector) exec(input interface{}) {
@@ -227,6 +235,17 @@ func (collector *ApiCollector) exec(input interface{}) {
 	reqData := new(RequestData)
 	reqData.Input = input
 	reqData.InputJSON = inputJson
+	if input != nil {
+		inputKey := checkpointHash(inputJson)
+		if _, ok := collector.checkpoint.isFinished(inputKey, 0); ok {
+			collector.args.Ctx.IncProgress(1)
+			return
+		}
+		// hold the input until all requests were enqueued
+		reqData.unit = &inputUnit{key: inputKey}
+		reqData.unit.hold()
+		defer collector.releaseInput(reqData.unit, true)
+	}
 	reqData.Pager = &Pager{
 		Page: 1,
 		Size: collector.args.PageSize,
@@ -269,7 +288,7 @@ func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
 		})
 		return nil
 	}
-	collector.args.ApiClient.NextTick(collect)
+	collector.nextTick(reqData.unit, collect)
 }
 
 // fetchPagesDetermined fetches data of all pages for APIs that return paging information
@@ -281,7 +300,7 @@ func (collector *ApiCollector) fetchPagesDetermined(reqData *RequestData) {
 			return errors.Default.Wrap(err, "fetchPagesDetermined get totalPages failed")
 		}
 		// spawn a none blocking go routine to fetch other pages
-		collector.args.ApiClient.NextTick(func() errors.Error {
+		collector.nextTick(reqData.unit, func() errors.Error {
 			for page := 2; page <= totalPages; page++ {
 				reqDataTemp := &RequestData{
 					Pager: &Pager{
@@ -291,6 +310,7 @@ func (collector *ApiCollector) fetchPagesDetermined(reqData *RequestData) {
 					},
 					Input:     reqData.Input,
 					InputJSON: reqData.InputJSON,
+					unit:      reqData.unit,
 				}
 				collector.fetchAsync(reqDataTemp, nil)
 			}
@@ -332,6 +352,7 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 			},
 			Input:     reqData.Input,
 			InputJSON: reqData.InputJSON,
+			unit:      reqData.unit,
 		}
 		var collect func() errors.Error
 		collect = func() errors.Error {
@@ -339,7 +360,7 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 				if count < collector.args.PageSize {
 					return nil
 				}
-				apiClient.NextTick(func() errors.Error {
+				collector.nextTick(reqDataCopy.unit, func() errors.Error {
 					reqDataCopy.Pager.Skip += collector.args.PageSize * concurrency
 					reqDataCopy.Pager.Page += concurrency
 					return collect()
@@ -348,7 +369,24 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 			})
 			return nil
 		}
-		apiClient.NextTick(collect)
+		collector.nextTick(reqDataCopy.unit, collect)
+	}
+}
+
+// nextTick schedules the task and keeps the input pending until the task was executed
+func (collector *ApiCollector) nextTick(unit *inputUnit, task func() errors.Error) {
+	unit.hold()
+	collector.args.ApiClient.NextTick(func() errors.Error {
+		err := task()
+		collector.releaseInput(unit, err == nil)
+		return err
+	})
+}
+
+// releaseInput records the input into the checkpoint once all its requests succeeded
+func (collector *ApiCollector) releaseInput(unit *inputUnit, succeeded bool) {
+	if unit.release(succeeded) {
+		collector.checkpoint.finish(unit.key, 0, 0)
 	}
 }
 
@@ -383,6 +421,28 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			Skip: 0,
 		}
 	}
+	// pages of collectors without input are checkpointed individually
+	checkpointPage := collector.args.Input == nil && collector.args.PageSize > 0
+	skipSaving := false
+	if checkpointPage {
+		if items, ok := collector.checkpoint.isFinished("", reqData.Pager.Page); ok {
+			if handler == nil {
+				collector.args.Ctx.IncProgress(1)
+				return
+			}
+			if collector.args.GetNextPageCustomData == nil && collector.args.GetTotalPages == nil {
+				// undetermined strategy only needs the number of items to move on
+				collector.args.Ctx.IncProgress(1)
+				err := handler(items, nil, nil)
+				if err != nil {
+					panic(err)
+				}
+				return
+			}
+			// the response is required to move on, fetch it again but don't save it twice
+			skipSaving = true
+		}
+	}
 	apiUrl, err := collector.generateUrl(reqData.Pager, reqData.Input)
 	if err != nil {
 		panic(err)
@@ -423,10 +483,13 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 		res.Body = io.NopCloser(bytes.NewBuffer(body))
 		// convert body to array of RawJSON
 		items, err := collector.args.ResponseParser(res)
+		stopped := false
 		if err != nil {
 			if errors.Is(err, ErrFinishCollect) {
 				logger.Info("a fetch stop by parser, reqInput: #%d", reqData.Params)
 				handler = nil
+				stopped = true
+				err = nil
 			} else {
 				return errors.Default.Wrap(err, fmt.Sprintf("error parsing response from %s", apiUrl))
 			}
@@ -437,22 +500,28 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			collector.args.Ctx.IncProgress(1)
 			return nil
 		}
-		db := collector.args.Ctx.GetDal()
-		urlString := res.Request.URL.String()
-		rows := make([]*RawData, count)
-		for i, msg := range items {
-			rows[i] = &RawData{
-				Params: collector.params,
-				Data:   msg,
-				Url:    urlString,
-				Input:  reqData.InputJSON,
+		if !skipSaving {
+			urlString := res.Request.URL.String()
+			rows := make([]*RawData, count)
+			for i, msg := range items {
+				rows[i] = &RawData{
+					Params: collector.params,
+					Data:   msg,
+					Url:    urlString,
+					Input:  reqData.InputJSON,
+				}
 			}
+			inputKey := ""
+			if reqData.unit != nil {
+				inputKey = reqData.unit.key
+			}
+			// a stopped page must be fetched again when resuming, so the collection would stop at the same place
+			saveErr := collector.checkpoint.saveRows(rows, inputKey, reqData.Pager.Page, checkpointPage && !stopped)
+			if saveErr != nil {
+				return saveErr
+			}
+			logger.Debug("fetchAsync === total %d rows were saved into database", count)
 		}
-		err = db.Create(rows, dal.From(collector.table))
-		if err != nil {
-			return errors.Default.Wrap(err, fmt.Sprintf("error inserting raw rows into %s", collector.table))
-		}
-		logger.Debug("fetchAsync === total %d rows were saved into database", count)
 		// increase progress only when it was not nested
 		collector.args.Ctx.IncProgress(1)
 		if handler != nil {
@@ -462,10 +531,23 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 		}
 		return nil
 	}
-	if collector.args.Method == http.MethodPost {
-		collector.args.ApiClient.DoPostAsync(apiUrl, apiQuery, reqBody, apiHeader, responseHandler)
+	// keep the input pending until the response was handled
+	reqData.unit.hold()
+	trackedResponseHandler := func(res *http.Response) errors.Error {
+		err := responseHandler(res)
+		collector.releaseInput(reqData.unit, err == nil)
+		return err
+	}
+	if templated, ok := collector.args.ApiClient.(urlTemplateApiClient); ok {
+		method, body := http.MethodGet, interface{}(nil)
+		if collector.args.Method == http.MethodPost {
+			method, body = http.MethodPost, reqBody
+		}
+		templated.doAsyncWithUrlTemplate(method, apiUrl, collector.args.UrlTemplate, apiQuery, body, apiHeader, trackedResponseHandler)
+	} else if collector.args.Method == http.MethodPost {
+		collector.args.ApiClient.DoPostAsync(apiUrl, apiQuery, reqBody, apiHeader, trackedResponseHandler)
 	} else {
-		collector.args.ApiClient.DoGetAsync(apiUrl, apiQuery, apiHeader, responseHandler)
+		collector.args.ApiClient.DoGetAsync(apiUrl, apiQuery, apiHeader, trackedResponseHandler)
 	}
 	logger.Debug("fetchAsync === enqueued for %s %v", apiUrl, apiQuery)
 }
@@ -0,0 +1,279 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// purgeBatchSize limits the number of ids in a single DELETE statement
+const purgeBatchSize = 1000
+
+// collectorCheckpoint records the pages or inputs finished by an ApiCollector while it runs, so a failed
+// collection could be resumed by the next run instead of starting over.
+// Pages are recorded for collectors without input, and inputs are recorded as a whole otherwise since
+// they tend to have only 1 or 2 pages.
+type collectorCheckpoint struct {
+	db       dal.Dal
+	logger   log.Logger
+	session  *models.CollectorCheckpoint
+	resuming bool
+	mu       sync.RWMutex
+	finished map[string]int
+}
+
+// newCollectorCheckpoint loads the checkpoint left by the previous run, it would be resumed only if
+// the previous run was in the same mode, otherwise it is discarded and a new one is created
+func newCollectorCheckpoint(
+	db dal.Dal,
+	logger log.Logger,
+	table string,
+	params string,
+	collectorKey string,
+	incremental bool,
+) (*collectorCheckpoint, errors.Error) {
+	checkpoint := &collectorCheckpoint{
+		db:     db,
+		logger: logger,
+		session: &models.CollectorCheckpoint{
+			RawDataTable:  table,
+			RawDataParams: params,
+			CollectorKey:  collectorKey,
+			Incremental:   incremental,
+		},
+		finished: make(map[string]int),
+	}
+	previous := &models.CollectorCheckpoint{}
+	err := db.First(previous, checkpoint.where())
+	if err != nil && !db.IsErrorNotFound(err) {
+		return nil, errors.Default.Wrap(err, "failed to load collector checkpoint")
+	}
+	if err == nil && previous.Incremental == incremental {
+		units := make([]*models.CollectorCheckpointUnit, 0)
+		err = db.All(&units, checkpoint.where())
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "failed to load collector checkpoint units")
+		}
+		for _, unit := range units {
+			if unit.Finished {
+				checkpoint.finished[checkpointUnitKey(unit.InputKey, unit.Page)] = unit.Items
+			}
+		}
+		err = checkpoint.purgeUnfinished(units)
+		if err != nil {
+			return nil, err
+		}
+		checkpoint.resuming = true
+		logger.Info("resuming collection from checkpoint created at %v, %d units were finished", previous.CreatedAt, len(checkpoint.finished))
+		return checkpoint, nil
+	}
+	err = checkpoint.clear()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Create(checkpoint.session)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to create collector checkpoint")
+	}
+	return checkpoint, nil
+}
+
+func (c *collectorCheckpoint) where() dal.Clause {
+	return dal.Where(
+		"raw_data_table = ? AND raw_data_params = ? AND collector_key = ?",
+		c.session.RawDataTable, c.session.RawDataParams, c.session.CollectorKey,
+	)
+}
+
+// isResuming returns true if the previous run was interrupted and the collected data should be kept
+func (c *collectorCheckpoint) isResuming() bool {
+	return c.resuming
+}
+
+// isFinished returns the number of items and true if the unit was finished by the previous run
+func (c *collectorCheckpoint) isFinished(inputKey string, page int) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items, ok := c.finished[checkpointUnitKey(inputKey, page)]
+	return items, ok
+}
+
+// purgeUnfinished removes the raw rows saved by the units which were not finished by the previous run,
+// otherwise they would be duplicated when the units are fetched again
+func (c *collectorCheckpoint) purgeUnfinished(units []*models.CollectorCheckpointUnit) errors.Error {
+	staleIds := make([]uint64, 0)
+	for _, unit := range units {
+		if unit.Finished || unit.RawIds == "" {
+			continue
+		}
+		// pages of a finished input are kept as a whole
+		if _, ok := c.finished[checkpointUnitKey(unit.InputKey, 0)]; ok {
+			continue
+		}
+		for _, rawId := range strings.Split(unit.RawIds, ",") {
+			id, err := strconv.ParseUint(rawId, 10, 64)
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("invalid raw id %s in collector checkpoint", rawId))
+			}
+			staleIds = append(staleIds, id)
+		}
+	}
+	for start := 0; start < len(staleIds); start += purgeBatchSize {
+		end := start + purgeBatchSize
+		if end > len(staleIds) {
+			end = len(staleIds)
+		}
+		err := c.db.Delete(&RawData{}, dal.From(c.session.RawDataTable), dal.Where("id IN ?", staleIds[start:end]))
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to delete raw data of unfinished collector checkpoint units")
+		}
+	}
+	if len(staleIds) > 0 {
+		c.logger.Info("%d raw rows saved by unfinished units were removed", len(staleIds))
+	}
+	err := c.db.Delete(&models.CollectorCheckpointUnit{}, dal.Where(
+		"raw_data_table = ? AND raw_data_params = ? AND collector_key = ? AND finished = ?",
+		c.session.RawDataTable, c.session.RawDataParams, c.session.CollectorKey, false,
+	))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete unfinished collector checkpoint units")
+	}
+	return nil
+}
+
+// saveRows saves the raw rows of a page along with its unit in one transaction, the ids of the rows are kept
+// in the unit until it finished, so they could be removed when resuming
+func (c *collectorCheckpoint) saveRows(rows []*RawData, inputKey string, page int, finished bool) errors.Error {
+	tx := c.db.Begin()
+	err := tx.Create(rows, dal.From(c.session.RawDataTable))
+	if err != nil {
+		return c.rollback(tx, errors.Default.Wrap(err, fmt.Sprintf("error inserting raw rows into %s", c.session.RawDataTable)))
+	}
+	unit := c.newUnit(inputKey, page, len(rows), finished)
+	if !finished {
+		rawIds := make([]string, len(rows))
+		for i, row := range rows {
+			rawIds[i] = strconv.FormatUint(row.ID, 10)
+		}
+		unit.RawIds = strings.Join(rawIds, ",")
+	}
+	err = tx.CreateOrUpdate(unit)
+	if err != nil {
+		return c.rollback(tx, errors.Default.Wrap(err, "failed to save collector checkpoint"))
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to commit raw rows and collector checkpoint")
+	}
+	if finished {
+		c.markFinished(inputKey, page, len(rows))
+	}
+	return nil
+}
+
+func (c *collectorCheckpoint) rollback(tx dal.Transaction, err errors.Error) errors.Error {
+	if e := tx.Rollback(); e != nil {
+		c.logger.Error(e, "failed to rollback raw rows and collector checkpoint")
+	}
+	return err
+}
+
+// finish records the unit, failure would be logged only since it merely costs extra requests in the next run
+func (c *collectorCheckpoint) finish(inputKey string, page int, items int) {
+	err := c.db.CreateOrUpdate(c.newUnit(inputKey, page, items, true))
+	if err != nil {
+		c.logger.Warn(err, "failed to save collector checkpoint")
+		return
+	}
+	c.markFinished(inputKey, page, items)
+}
+
+func (c *collectorCheckpoint) newUnit(inputKey string, page int, items int, finished bool) *models.CollectorCheckpointUnit {
+	return &models.CollectorCheckpointUnit{
+		RawDataTable:  c.session.RawDataTable,
+		RawDataParams: c.session.RawDataParams,
+		CollectorKey:  c.session.CollectorKey,
+		InputKey:      inputKey,
+		Page:          page,
+		Items:         items,
+		Finished:      finished,
+	}
+}
+
+func (c *collectorCheckpoint) markFinished(inputKey string, page int, items int) {
+	c.mu.Lock()
+	c.finished[checkpointUnitKey(inputKey, page)] = items
+	c.mu.Unlock()
+}
+
+// clear removes the checkpoint, it should be called once the collection succeeded
+func (c *collectorCheckpoint) clear() errors.Error {
+	err := c.db.Delete(&models.CollectorCheckpointUnit{}, c.where())
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete collector checkpoint units")
+	}
+	err = c.db.Delete(&models.CollectorCheckpoint{}, c.where())
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete collector checkpoint")
+	}
+	return nil
+}
+
+func checkpointUnitKey(inputKey string, page int) string {
+	return fmt.Sprintf("%s#%d", inputKey, page)
+}
+
+func checkpointHash(data []byte) string {
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// inputUnit tracks the outstanding requests of an input, the input is finished when all its requests succeeded
+type inputUnit struct {
+	key     string
+	pending int32
+	failed  int32
+}
+
+func (u *inputUnit) hold() {
+	if u != nil {
+		atomic.AddInt32(&u.pending, 1)
+	}
+}
+
+// release returns true if this was the last outstanding request and all requests succeeded
+func (u *inputUnit) release(succeeded bool) bool {
+	if u == nil {
+		return false
+	}
+	if !succeeded {
+		atomic.StoreInt32(&u.failed, 1)
+	}
+	return atomic.AddInt32(&u.pending, -1) == 0 && atomic.LoadInt32(&u.failed) == 0
+}
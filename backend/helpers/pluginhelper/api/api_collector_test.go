@@ -19,7 +19,10 @@ package api
 
 import (
 	"bytes"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/common"
 	"github.com/apache/incubator-devlake/helpers/unithelper"
 	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
@@ -36,8 +39,24 @@ import (
 func TestFetchPageUndetermined(t *testing.T) {
 	mockDal := new(mockdal.Dal)
 	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
-	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	// no checkpoint was left by the previous run
+	mockDal.On("First", mock.Anything, mock.Anything).Return(errors.NotFound.New("not found")).Once()
+	mockDal.On("IsErrorNotFound", mock.Anything).Return(true)
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Times(2)
 	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	// raw data flushed and saved along with the unfinished unit of the input
+	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockTx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(mockTx).Once()
+	mockTx.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		unit := args.Get(0).(*models.CollectorCheckpointUnit)
+		assert.False(t, unit.Finished)
+		assert.Equal(t, 1, unit.Page)
+	}).Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+	// checkpoint cleared
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Times(2)
 
 	mockCtx := unithelper.DummySubTaskContext(mockDal)
 
@@ -98,5 +117,120 @@ func TestFetchPageUndetermined(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, collector.Execute())
 
+	mockDal.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestResumeFromCheckpoint(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	// the previous run finished page 1 and then failed
+	mockDal.On("First", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		units := args.Get(0).(*[]*models.CollectorCheckpointUnit)
+		*units = append(*units, &models.CollectorCheckpointUnit{Page: 1, Items: 3, Finished: true})
+	}).Return(nil).Once()
+	// no unfinished unit was left
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+	// page 2 is saved and checkpointed, the raw data must NOT be flushed
+	mockTx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(mockTx).Once()
+	mockTx.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		unit := args.Get(0).(*models.CollectorCheckpointUnit)
+		assert.Equal(t, 2, unit.Page)
+		assert.Equal(t, 3, unit.Items)
+		assert.True(t, unit.Finished)
+	}).Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+	// checkpoint cleared
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Times(2)
+
+	mockCtx := unithelper.DummySubTaskContext(mockDal)
+
+	// page 1 is skipped, so we are expecting requests for page 2 and page 3 only
+	var requestedPages []string
+	mockApi := new(mockapi.RateLimitedApiClient)
+	mockApi.On("DoGetAsync", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		page := args.Get(1).(url.Values).Get("page")
+		requestedPages = append(requestedPages, page)
+		body := "[]"
+		if page == "2" {
+			body = "[4,5,6]"
+		}
+		res := &http.Response{
+			Request: &http.Request{
+				URL: &url.URL{},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+		handler := args.Get(3).(common.ApiAsyncCallback)
+		assert.Nil(t, handler(res))
+	}).Twice()
+	mockApi.On("NextTick", mock.Anything).Run(func(args mock.Arguments) {
+		handler := args.Get(0).(func() errors.Error)
+		assert.Nil(t, handler())
+	})
+	mockApi.On("WaitAsync").Return(nil)
+	mockApi.On("GetAfterFunction", mock.Anything).Return(nil)
+	mockApi.On("SetAfterFunction", mock.Anything).Return()
+
+	collector, err := NewApiCollector(ApiCollectorArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:    mockCtx,
+			Table:  "whatever rawtable",
+			Params: struct{ Name string }{Name: "testparams"},
+		},
+		ApiClient:   mockApi,
+		UrlTemplate: "whatever url",
+		Query: func(reqData *RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("page", fmt.Sprintf("%d", reqData.Pager.Page))
+			return query, nil
+		},
+		Concurrency:    1,
+		PageSize:       3,
+		ResponseParser: GetRawMessageArrayFromResponse,
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, collector.Execute())
+	assert.Equal(t, []string{"2", "3"}, requestedPages)
+
+	mockDal.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestResumeRemovesRawDataOfUnfinishedUnits(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	// the previous run finished input a, saved the first page of input b and then failed
+	inputA := checkpointHash([]byte(`{"Id":"a"}`))
+	inputB := checkpointHash([]byte(`{"Id":"b"}`))
+	mockDal.On("First", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		units := args.Get(0).(*[]*models.CollectorCheckpointUnit)
+		*units = append(
+			*units,
+			&models.CollectorCheckpointUnit{InputKey: inputA, Page: 1, RawIds: "1,2"},
+			&models.CollectorCheckpointUnit{InputKey: inputA, Page: 0, Finished: true},
+			&models.CollectorCheckpointUnit{InputKey: inputB, Page: 1, RawIds: "3,4"},
+		)
+	}).Return(nil).Once()
+	// only the rows of input b are removed
+	mockDal.On("Delete", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		assert.IsType(t, &RawData{}, args.Get(0))
+		where := args.Get(1).([]dal.Clause)[1]
+		assert.Equal(t, []interface{}{[]uint64{3, 4}}, where.Data.(dal.DalClause).Params)
+	}).Return(nil).Once()
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+
+	checkpoint, err := newCollectorCheckpoint(mockDal, unithelper.DummyLogger(), "whatever rawtable", "params", "key", false)
+	assert.Nil(t, err)
+	assert.True(t, checkpoint.isResuming())
+	_, ok := checkpoint.isFinished(inputA, 0)
+	assert.True(t, ok)
+	_, ok = checkpoint.isFinished(inputB, 0)
+	assert.False(t, ok)
+
 	mockDal.AssertExpectations(t)
 }
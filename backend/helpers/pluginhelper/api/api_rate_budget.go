@@ -0,0 +1,302 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	gocontext "context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ApiRateBudget is the request rate shared by all api clients of the same connection (or the same host when the
+// connection is unknown) within the process, so concurrent tasks wouldn't assume each of them owns the whole quota.
+// The rate is lowered to spread the remaining requests until the quota resets as reported by the `X-RateLimit-*`
+// headers, and requests are held off as long as `Retry-After` asks or the quota runs out
+type ApiRateBudget struct {
+	mu       sync.Mutex
+	key      string
+	requests int
+	duration time.Duration
+	// next is the earliest time the next request could be sent
+	next        time.Time
+	remaining   int
+	resetAt     time.Time
+	pausedUntil time.Time
+	clients     int
+	sent        uint64
+	throttled   uint64
+}
+
+// ApiRateBudgetState is a snapshot of an ApiRateBudget
+type ApiRateBudgetState struct {
+	Key string `json:"key"`
+	// RequestsPerHour is the rate calculated by the clients
+	RequestsPerHour int `json:"requestsPerHour"`
+	// EffectiveRequestsPerHour is the rate in effect after adapting to the rate limit headers
+	EffectiveRequestsPerHour int `json:"effectiveRequestsPerHour"`
+	// Remaining is the number of requests left before the quota resets, -1 if the server didn't tell
+	Remaining   int        `json:"remaining"`
+	ResetAt     *time.Time `json:"resetAt"`
+	PausedUntil *time.Time `json:"pausedUntil"`
+	// Clients is the number of api clients sharing the budget at the moment
+	Clients int `json:"clients"`
+	// Sent is the number of requests sent within the budget
+	Sent uint64 `json:"sent"`
+	// Throttled is the number of responses asking to slow down, i.e. 429 Too Many Requests
+	Throttled uint64 `json:"throttled"`
+}
+
+var rateBudgets = make(map[string]*ApiRateBudget)
+var rateBudgetsLock sync.Mutex
+
+// acquireApiRateBudget returns the budget of the key for an api client, with the rate updated to the latest
+// calculation. The budget must be released by releaseApiRateBudget once the client is no longer in use
+func acquireApiRateBudget(key string, requests int, duration time.Duration) *ApiRateBudget {
+	rateBudgetsLock.Lock()
+	defer rateBudgetsLock.Unlock()
+	budget, ok := rateBudgets[key]
+	if !ok {
+		budget = &ApiRateBudget{key: key, remaining: -1}
+		rateBudgets[key] = budget
+	}
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+	budget.requests = requests
+	budget.duration = duration
+	budget.clients++
+	return budget
+}
+
+func releaseApiRateBudget(budget *ApiRateBudget) {
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+	if budget.clients > 0 {
+		budget.clients--
+	}
+}
+
+// GetApiRateBudgets returns the snapshots of all budgets known to the process, sorted by key
+func GetApiRateBudgets() []*ApiRateBudgetState {
+	rateBudgetsLock.Lock()
+	budgets := make([]*ApiRateBudget, 0, len(rateBudgets))
+	for _, budget := range rateBudgets {
+		budgets = append(budgets, budget)
+	}
+	rateBudgetsLock.Unlock()
+	states := make([]*ApiRateBudgetState, len(budgets))
+	for i, budget := range budgets {
+		states[i] = budget.State()
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Key < states[j].Key
+	})
+	return states
+}
+
+// State returns a snapshot of the budget
+func (b *ApiRateBudget) State() *ApiRateBudgetState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	state := &ApiRateBudgetState{
+		Key:                      b.key,
+		RequestsPerHour:          perHour(b.baseInterval()),
+		EffectiveRequestsPerHour: perHour(b.interval(now)),
+		Remaining:                b.remaining,
+		Clients:                  b.clients,
+		Sent:                     b.sent,
+		Throttled:                b.throttled,
+	}
+	if b.resetAt.After(now) {
+		resetAt := b.resetAt
+		state.ResetAt = &resetAt
+	}
+	if b.pausedUntil.After(now) {
+		pausedUntil := b.pausedUntil
+		state.PausedUntil = &pausedUntil
+	}
+	return state
+}
+
+// Wait blocks until a request could be sent within the budget, or the ctx is done
+func (b *ApiRateBudget) Wait(ctx gocontext.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	at := b.next
+	if at.Before(now) {
+		at = now
+	}
+	if b.pausedUntil.After(at) {
+		at = b.pausedUntil
+	}
+	b.next = at.Add(b.interval(at))
+	if b.remaining > 0 {
+		b.remaining--
+	}
+	b.sent++
+	b.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	if ctx == nil {
+		ctx = gocontext.Background()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Observe adapts the budget to the rate limit headers of the response
+func (b *ApiRateBudget) Observe(res *http.Response) {
+	if res == nil {
+		return
+	}
+	now := time.Now()
+	remaining, hasRemaining := parseRateLimitInt(res.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	resetAt, hasResetAt := parseRateLimitReset(res.Header, now)
+	retryAfter, hasRetryAfter := parseRetryAfter(res.Header.Get("Retry-After"), now)
+	throttled := res.StatusCode == http.StatusTooManyRequests ||
+		(res.StatusCode == http.StatusForbidden && (hasRetryAfter || (hasRemaining && remaining == 0)))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if throttled {
+		b.throttled++
+	}
+	if hasRemaining {
+		b.remaining = remaining
+		if hasResetAt {
+			b.resetAt = resetAt
+			if remaining <= 0 && resetAt.After(b.pausedUntil) {
+				b.pausedUntil = resetAt
+			}
+		}
+	}
+	if hasRetryAfter && (throttled || res.StatusCode == http.StatusServiceUnavailable) && retryAfter.After(b.pausedUntil) {
+		b.pausedUntil = retryAfter
+	}
+}
+
+// baseInterval is the interval between requests by the calculated rate
+func (b *ApiRateBudget) baseInterval() time.Duration {
+	if b.requests <= 0 || b.duration <= 0 {
+		return 0
+	}
+	return b.duration / time.Duration(b.requests)
+}
+
+// interval is the interval between requests at the time, the remaining requests are spread evenly until the
+// quota resets if the calculated rate would use them up before that
+func (b *ApiRateBudget) interval(now time.Time) time.Duration {
+	interval := b.baseInterval()
+	if b.remaining > 0 && b.resetAt.After(now) {
+		if adaptive := b.resetAt.Sub(now) / time.Duration(b.remaining); adaptive > interval {
+			interval = adaptive
+		}
+	}
+	return interval
+}
+
+func perHour(interval time.Duration) int {
+	if interval <= 0 {
+		return 0
+	}
+	return int(time.Hour / interval)
+}
+
+// getRateBudgetKey identifies the budget by the plugin and connection id as `github:1@api.github.com`, or by the
+// host of the endpoint if the connection is unknown
+func getRateBudgetKey(endpoint string, pluginName string, connection interface{}) string {
+	host := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	if connection == nil || pluginName == "" {
+		return host
+	}
+	v := reflect.ValueOf(connection)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return host
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return host
+	}
+	id := v.FieldByName("ID")
+	if !id.IsValid() || !id.CanUint() || id.Uint() == 0 {
+		return host
+	}
+	return fmt.Sprintf("%s:%d@%s", pluginName, id.Uint(), host)
+}
+
+func parseRateLimitInt(header http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			if i, err := strconv.Atoi(value); err == nil {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// parseRateLimitReset parses the reset header which is either a unix timestamp (GitHub) or the number of seconds
+// until the quota resets (the IETF draft)
+func parseRateLimitReset(header http.Header, now time.Time) (time.Time, bool) {
+	reset, ok := parseRateLimitInt(header, "X-RateLimit-Reset", "RateLimit-Reset")
+	if !ok || reset < 0 {
+		return time.Time{}, false
+	}
+	// no quota lasts over a year, a larger number must be a timestamp
+	if reset > 365*24*3600 {
+		return time.Unix(int64(reset), 0), true
+	}
+	return now.Add(time.Duration(reset) * time.Second), true
+}
+
+// parseRetryAfter parses the Retry-After header which is either a number of seconds or a http date
+func parseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return time.Time{}, false
+		}
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at, true
+	}
+	return time.Time{}, false
+}
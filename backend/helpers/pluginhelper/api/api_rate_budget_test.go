@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	gocontext "context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRateBudgetConnection struct {
+	common.Model
+	Endpoint string
+}
+
+func TestGetRateBudgetKey(t *testing.T) {
+	connection := &testRateBudgetConnection{Model: common.Model{ID: 3}}
+	assert.Equal(t, "github:3@api.github.com", getRateBudgetKey("https://api.github.com/", "github", connection))
+	assert.Equal(t, "api.github.com", getRateBudgetKey("https://api.github.com/", "", connection))
+	assert.Equal(t, "api.github.com", getRateBudgetKey("https://api.github.com/", "github", &testRateBudgetConnection{}))
+	assert.Equal(t, "gitlab.example.com:8443", getRateBudgetKey("https://gitlab.example.com:8443/api/v4/", "", nil))
+}
+
+func TestAcquireApiRateBudgetShared(t *testing.T) {
+	a := acquireApiRateBudget("TestAcquireApiRateBudgetShared", 3600, time.Hour)
+	b := acquireApiRateBudget("TestAcquireApiRateBudgetShared", 1800, time.Hour)
+	assert.Same(t, a, b)
+	state := a.State()
+	assert.Equal(t, 2, state.Clients)
+	assert.Equal(t, 1800, state.RequestsPerHour)
+	releaseApiRateBudget(a)
+	releaseApiRateBudget(b)
+	assert.Equal(t, 0, a.State().Clients)
+}
+
+func TestApiRateBudgetWait(t *testing.T) {
+	budget := &ApiRateBudget{key: "wait", remaining: -1, requests: 20, duration: time.Second}
+	begin := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, budget.Wait(gocontext.Background()))
+	}
+	// the first request goes immediately, the other two wait for 50ms each
+	assert.GreaterOrEqual(t, time.Since(begin), 100*time.Millisecond)
+	assert.Equal(t, uint64(3), budget.State().Sent)
+
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	cancel()
+	budget.pausedUntil = time.Now().Add(time.Hour)
+	assert.Equal(t, gocontext.Canceled, budget.Wait(ctx))
+}
+
+func TestApiRateBudgetObserveRemaining(t *testing.T) {
+	budget := &ApiRateBudget{key: "remaining", remaining: -1, requests: 3600, duration: time.Hour}
+	res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	res.Header.Set("X-RateLimit-Remaining", "10")
+	res.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(100*time.Second).Unix(), 10))
+	budget.Observe(res)
+
+	state := budget.State()
+	assert.Equal(t, 10, state.Remaining)
+	assert.NotNil(t, state.ResetAt)
+	assert.Nil(t, state.PausedUntil)
+	// 10 requests in 100 seconds
+	assert.InDelta(t, 360, state.EffectiveRequestsPerHour, 20)
+	assert.Equal(t, 3600, state.RequestsPerHour)
+
+	res.Header.Set("X-RateLimit-Remaining", "0")
+	budget.Observe(res)
+	assert.NotNil(t, budget.State().PausedUntil)
+}
+
+func TestApiRateBudgetObserveRetryAfter(t *testing.T) {
+	budget := &ApiRateBudget{key: "retry-after", remaining: -1, requests: 3600, duration: time.Hour}
+	res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	res.Header.Set("Retry-After", "60")
+	budget.Observe(res)
+	assert.Nil(t, budget.State().PausedUntil)
+
+	res.StatusCode = http.StatusTooManyRequests
+	budget.Observe(res)
+	state := budget.State()
+	assert.Equal(t, uint64(1), state.Throttled)
+	assert.NotNil(t, state.PausedUntil)
+	assert.WithinDuration(t, time.Now().Add(60*time.Second), *state.PausedUntil, 2*time.Second)
+}
@@ -18,11 +18,15 @@ limitations under the License.
 package api
 
 import (
+	gocontext "context"
 	"fmt"
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
 	"reflect"
 	"strings"
 )
@@ -110,16 +114,43 @@ func (c *BatchSave) Flush() errors.Error {
 	if c.tableName != "" {
 		clauses = append(clauses, dal.From(c.tableName))
 	}
+	_, span := tracing.Start(
+		getExecContext(c.basicRes),
+		"batch save flush",
+		tracing.ATTR_TABLE.String(c.metricsTableName()),
+		tracing.ATTR_ROWS.Int(c.current),
+	)
 	err := c.db.CreateOrUpdate(c.slots.Slice(0, c.current).Interface(), clauses...)
+	tracing.End(span, err)
 	if err != nil {
 		return err
 	}
 	c.log.Debug("batch save flush total %d records to database", c.current)
+	plugin.GetSubTaskMetrics(c.basicRes).AddRowsWritten(c.metricsTableName(), c.current)
+	metrics.ObserveBatchSaveFlush(c.metricsTableName(), c.current)
 	c.current = 0
 	c.valueIndex = make(map[string]int)
 	return nil
 }
 
+// getExecContext returns the go context of the subtask which carries its span, if basicRes is a subtask context
+func getExecContext(basicRes context.BasicRes) gocontext.Context {
+	if execContext, ok := basicRes.(plugin.ExecContext); ok {
+		return execContext.GetContext()
+	}
+	return gocontext.Background()
+}
+
+func (c *BatchSave) metricsTableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	if tabler, ok := reflect.New(c.slotType.Elem()).Interface().(dal.Tabler); ok {
+		return tabler.TableName()
+	}
+	return c.slotType.Elem().Name()
+}
+
 // Close would flash the cache and release resources
 func (c *BatchSave) Close() errors.Error {
 	if c.current > 0 {
@@ -29,6 +29,7 @@ import (
 )
 
 // BasicAuth implements HTTP Basic Authentication
+// Password could be a reference to an external secret, check ResolveSecret for details
 type BasicAuth struct {
 	Username string `mapstructure:"username" validate:"required" json:"username"`
 	Password string `mapstructure:"password" validate:"required" json:"password" gorm:"serializer:encdec"`
@@ -39,9 +40,14 @@ func (ba *BasicAuth) GetEncodedToken() string {
 	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v:%v", ba.Username, ba.Password)))
 }
 
-// SetupAuthentication sets up the request headers for authentication
+// SetupAuthentication sets up the request headers for authentication, the password could be a secret reference
 func (ba *BasicAuth) SetupAuthentication(request *http.Request) errors.Error {
-	request.Header.Set("Authorization", fmt.Sprintf("Basic %v", ba.GetEncodedToken()))
+	password, err := ResolveSecret(ba.Password)
+	if err != nil {
+		return err
+	}
+	resolved := BasicAuth{Username: ba.Username, Password: password}
+	request.Header.Set("Authorization", fmt.Sprintf("Basic %v", resolved.GetEncodedToken()))
 	return nil
 }
 
@@ -54,13 +60,18 @@ func (ba *BasicAuth) GetBasicAuthenticator() plugin.ApiAuthenticator {
 }
 
 // AccessToken implements HTTP Bearer Authentication with Access Token
+// Token could be a reference to an external secret, check ResolveSecret for details
 type AccessToken struct {
 	Token string `mapstructure:"token" validate:"required" json:"token" gorm:"serializer:encdec"`
 }
 
-// SetupAuthentication sets up the request headers for authentication
+// SetupAuthentication sets up the request headers for authentication, the token could be a secret reference
 func (at *AccessToken) SetupAuthentication(request *http.Request) errors.Error {
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %v", at.Token))
+	token, err := ResolveSecret(at.Token)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
 	return nil
 }
 
@@ -70,6 +81,7 @@ func (at *AccessToken) GetAccessTokenAuthenticator() plugin.ApiAuthenticator {
 }
 
 // AppKey implements the API Key and Secret authentication mechanism
+// SecretKey could be a reference to an external secret, which is resolved by NewApiClientFromConnection
 type AppKey struct {
 	AppId     string `mapstructure:"appId" validate:"required" json:"appId"`
 	SecretKey string `mapstructure:"secretKey" validate:"required" json:"secretKey" gorm:"serializer:encdec"`
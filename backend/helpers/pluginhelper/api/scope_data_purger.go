@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// PurgedRows is the number of rows removed from a table
+type PurgedRows struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+}
+
+// ScopeDataPurger removes all data collected for a scope by following the lineage every record carries,
+// that is, the `params` of `_raw_*` tables and the `_raw_data_params`/`_raw_data_table` of tool and domain layer tables,
+// which are exactly the RawDataSubTaskArgs.Params used by the subtasks
+type ScopeDataPurger struct {
+	db         dal.Dal
+	log        log.Logger
+	pluginName string
+}
+
+// NewScopeDataPurger creates a ScopeDataPurger for the plugin, `pluginName` must be the one used in the names of
+// its raw tables, i.e. `github` for `_raw_github_api_issues`
+func NewScopeDataPurger(basicRes context.BasicRes, pluginName string) *ScopeDataPurger {
+	return &ScopeDataPurger{
+		db:         basicRes.GetDal(),
+		log:        basicRes.GetLogger(),
+		pluginName: pluginName,
+	}
+}
+
+// Purge deletes raw, tool layer and domain layer rows produced with the given params and resets the collector states,
+// the numbers of rows are reported without any deletion if dryRun was true
+func (p *ScopeDataPurger) Purge(params interface{}, dryRun bool) ([]*PurgedRows, errors.Error) {
+	paramsBytes, e := json.Marshal(params)
+	if e != nil {
+		return nil, errors.Default.Wrap(e, "unable to serialize raw data params")
+	}
+	rawParams := string(paramsBytes)
+	rawTablePrefix := fmt.Sprintf("_raw_%s_", p.pluginName)
+	toolTablePrefix := fmt.Sprintf("_tool_%s_", p.pluginName)
+	// `_` is a wildcard of LIKE, escape it to match the prefix literally
+	rawTablePattern := strings.ReplaceAll(rawTablePrefix, "_", `\_`) + "%"
+
+	tables, err := p.db.AllTables()
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error listing tables")
+	}
+	targets := make([]*purgeTarget, 0)
+	for _, table := range tables {
+		var clauses []dal.Clause
+		switch {
+		case strings.HasPrefix(table, rawTablePrefix):
+			clauses = []dal.Clause{dal.Where("params = ?", rawParams)}
+		case strings.HasPrefix(table, toolTablePrefix):
+			if !p.hasLineage(table) {
+				continue
+			}
+			clauses = []dal.Clause{dal.Where("_raw_data_params = ?", rawParams)}
+		case !strings.HasPrefix(table, "_"):
+			// domain layer tables are shared by all plugins, only rows converted from our raw tables are matched
+			if !p.hasLineage(table) {
+				continue
+			}
+			clauses = []dal.Clause{dal.Where("_raw_data_params = ? AND _raw_data_table LIKE ?", rawParams, rawTablePattern)}
+		default:
+			continue
+		}
+		targets = append(targets, &purgeTarget{table: table, clauses: clauses})
+	}
+	// collectors would start over from scratch next time
+	for _, table := range []string{
+		models.CollectorLatestState{}.TableName(),
+		models.CollectorCheckpoint{}.TableName(),
+		models.CollectorCheckpointUnit{}.TableName(),
+	} {
+		targets = append(targets, &purgeTarget{
+			table:   table,
+			clauses: []dal.Clause{dal.Where("raw_data_params = ? AND raw_data_table LIKE ?", rawParams, rawTablePattern)},
+		})
+	}
+
+	purged := make([]*PurgedRows, 0)
+	toDelete := make([]*purgeTarget, 0)
+	for _, target := range targets {
+		count, err := p.db.Count(append([]dal.Clause{dal.From(target.table)}, target.clauses...)...)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("error counting rows of %s", target.table))
+		}
+		if count > 0 {
+			purged = append(purged, &PurgedRows{Table: target.table, Rows: count})
+			toDelete = append(toDelete, target)
+		}
+	}
+	if dryRun || len(toDelete) == 0 {
+		return purged, nil
+	}
+	// all or nothing, a scope must not be left partially purged
+	tx := p.db.Begin()
+	for _, target := range toDelete {
+		err = deleteScopeRows(tx, target.table, target.clauses...)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("error deleting rows of %s", target.table))
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error committing the purge")
+	}
+	for _, rows := range purged {
+		p.log.Info("purged %d rows from %s", rows.Rows, rows.Table)
+	}
+	return purged, nil
+}
+
+type purgeTarget struct {
+	table   string
+	clauses []dal.Clause
+}
+
+func (p *ScopeDataPurger) hasLineage(table string) bool {
+	columns, err := p.db.GetColumns(dal.DefaultTabler{Name: table}, func(columnMeta dal.ColumnMeta) bool {
+		return columnMeta.Name() == "_raw_data_params"
+	})
+	if err != nil {
+		p.log.Warn(err, "failed to get columns of table %s", table)
+		return false
+	}
+	return len(columns) > 0
+}
+
+// deleteScopeRows deletes the matched rows of the table, the table must be named by a From clause since gorm
+// resolves the table of a DefaultTabler from the zero value of the type, that is, an empty name
+func deleteScopeRows(db dal.Dal, table string, clauses ...dal.Clause) errors.Error {
+	return db.Delete(&dal.DefaultTabler{Name: table}, append([]dal.Clause{dal.From(table)}, clauses...)...)
+}
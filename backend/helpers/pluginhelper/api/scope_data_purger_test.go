@@ -0,0 +1,153 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type testPurgeParams struct {
+	ConnectionId uint64
+	Name         string
+}
+
+func TestScopeDataPurger(t *testing.T) {
+	lineageColumn := new(mockdal.ColumnMeta)
+	lineageColumn.On("Name").Return("_raw_data_params")
+
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AllTables").Return([]string{
+		"_raw_github_api_issues",
+		"_raw_gitlab_api_issues",
+		"_tool_github_issues",
+		"_tool_github_connections",
+		"issues",
+		"project_mapping",
+	}, nil)
+	mockDal.On("GetColumns", mock.Anything, mock.Anything).Return(
+		func(dst dal.Tabler, filter func(dal.ColumnMeta) bool) []dal.ColumnMeta {
+			switch dst.TableName() {
+			case "_tool_github_issues", "issues":
+				return []dal.ColumnMeta{lineageColumn}
+			}
+			return nil
+		},
+		nil,
+	)
+	counts := map[string]int64{
+		"_raw_github_api_issues":          10,
+		"_tool_github_issues":             8,
+		"issues":                          8,
+		"_devlake_collector_latest_state": 1,
+	}
+	// each Count receives dal.From(table) as the first clause
+	var countedTables []string
+	mockDal.On("Count", mock.Anything).Return(
+		func(clauses ...dal.Clause) int64 {
+			table := clauses[0].Data.(string)
+			countedTables = append(countedTables, table)
+			return counts[table]
+		},
+		nil,
+	)
+	// the rows are deleted within a transaction, each Delete receives dal.From(table) as the first clause
+	var deletedTables []string
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("Delete", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		clauses := args.Get(1).([]dal.Clause)
+		deletedTables = append(deletedTables, clauses[0].Data.(string))
+	}).Return(nil)
+	mockTx.On("Commit").Return(nil)
+	mockDal.On("Begin").Return(mockTx)
+
+	purger := &ScopeDataPurger{db: mockDal, log: unithelper.DummyLogger(), pluginName: "github"}
+
+	purged, err := purger.Purge(&testPurgeParams{ConnectionId: 1, Name: "apache/incubator-devlake"}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"_raw_github_api_issues",
+		"_tool_github_issues",
+		"issues",
+		"_devlake_collector_latest_state",
+		"_devlake_collector_checkpoints",
+		"_devlake_collector_checkpoint_units",
+	}, countedTables)
+	assert.Len(t, purged, 4)
+	assert.Empty(t, deletedTables)
+
+	purged, err = purger.Purge(&testPurgeParams{ConnectionId: 1, Name: "apache/incubator-devlake"}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, &PurgedRows{Table: "_raw_github_api_issues", Rows: 10}, purged[0])
+	assert.Equal(t, []string{"_raw_github_api_issues", "_tool_github_issues", "issues", "_devlake_collector_latest_state"}, deletedTables)
+	mockTx.AssertNumberOfCalls(t, "Commit", 1)
+}
+
+func TestScopeDataPurgerRollback(t *testing.T) {
+	lineageColumn := new(mockdal.ColumnMeta)
+	lineageColumn.On("Name").Return("_raw_data_params")
+
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AllTables").Return([]string{"_raw_github_api_issues", "_tool_github_issues"}, nil)
+	mockDal.On("GetColumns", mock.Anything, mock.Anything).Return([]dal.ColumnMeta{lineageColumn}, nil)
+	mockDal.On("Count", mock.Anything).Return(int64(1), nil)
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+	mockTx.On("Delete", mock.Anything, mock.Anything).Return(errors.Default.New("lock wait timeout")).Once()
+	mockTx.On("Rollback").Return(nil)
+	mockDal.On("Begin").Return(mockTx)
+
+	purger := &ScopeDataPurger{db: mockDal, log: unithelper.DummyLogger(), pluginName: "github"}
+	purged, err := purger.Purge(&testPurgeParams{ConnectionId: 1, Name: "apache/incubator-devlake"}, false)
+	assert.NotNil(t, err)
+	assert.Nil(t, purged)
+	mockTx.AssertNumberOfCalls(t, "Rollback", 1)
+	mockTx.AssertNotCalled(t, "Commit")
+}
+
+// the mocked dal can not tell whether the statements are valid, render them with gorm instead
+func TestDeleteScopeRowsSql(t *testing.T) {
+	db, e := gorm.Open(
+		mysql.New(mysql.Config{DSN: "user:pass@tcp(localhost:3306)/lake", SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true},
+	)
+	assert.Nil(t, e)
+	var statements []string
+	e = db.Callback().Delete().After("gorm:delete").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	})
+	assert.Nil(t, e)
+
+	err := deleteScopeRows(dalgorm.NewDalgorm(db), "_tool_github_issues", dal.Where("_raw_data_params = ?", "{}"))
+	assert.Nil(t, err)
+	err = deleteScopeRows(dalgorm.NewDalgorm(db), "_devlake_collector_latest_state", dal.Where("raw_data_params = ? AND raw_data_table LIKE ?", "{}", "\\_raw\\_github\\_%"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"DELETE FROM `_tool_github_issues` WHERE _raw_data_params = ?",
+		"DELETE FROM `_devlake_collector_latest_state` WHERE raw_data_params = ? AND raw_data_table LIKE ?",
+	}, statements)
+}
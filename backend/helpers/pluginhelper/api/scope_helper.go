@@ -259,6 +259,74 @@ func (c *ScopeApiHelper[Conn, Scope, Tr]) GetScope(input *plugin.ApiResourceInpu
 	return &plugin.ApiResourceOutput{Body: scopeRes, Status: http.StatusOK}, nil
 }
 
+// ScopeDeletionRes is the output of ScopeApiHelper.Delete
+type ScopeDeletionRes struct {
+	DryRun bool          `json:"dryRun"`
+	Purged []*PurgedRows `json:"purged"`
+}
+
+// Delete purges all data collected for the scope, i.e. raw, tool layer, domain layer rows and collector states,
+// and then removes the scope itself unless `dataOnly=true` was given in the query string. Nothing would be
+// deleted with `dryRun=true`, the numbers of rows to be removed are reported instead.
+// The getRawParams must return the RawDataSubTaskArgs.Params that the subtasks of the plugin use for the scope.
+func (c *ScopeApiHelper[Conn, Scope, Tr]) Delete(
+	input *plugin.ApiResourceInput,
+	fieldName string,
+	getRawParams func(scope *Scope) interface{},
+) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, scopeId := extractFromReqParam(input.Params)
+	if connectionId == 0 || len(scopeId) == 0 || scopeId == "0" {
+		return nil, errors.BadInput.New("invalid path params")
+	}
+	err := c.VerifyConnection(connectionId)
+	if err != nil {
+		return nil, err
+	}
+	dryRun, dataOnly := false, false
+	if v := input.Query.Get("dryRun"); v != "" {
+		dryRun, err = errors.Convert01(strconv.ParseBool(v))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid dryRun")
+		}
+	}
+	if v := input.Query.Get("dataOnly"); v != "" {
+		dataOnly, err = errors.Convert01(strconv.ParseBool(v))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid dataOnly")
+		}
+	}
+	query := dal.Where(fmt.Sprintf("connection_id = ? AND %s = ?", fieldName), connectionId, scopeId)
+	var scope Scope
+	err = c.db.First(&scope, query)
+	if err != nil {
+		if c.db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("Scope not found")
+		}
+		return nil, err
+	}
+	purger := &ScopeDataPurger{
+		db:         c.db,
+		log:        c.log,
+		pluginName: getPluginNameOfConnection(new(Conn)),
+	}
+	purged, err := purger.Purge(getRawParams(&scope), dryRun)
+	if err != nil {
+		return nil, err
+	}
+	if !dataOnly {
+		if !dryRun {
+			err = c.db.Delete(&scope, query)
+			if err != nil {
+				return nil, errors.Default.Wrap(err, "error deleting scope")
+			}
+		}
+		if tabler, ok := interface{}(&scope).(dal.Tabler); ok {
+			purged = append(purged, &PurgedRows{Table: tabler.TableName(), Rows: 1})
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: &ScopeDeletionRes{DryRun: dryRun, Purged: purged}, Status: http.StatusOK}, nil
+}
+
 func (c *ScopeApiHelper[Conn, Scope, Tr]) VerifyConnection(connId uint64) errors.Error {
 	var conn Conn
 	err := c.connHelper.FirstById(&conn, connId)
@@ -0,0 +1,308 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	aha "github.com/apache/incubator-devlake/core/plugin"
+)
+
+// SecretReferencePrefix marks a value as a reference to a secret kept outside lake, a reference looks like
+// `secret://<provider>/<path>`, i.e. `secret://env/DEVLAKE_SECRET_GITHUB_TOKEN`, `secret://file/run/secrets/jira`
+// or `secret://vault/secret/data/jira#password`. Tokens and passwords never contain `://`, so a literal secret
+// would not be taken as a reference by accident.
+const SecretReferencePrefix = "secret://"
+
+// SecretProvider resolves the path of references to secrets of a provider
+type SecretProvider interface {
+	Resolve(path string) (string, errors.Error)
+}
+
+var secretProviders = map[string]SecretProvider{
+	"env":   envSecretProvider{},
+	"file":  fileSecretProvider{},
+	"vault": newVaultSecretProvider(),
+}
+
+// RegisterSecretProvider registers the provider for references of `secret://<name>/<path>`
+func RegisterSecretProvider(name string, provider SecretProvider) {
+	secretProviders[name] = provider
+}
+
+// IsSecretReference tells whether the value is a reference to an external secret
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretReferencePrefix)
+}
+
+// ResolveSecret returns the secret the value refers to, or the value itself if it is a literal secret
+func ResolveSecret(value string) (string, errors.Error) {
+	if !IsSecretReference(value) {
+		return value, nil
+	}
+	name, path, _ := strings.Cut(strings.TrimPrefix(value, SecretReferencePrefix), "/")
+	provider, ok := secretProviders[name]
+	if !ok {
+		return "", errors.BadInput.New(fmt.Sprintf("secret provider %s is not supported", name))
+	}
+	secret, err := provider.Resolve(path)
+	if err != nil {
+		return "", errors.Default.Wrap(err, fmt.Sprintf("failed to resolve secret reference of %s", name))
+	}
+	return secret, nil
+}
+
+// resolveConnectionSecrets returns a copy of the connection with references in the `serializer:encdec` fields
+// replaced by the secrets, the connection itself is left untouched, so the references are what's saved and
+// returned by the connection apis
+func resolveConnectionSecrets(connection aha.ApiConnection) (aha.ApiConnection, errors.Error) {
+	v := reflect.ValueOf(connection)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return connection, nil
+	}
+	clone := reflect.New(v.Elem().Type())
+	clone.Elem().Set(v.Elem())
+	err := resolveStructSecrets(clone.Elem())
+	if err != nil {
+		return nil, err
+	}
+	return clone.Interface().(aha.ApiConnection), nil
+}
+
+func resolveStructSecrets(v reflect.Value) errors.Error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+		if field.Anonymous && fieldValue.Kind() == reflect.Struct {
+			// the cached authenticator points to the original connection
+			if multiAuth, ok := fieldValue.Addr().Interface().(*MultiAuth); ok {
+				multiAuth.apiAuthenticator = nil
+			}
+			err := resolveStructSecrets(fieldValue)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if fieldValue.Kind() == reflect.String && strings.Contains(field.Tag.Get("gorm"), "serializer:encdec") {
+			secret, err := ResolveSecret(fieldValue.String())
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("failed to resolve %s", field.Name))
+			}
+			fieldValue.SetString(secret)
+		}
+	}
+	return nil
+}
+
+// copyPreparedConnection copies the state set up by PrepareApiClient on the resolved copy back to the connection,
+// i.e. the tokens of GitHub connections, while the secret fields of the connection keep the references
+func copyPreparedConnection(connection aha.ApiConnection, resolved aha.ApiConnection) {
+	dst := reflect.ValueOf(connection)
+	src := reflect.ValueOf(resolved)
+	if dst.Kind() != reflect.Ptr || dst.Elem().Kind() != reflect.Struct || dst.Pointer() == src.Pointer() {
+		return
+	}
+	references := reflect.New(dst.Elem().Type()).Elem()
+	references.Set(dst.Elem())
+	dst.Elem().Set(src.Elem())
+	restoreStructSecrets(dst.Elem(), references)
+}
+
+func restoreStructSecrets(v reflect.Value, references reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+		if field.Anonymous && fieldValue.Kind() == reflect.Struct {
+			restoreStructSecrets(fieldValue, references.Field(i))
+			continue
+		}
+		if fieldValue.Kind() == reflect.String && strings.Contains(field.Tag.Get("gorm"), "serializer:encdec") {
+			fieldValue.SetString(references.Field(i).String())
+		}
+	}
+}
+
+// envSecretProvider reads secrets from environment variables prefixed with DEVLAKE_SECRET_, so the
+// variables of lake itself are never exposed
+type envSecretProvider struct{}
+
+const envSecretPrefix = "DEVLAKE_SECRET_"
+
+func (envSecretProvider) Resolve(name string) (string, errors.Error) {
+	if !strings.HasPrefix(name, envSecretPrefix) {
+		return "", errors.BadInput.New(fmt.Sprintf("environment variable %s is not allowed, only %s* are", name, envSecretPrefix))
+	}
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return "", errors.NotFound.New(fmt.Sprintf("environment variable %s is not set", name))
+	}
+	return secret, nil
+}
+
+// fileSecretProvider reads secrets from files under SECRETS_FILE_DIRS, which defaults to /run/secrets,
+// the path is absolute, i.e. `secret://file/run/secrets/jira` refers to /run/secrets/jira
+type fileSecretProvider struct{}
+
+func (fileSecretProvider) Resolve(path string) (string, errors.Error) {
+	path = filepath.Clean("/" + path)
+	dirs := config.GetConfig().GetString("SECRETS_FILE_DIRS")
+	if dirs == "" {
+		dirs = "/run/secrets"
+	}
+	if !isUnderDirs(path, dirs) {
+		return "", errors.BadInput.New(fmt.Sprintf("file %s is not under SECRETS_FILE_DIRS", path))
+	}
+	// a link under the dirs must not expose a file outside of them
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", errors.Default.Wrap(err, fmt.Sprintf("failed to read secret file %s", path))
+	}
+	if !isUnderDirs(realPath, dirs) {
+		return "", errors.BadInput.New(fmt.Sprintf("file %s links to %s which is not under SECRETS_FILE_DIRS", path, realPath))
+	}
+	content, err := os.ReadFile(realPath)
+	if err != nil {
+		return "", errors.Default.Wrap(err, fmt.Sprintf("failed to read secret file %s", path))
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// isUnderDirs tells whether the path is under one of the comma separated dirs, or the targets of them if the dirs
+// are links, i.e. mounted secrets of kubernetes
+func isUnderDirs(path string, dirs string) bool {
+	for _, dir := range strings.Split(dirs, ",") {
+		dir = filepath.Clean(strings.TrimSpace(dir))
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+		if realDir, err := filepath.EvalSymlinks(dir); err == nil && strings.HasPrefix(path, realDir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// vaultSecretProvider reads secrets from the KV engine of Vault at VAULT_ADDR with VAULT_TOKEN, the reference is
+// `secret://vault/<path>#<key>`, i.e. `secret://vault/secret/data/jira#password`, secrets are cached for
+// VAULT_CACHE_SECONDS
+type vaultSecretProvider struct {
+	client *http.Client
+	mu     sync.Mutex
+	cache  map[string]vaultCachedSecret
+}
+
+type vaultCachedSecret struct {
+	data      map[string]interface{}
+	expiredAt time.Time
+}
+
+func newVaultSecretProvider() *vaultSecretProvider {
+	return &vaultSecretProvider{
+		client: &http.Client{Timeout: 10 * time.Second},
+		cache:  make(map[string]vaultCachedSecret),
+	}
+}
+
+func (p *vaultSecretProvider) Resolve(reference string) (string, errors.Error) {
+	path, key, found := strings.Cut(reference, "#")
+	if !found || path == "" || key == "" {
+		return "", errors.BadInput.New("vault reference must be in the format of secret://vault/<path>#<key>")
+	}
+	data, err := p.read(strings.Trim(path, "/"))
+	if err != nil {
+		return "", err
+	}
+	secret, ok := data[key].(string)
+	if !ok {
+		return "", errors.NotFound.New(fmt.Sprintf("key %s is not found in vault path %s", key, path))
+	}
+	return secret, nil
+}
+
+func (p *vaultSecretProvider) read(path string) (map[string]interface{}, errors.Error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cached, ok := p.cache[path]; ok && time.Now().Before(cached.expiredAt) {
+		return cached.data, nil
+	}
+	cfg := config.GetConfig()
+	addr := strings.TrimRight(cfg.GetString("VAULT_ADDR"), "/")
+	if addr == "" {
+		return nil, errors.BadInput.New("VAULT_ADDR is not set")
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s", addr, path), nil)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	req.Header.Set("X-Vault-Token", cfg.GetString("VAULT_TOKEN"))
+	if namespace := cfg.GetString("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to request vault")
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to read response of vault")
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("vault responded %d for %s", res.StatusCode, path))
+	}
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	err = json.Unmarshal(body, &secret)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to decode response of vault")
+	}
+	data := secret.Data
+	// KV version 2 nests the secret in data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+	ttl := cfg.GetInt("VAULT_CACHE_SECONDS")
+	if ttl <= 0 {
+		ttl = 60
+	}
+	p.cache[path] = vaultCachedSecret{data: data, expiredAt: time.Now().Add(time.Duration(ttl) * time.Second)}
+	return data, nil
+}
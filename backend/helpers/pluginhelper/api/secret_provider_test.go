@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSecretConnection struct {
+	RestConnection
+	BasicAuth
+	MultiAuth
+}
+
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("DEVLAKE_SECRET_TEST_TOKEN", "s3cret")
+	secret, err := ResolveSecret("secret://env/DEVLAKE_SECRET_TEST_TOKEN")
+	assert.Nil(t, err)
+	assert.Equal(t, "s3cret", secret)
+
+	// values without the marker are literal secrets
+	for _, literal := range []string{"literal:with:colons", "env:DEVLAKE_SECRET_TEST_TOKEN"} {
+		secret, err = ResolveSecret(literal)
+		assert.Nil(t, err)
+		assert.Equal(t, literal, secret)
+	}
+
+	t.Setenv("TEST_NOT_A_SECRET", "s3cret")
+	_, err = ResolveSecret("secret://env/TEST_NOT_A_SECRET")
+	assert.Equal(t, errors.BadInput, err.GetType())
+	_, err = ResolveSecret("secret://env/DB_URL")
+	assert.NotNil(t, err)
+	_, err = ResolveSecret("secret://env/DEVLAKE_SECRET_NOT_SET")
+	assert.NotNil(t, err)
+	_, err = ResolveSecret("secret://unknown/path")
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
+
+func TestResolveFileSecret(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jira")
+	assert.Nil(t, os.WriteFile(path, []byte("from-file\n"), 0600))
+	config.GetConfig().Set("SECRETS_FILE_DIRS", dir)
+	defer config.GetConfig().Set("SECRETS_FILE_DIRS", "")
+
+	secret, err := ResolveSecret("secret://file" + path)
+	assert.Nil(t, err)
+	assert.Equal(t, "from-file", secret)
+
+	_, err = ResolveSecret("secret://file" + filepath.Join(dir, "..", "jira"))
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
+
+func TestResolveFileSecretSymlink(t *testing.T) {
+	dir := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "passwd")
+	assert.Nil(t, os.WriteFile(outside, []byte("outside"), 0600))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "jira"), []byte("inside"), 0600))
+	assert.Nil(t, os.Symlink(outside, filepath.Join(dir, "escape")))
+	assert.Nil(t, os.Symlink(filepath.Join(dir, "jira"), filepath.Join(dir, "alias")))
+	config.GetConfig().Set("SECRETS_FILE_DIRS", dir)
+	defer config.GetConfig().Set("SECRETS_FILE_DIRS", "")
+
+	_, err := ResolveSecret("secret://file" + filepath.Join(dir, "escape"))
+	assert.Equal(t, errors.BadInput, err.GetType())
+
+	secret, err := ResolveSecret("secret://file" + filepath.Join(dir, "alias"))
+	assert.Nil(t, err)
+	assert.Equal(t, "inside", secret)
+}
+
+func TestResolveVaultSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/secret/data/jira", r.URL.Path)
+		assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+		_, _ = w.Write([]byte(`{"data":{"data":{"password":"from-vault"},"metadata":{"version":1}}}`))
+	}))
+	defer server.Close()
+	config.GetConfig().Set("VAULT_ADDR", server.URL)
+	config.GetConfig().Set("VAULT_TOKEN", "vault-token")
+	defer config.GetConfig().Set("VAULT_ADDR", "")
+
+	secret, err := ResolveSecret("secret://vault/secret/data/jira#password")
+	assert.Nil(t, err)
+	assert.Equal(t, "from-vault", secret)
+
+	_, err = ResolveSecret("secret://vault/secret/data/jira#username")
+	assert.NotNil(t, err)
+}
+
+func TestResolveConnectionSecrets(t *testing.T) {
+	t.Setenv("DEVLAKE_SECRET_TEST_PASSWORD", "s3cret")
+	connection := &testSecretConnection{
+		BasicAuth: BasicAuth{Username: "user", Password: "secret://env/DEVLAKE_SECRET_TEST_PASSWORD"},
+		MultiAuth: MultiAuth{AuthMethod: "BasicAuth"},
+	}
+	resolved, err := resolveConnectionSecrets(connection)
+	assert.Nil(t, err)
+	assert.Equal(t, "s3cret", resolved.(*testSecretConnection).Password)
+	assert.Equal(t, "secret://env/DEVLAKE_SECRET_TEST_PASSWORD", connection.Password)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	assert.Nil(t, connection.SetupAuthentication(req))
+	username, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "s3cret", password)
+}
@@ -33,5 +33,6 @@ func DummyLogger() *mocklog.Logger {
 	logger.On("Warn", mock.Anything, mock.Anything).Maybe()
 	logger.On("Error", mock.Anything, mock.Anything, mock.Anything).Maybe()
 	logger.On("Nested", mock.Anything).Return(logger).Maybe()
+	logger.On("WithFields", mock.Anything).Return(logger).Maybe()
 	return logger
 }
@@ -20,6 +20,7 @@ package context
 import (
 	gocontext "context"
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"sync"
 	"sync/atomic"
@@ -61,6 +62,12 @@ func (c *defaultExecContext) GetContext() gocontext.Context {
 	return c.ctx
 }
 
+// SetContext replaces the go context, i.e. with the one carrying the span of the subtask.
+// It must be called before the subtask starts
+func (c *defaultExecContext) SetContext(ctx gocontext.Context) {
+	c.ctx = ctx
+}
+
 func (c *defaultExecContext) GetData() interface{} {
 	return c.data
 }
@@ -97,9 +104,10 @@ func (c *defaultExecContext) IncProgress(progressType plugin.ProgressType, quant
 }
 
 func (c *defaultExecContext) fork(name string) *defaultExecContext {
+	basicRes := c.BasicRes.NestedLogger(name)
 	return newDefaultExecContext(
 		c.ctx,
-		c.BasicRes.NestedLogger(name),
+		basicRes.ReplaceLogger(basicRes.GetLogger().WithFields(log.Fields{log.FIELD_SUBTASK: name})),
 		name,
 		c.data,
 		c.progress,
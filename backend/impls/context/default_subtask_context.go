@@ -29,11 +29,13 @@ type DefaultSubTaskContext struct {
 	*defaultExecContext
 	taskCtx          *DefaultTaskContext
 	LastProgressTime time.Time
+	metrics          *plugin.SubTaskMetrics
 }
 
 // SetProgress FIXME ...
 func (c *DefaultSubTaskContext) SetProgress(current int, total int) {
 	c.defaultExecContext.SetProgress(plugin.SubTaskSetProgress, current, total)
+	c.metrics.SetRecords(current, total)
 	if total > -1 {
 		c.BasicRes.GetLogger().Info("total jobs: %d", c.total)
 	}
@@ -42,6 +44,7 @@ func (c *DefaultSubTaskContext) SetProgress(current int, total int) {
 // IncProgress FIXME ...
 func (c *DefaultSubTaskContext) IncProgress(quantity int) {
 	c.defaultExecContext.IncProgress(plugin.SubTaskIncProgress, quantity)
+	c.metrics.SetRecords(int(c.current), c.total)
 	if c.LastProgressTime.IsZero() || c.LastProgressTime.Add(3*time.Second).Before(time.Now()) || c.current%1000 == 0 {
 		c.LastProgressTime = time.Now()
 		c.BasicRes.GetLogger().Info("finished records: %d", c.current)
@@ -50,6 +53,11 @@ func (c *DefaultSubTaskContext) IncProgress(quantity int) {
 	}
 }
 
+// GetSubTaskMetrics returns the execution statistics of the subtask
+func (c *DefaultSubTaskContext) GetSubTaskMetrics() *plugin.SubTaskMetrics {
+	return c.metrics
+}
+
 // TaskContext FIXME ...
 func (c *DefaultSubTaskContext) TaskContext() plugin.TaskContext {
 	if c.taskCtx == nil {
@@ -72,7 +80,9 @@ func NewStandaloneSubTaskContext(
 		newDefaultExecContext(ctx, basicRes, name, data, nil),
 		nil,
 		time.Time{},
+		plugin.NewSubTaskMetrics(),
 	}
 }
 
 var _ plugin.SubTaskContext = (*DefaultSubTaskContext)(nil)
+var _ plugin.SubTaskMetricsHolder = (*DefaultSubTaskContext)(nil)
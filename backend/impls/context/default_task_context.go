@@ -31,6 +31,8 @@ type DefaultTaskContext struct {
 	*defaultExecContext
 	subtasks    map[string]bool
 	subtaskCtxs map[string]*DefaultSubTaskContext
+	// the subtask being executed, subtasks are executed one after another
+	current *DefaultSubTaskContext
 }
 
 // SetProgress FIXME ...
@@ -57,8 +59,10 @@ func (c *DefaultTaskContext) SubTaskContext(subtask string) (plugin.SubTaskConte
 					c.defaultExecContext.fork(subtask),
 					c,
 					time.Time{},
+					plugin.NewSubTaskMetrics(),
 				}
 			}
+			c.current = c.subtaskCtxs[subtask]
 			c.defaultExecContext.mu.Unlock()
 			return c.subtaskCtxs[subtask], nil
 		}
@@ -69,6 +73,16 @@ func (c *DefaultTaskContext) SubTaskContext(subtask string) (plugin.SubTaskConte
 	return nil, errors.Default.New(fmt.Sprintf("subtask %s doesn't exist", subtask))
 }
 
+// GetSubTaskMetrics returns the execution statistics of the subtask being executed
+func (c *DefaultTaskContext) GetSubTaskMetrics() *plugin.SubTaskMetrics {
+	c.defaultExecContext.mu.Lock()
+	defer c.defaultExecContext.mu.Unlock()
+	if c.current == nil {
+		return nil
+	}
+	return c.current.metrics
+}
+
 // SetData FIXME ...
 func (c *DefaultTaskContext) SetData(data interface{}) {
 	c.data = data
@@ -86,7 +100,9 @@ func NewDefaultTaskContext(
 		newDefaultExecContext(ctx, basicRes, name, nil, progress),
 		subtasks,
 		make(map[string]*DefaultSubTaskContext),
+		nil,
 	}
 }
 
 var _ plugin.TaskContext = (*DefaultTaskContext)(nil)
+var _ plugin.SubTaskMetricsHolder = (*DefaultTaskContext)(nil)
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logruslog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/sirupsen/logrus"
+	prefixed "github.com/x-cray/logrus-prefixed-formatter"
+)
+
+const (
+	FORMAT_TEXT = "text"
+	FORMAT_JSON = "json"
+)
+
+// fieldPrefix holds the prefixes added by Logger.Nested
+const fieldPrefix = "prefix"
+
+// NewFormatter returns the logrus.Formatter of the format, text if it is empty
+func NewFormatter(format string) (logrus.Formatter, errors.Error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", FORMAT_TEXT:
+		return &textFormatter{
+			inner: &prefixed.TextFormatter{
+				TimestampFormat: "2006-01-02 15:04:05",
+				FullTimestamp:   true,
+			},
+		}, nil
+	case FORMAT_JSON:
+		return &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+			FieldMap: logrus.FieldMap{
+				logrus.FieldKeyMsg: "message",
+			},
+		}, nil
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unknown logging format %s", format))
+}
+
+// textFormatter puts the prefix in front of the message and leaves the structured fields out, since they are
+// presented by the prefixes already
+type textFormatter struct {
+	inner logrus.Formatter
+}
+
+func (f *textFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	e := &logrus.Entry{
+		Logger:  entry.Logger,
+		Data:    logrus.Fields{},
+		Time:    entry.Time,
+		Level:   entry.Level,
+		Caller:  entry.Caller,
+		Message: entry.Message,
+		Context: entry.Context,
+	}
+	if prefix, ok := entry.Data[fieldPrefix].(string); ok && prefix != "" {
+		e.Message = fmt.Sprintf("%s %s", prefix, entry.Message)
+	}
+	return f.inner.Format(e)
+}
+
+// stdoutHook writes entries to stdout with its own formatter, so stdout could be collected by log collectors in
+// json while the log files stay in text
+type stdoutHook struct {
+	mu        sync.Mutex
+	writer    io.Writer
+	formatter logrus.Formatter
+}
+
+func (h *stdoutHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *stdoutHook) Fire(entry *logrus.Entry) error {
+	b, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = h.writer.Write(b)
+	return err
+}
@@ -19,10 +19,8 @@ package logruslog
 
 import (
 	"github.com/apache/incubator-devlake/core/config"
-	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/sirupsen/logrus"
-	prefixed "github.com/x-cray/logrus-prefixed-formatter"
 	"os"
 	"path/filepath"
 	"strings"
@@ -46,10 +44,22 @@ func init() {
 		logLevel = logrus.ErrorLevel
 	}
 	inner.SetLevel(logLevel)
-	inner.SetFormatter(&prefixed.TextFormatter{
-		TimestampFormat: "2006-01-02 15:04:05",
-		FullTimestamp:   true,
-	})
+	// LOGGING_FORMAT applies to the log files, and stdout as well unless LOGGING_STDOUT_FORMAT is specified
+	format := cfg.GetString("LOGGING_FORMAT")
+	formatter, err := NewFormatter(format)
+	if err != nil {
+		panic(err)
+	}
+	inner.SetFormatter(formatter)
+	stdoutFormat := cfg.GetString("LOGGING_STDOUT_FORMAT")
+	if stdoutFormat == "" {
+		stdoutFormat = format
+	}
+	stdoutFormatter, err := NewFormatter(stdoutFormat)
+	if err != nil {
+		panic(err)
+	}
+	inner.AddHook(&stdoutHook{writer: os.Stdout, formatter: stdoutFormatter})
 	basePath := cfg.GetString("LOGGING_DIR")
 	if basePath == "" {
 		basePath = "./logs"
@@ -59,7 +69,6 @@ func init() {
 	} else {
 		basePath = filepath.Join(abs, "devlake.log")
 	}
-	var err errors.Error
 	Global, err = NewDefaultLogger(inner)
 	if err != nil {
 		panic(err)
@@ -22,6 +22,8 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/sirupsen/logrus"
+	"io"
+	"os"
 	"regexp"
 	"strings"
 )
@@ -33,6 +35,16 @@ type DefaultLogger struct {
 	config *log.LoggerConfig
 }
 
+// hasStdoutHook returns whether stdout is written by the stdoutHook already
+func hasStdoutHook(logger *logrus.Logger) bool {
+	for _, hook := range logger.Hooks[logrus.InfoLevel] {
+		if _, ok := hook.(*stdoutHook); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func NewDefaultLogger(logger *logrus.Logger) (log.Logger, errors.Error) {
 	defaultLogger := &DefaultLogger{
 		log:    logger,
@@ -51,10 +63,14 @@ func (l *DefaultLogger) IsLevelEnabled(level log.LogLevel) bool {
 func (l *DefaultLogger) Log(level log.LogLevel, format string, a ...interface{}) {
 	if l.IsLevelEnabled(level) {
 		msg := fmt.Sprintf(format, a...)
-		if l.config.Prefix != "" {
-			msg = fmt.Sprintf("%s %s", l.config.Prefix, msg)
+		fields := make(logrus.Fields, len(l.config.Fields)+1)
+		for k, v := range l.config.Fields {
+			fields[k] = v
+		}
+		if prefix := strings.TrimSpace(l.config.Prefix); prefix != "" {
+			fields[fieldPrefix] = prefix
 		}
-		l.log.Log(logrus.Level(level), msg)
+		l.log.WithFields(fields).Log(logrus.Level(level), msg)
 	}
 }
 
@@ -82,7 +98,9 @@ func (l *DefaultLogger) SetStream(config *log.LoggerStreamConfig) {
 	if config.Path != "" {
 		l.config.Path = config.Path
 	}
-	if config.Writer != nil {
+	if config.Writer == os.Stdout && hasStdoutHook(l.log) {
+		l.log.SetOutput(io.Discard)
+	} else if config.Writer != nil {
 		l.log.SetOutput(config.Writer)
 	}
 }
@@ -91,6 +109,7 @@ func (l *DefaultLogger) GetConfig() *log.LoggerConfig {
 	return &log.LoggerConfig{
 		Path:   l.config.Path,
 		Prefix: l.config.Prefix,
+		Fields: l.copyFields(nil),
 	}
 }
 
@@ -99,7 +118,7 @@ func (l *DefaultLogger) Nested(newPrefix string) log.Logger {
 	if newPrefix != "" {
 		newTotalPrefix = l.createPrefix(newPrefix)
 	}
-	newLogger, err := l.getLogger(newTotalPrefix)
+	newLogger, err := l.getLogger(newTotalPrefix, l.copyFields(nil))
 	if err != nil {
 		l.Error(err, "error getting a new logger")
 		return l
@@ -107,21 +126,48 @@ func (l *DefaultLogger) Nested(newPrefix string) log.Logger {
 	return newLogger
 }
 
-func (l *DefaultLogger) getLogger(prefix string) (log.Logger, errors.Error) {
+func (l *DefaultLogger) WithFields(fields log.Fields) log.Logger {
+	newLogger, err := l.getLogger(l.config.Prefix, l.copyFields(fields))
+	if err != nil {
+		l.Error(err, "error getting a new logger")
+		return l
+	}
+	return newLogger
+}
+
+func (l *DefaultLogger) getLogger(prefix string, fields log.Fields) (log.Logger, errors.Error) {
 	newLogrus := logrus.New()
 	newLogrus.SetLevel(l.log.Level)
 	newLogrus.SetFormatter(l.log.Formatter)
 	newLogrus.SetOutput(l.log.Out)
+	hooks := make(logrus.LevelHooks, len(l.log.Hooks))
+	for level, levelHooks := range l.log.Hooks {
+		hooks[level] = append([]logrus.Hook(nil), levelHooks...)
+	}
+	newLogrus.ReplaceHooks(hooks)
 	newLogger := &DefaultLogger{
 		log: newLogrus,
 		config: &log.LoggerConfig{
 			Path:   l.config.Path,
 			Prefix: prefix,
+			Fields: fields,
 		},
 	}
 	return newLogger, nil
 }
 
+// copyFields returns the fields of the logger merged with the extra ones
+func (l *DefaultLogger) copyFields(extra log.Fields) log.Fields {
+	fields := make(log.Fields, len(l.config.Fields)+len(extra))
+	for k, v := range l.config.Fields {
+		fields[k] = v
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	return fields
+}
+
 func (l *DefaultLogger) createPrefix(newPrefix string) string {
 	newPrefix = strings.TrimSpace(newPrefix)
 	alreadyInBrackets := alreadyInBracketsRegex.MatchString(newPrefix)
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logruslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(t *testing.T, format string) (log.Logger, *bytes.Buffer) {
+	formatter, err := NewFormatter(format)
+	assert.Nil(t, err)
+	inner := logrus.New()
+	inner.SetFormatter(formatter)
+	buf := &bytes.Buffer{}
+	inner.SetOutput(buf)
+	logger, err := NewDefaultLogger(inner)
+	assert.Nil(t, err)
+	return logger, buf
+}
+
+func TestJsonFormat(t *testing.T) {
+	logger, buf := newTestLogger(t, FORMAT_JSON)
+	logger.Nested("pipeline #1").
+		WithFields(log.Fields{log.FIELD_PIPELINE_ID: 1}).
+		Nested("task #2").
+		WithFields(log.Fields{log.FIELD_TASK_ID: 2, log.FIELD_PLUGIN: "github"}).
+		Info("hello %s", "world")
+
+	entry := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "hello world", entry["message"])
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "[pipeline #1] [task #2]", entry[fieldPrefix])
+	assert.Equal(t, float64(1), entry[log.FIELD_PIPELINE_ID])
+	assert.Equal(t, float64(2), entry[log.FIELD_TASK_ID])
+	assert.Equal(t, "github", entry[log.FIELD_PLUGIN])
+}
+
+func TestTextFormat(t *testing.T) {
+	logger, buf := newTestLogger(t, FORMAT_TEXT)
+	logger.Nested("pipeline #1").
+		WithFields(log.Fields{log.FIELD_PIPELINE_ID: 1}).
+		Info("hello")
+
+	assert.Contains(t, buf.String(), "[pipeline #1] hello")
+	assert.NotContains(t, buf.String(), log.FIELD_PIPELINE_ID)
+}
+
+func TestWithFieldsDoesNotAffectParent(t *testing.T) {
+	logger, buf := newTestLogger(t, FORMAT_JSON)
+	logger.WithFields(log.Fields{log.FIELD_SUBTASK: "collectIssues"})
+	logger.Info("parent")
+
+	entry := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.NotContains(t, entry, log.FIELD_SUBTASK)
+}
+
+func TestUnknownFormat(t *testing.T) {
+	_, err := NewFormatter("xml")
+	assert.NotNil(t, err)
+}
@@ -36,5 +36,6 @@ func GetFileStream(path string) (io.Writer, errors.Error) {
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
-	return io.MultiWriter(os.Stdout, file), nil
+	// stdout is written by the stdoutHook
+	return file, nil
 }
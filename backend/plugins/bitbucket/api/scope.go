@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
 	"strings"
 )
 
@@ -93,3 +94,23 @@ func GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors
 	input.Params["scopeId"] = strings.TrimLeft(input.Params["scopeId"], "/")
 	return scopeHelper.GetScope(input, "bitbucket_id")
 }
+
+// DeleteScope delete a bitbucket repo and all data collected for it
+// @Summary delete a bitbucket repo and all data collected for it
+// @Description Purge raw, tool layer and domain layer data collected for the bitbucket repo and reset its collector states.
+// @Description The bitbucket repo itself would be deleted as well unless dataOnly was set.
+// @Tags plugins/bitbucket
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path string true "repo ID"
+// @Param dryRun query bool false "only report the number of rows to be deleted"
+// @Param dataOnly query bool false "keep the bitbucket repo, only purge the collected data"
+// @Success 200  {object} api.ScopeDeletionRes
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/bitbucket/connections/{connectionId}/scopes/{scopeId} [DELETE]
+func DeleteScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	input.Params["scopeId"] = strings.TrimLeft(input.Params["scopeId"], "/")
+	return scopeHelper.Delete(input, "bitbucket_id", func(scope *models.BitbucketRepo) interface{} {
+		return tasks.BitbucketApiParams{ConnectionId: scope.ConnectionId, FullName: scope.BitbucketId}
+	})
+}
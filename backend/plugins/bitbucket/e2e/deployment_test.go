@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
+)
+
+func TestBitbucketDeploymentDataFlow(t *testing.T) {
+
+	var bitbucket impl.Bitbucket
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket", bitbucket)
+
+	taskData := &tasks.BitbucketTaskData{
+		Options: &tasks.BitbucketOptions{
+			ConnectionId: 4,
+			FullName:     "thenicetgp/lake",
+		},
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_bitbucket_deployments_for_convertor.csv", &models.BitbucketDeployment{})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdDeployment{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdDeployment{},
+		"./snapshot_tables/cicd_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"cicd_scope_id",
+			"cicd_pipeline_id",
+			"name",
+			"result",
+			"status",
+			"original_status",
+			"environment",
+			"original_environment",
+			"repo_id",
+			"commit_sha",
+			"url",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+		),
+	)
+}
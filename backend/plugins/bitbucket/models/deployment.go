@@ -25,6 +25,7 @@ import (
 type BitbucketDeployment struct {
 	ConnectionId    uint64 `gorm:"primaryKey"`
 	BitbucketId     string `gorm:"primaryKey"`
+	RepoId          string `gorm:"type:varchar(255)"`
 	PipelineId      string `gorm:"type:varchar(255)"`
 	StepId          string `gorm:"type:varchar(255)"`
 	Type            string `gorm:"type:varchar(255)"`
@@ -34,6 +35,7 @@ type BitbucketDeployment struct {
 	Key             string `gorm:"type:varchar(255)"`
 	WebUrl          string `gorm:"type:varchar(255)"`
 	Status          string `gorm:"type:varchar(100)"`
+	Result          string `gorm:"type:varchar(100)"`
 	StateUrl        string `gorm:"type:varchar(255)"`
 	CommitSha       string `gorm:"type:varchar(255)"`
 	CommitUrl       string `gorm:"type:varchar(255)"`
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addRepoIdAndResultToDeployments)(nil)
+
+type bitbucketDeployment20230325 struct {
+	RepoId string `gorm:"type:varchar(255)"`
+	Result string `gorm:"type:varchar(100)"`
+}
+
+func (bitbucketDeployment20230325) TableName() string {
+	return "_tool_bitbucket_deployments"
+}
+
+type addRepoIdAndResultToDeployments struct{}
+
+func (*addRepoIdAndResultToDeployments) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &bitbucketDeployment20230325{})
+}
+
+func (*addRepoIdAndResultToDeployments) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(bitbucketDeployment20230325{}.TableName(), "repo_id", "result")
+}
+
+func (*addRepoIdAndResultToDeployments) Version() uint64 {
+	return 20230325000001
+}
+
+func (*addRepoIdAndResultToDeployments) Name() string {
+	return "add repo_id and result to _tool_bitbucket_deployments"
+}
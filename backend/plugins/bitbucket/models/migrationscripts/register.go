@@ -32,5 +32,6 @@ func All() []plugin.MigrationScript {
 		new(addScope20230206),
 		new(addPipelineStep20230215),
 		new(addConnectionIdToTransformationRule),
+		new(addRepoIdAndResultToDeployments),
 	}
 }
@@ -45,7 +45,7 @@ func CollectApiDeployments(taskCtx plugin.SubTaskContext) errors.Error {
 		Query: GetQueryFields(`values.type,values.uuid,values.environment.name,values.environment.environment_type.name,values.step.uuid,` +
 			`values.release.pipeline,values.release.key,values.release.name,values.release.url,values.release.created_on,` +
 			`values.release.commit.hash,values.release.commit.links.html,` +
-			`values.state.name,values.state.url,values.state.started_on,values.state.completed_on,values.last_update_time,` +
+			`values.state.name,values.state.url,values.state.status.name,values.state.started_on,values.state.completed_on,values.last_update_time,` +
 			`page,pagelen,size`),
 		ResponseParser: GetRawMessageFromResponse,
 		GetTotalPages:  GetTotalPagesFromResponse,
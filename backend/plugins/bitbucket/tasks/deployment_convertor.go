@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+var ConvertDeploymentMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table bitbucket_deployments into domain layer table cicd_deployments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(models.BitbucketDeployment{}),
+		dal.Where("repo_id = ? and connection_id = ?", data.Options.FullName, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.BitbucketDeployment{})
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.BitbucketPipeline{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.BitbucketRepo{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.BitbucketDeployment{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			bitbucketDeployment := inputRow.(*models.BitbucketDeployment)
+
+			domainDeployment := &devops.CicdDeployment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: deploymentIdGen.Generate(data.Options.ConnectionId, bitbucketDeployment.BitbucketId),
+				},
+				CicdScopeId: repoIdGen.Generate(data.Options.ConnectionId, bitbucketDeployment.RepoId),
+				Name:        bitbucketDeployment.Name,
+				Result: devops.GetResult(&devops.ResultRule{
+					Failed:  []string{models.FAILED, models.ERROR},
+					Abort:   []string{models.STOPPED},
+					Success: []string{models.SUCCESSFUL},
+					Default: "",
+				}, bitbucketDeployment.Result),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{models.IN_PROGRESS, models.PENDING},
+					Default:    devops.DONE,
+				}, bitbucketDeployment.Status),
+				OriginalStatus:      bitbucketDeployment.Status,
+				Environment:         getEnvironmentByType(bitbucketDeployment.EnvironmentType),
+				OriginalEnvironment: bitbucketDeployment.Environment,
+				RepoId:              repoIdGen.Generate(data.Options.ConnectionId, bitbucketDeployment.RepoId),
+				CommitSha:           bitbucketDeployment.CommitSha,
+				Url:                 bitbucketDeployment.WebUrl,
+				StartedDate:         bitbucketDeployment.StartedOn,
+				FinishedDate:        bitbucketDeployment.CompletedOn,
+			}
+			if bitbucketDeployment.PipelineId != "" {
+				domainDeployment.CicdPipelineId = pipelineIdGen.Generate(data.Options.ConnectionId, bitbucketDeployment.PipelineId)
+			}
+			if bitbucketDeployment.CreatedOn != nil {
+				domainDeployment.CreatedDate = *bitbucketDeployment.CreatedOn
+			}
+			if bitbucketDeployment.StartedOn != nil && bitbucketDeployment.CompletedOn != nil {
+				domainDeployment.DurationSec = uint64(bitbucketDeployment.CompletedOn.Sub(*bitbucketDeployment.StartedOn).Seconds())
+			}
+
+			return []interface{}{
+				domainDeployment,
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getEnvironmentByType maps the three environment types every bitbucket environment belongs to
+func getEnvironmentByType(environmentType string) string {
+	switch environmentType {
+	case `Production`:
+		return devops.PRODUCTION
+	case `Staging`:
+		return devops.STAGING
+	case `Test`:
+		return devops.TESTING
+	}
+	return ""
+}
@@ -62,12 +62,12 @@ type bitbucketApiDeploymentsResponse struct {
 	} `json:"release"`
 	State struct {
 		//Type   string `json:"type"`
-		Name string `json:"name"`
-		URL  string `json:"url"`
-		//Status struct {
-		//	Type string `json:"type"`
-		//	Name string `json:"name"`
-		//} `json:"status"`
+		Name   string `json:"name"`
+		URL    string `json:"url"`
+		Status struct {
+			//Type string `json:"type"`
+			Name string `json:"name"`
+		} `json:"status"`
 		StartedOn   *time.Time `json:"started_on"`
 		CompletedOn *time.Time `json:"completed_on"`
 	} `json:"state"`
@@ -98,6 +98,7 @@ func ExtractApiDeployments(taskCtx plugin.SubTaskContext) errors.Error {
 			bitbucketDeployment := &models.BitbucketDeployment{
 				ConnectionId:    data.Options.ConnectionId,
 				BitbucketId:     bitbucketApiDeployments.UUID,
+				RepoId:          data.Options.FullName,
 				PipelineId:      bitbucketApiDeployments.Release.Pipeline.UUID,
 				StepId:          bitbucketApiDeployments.Step.UUID,
 				Type:            bitbucketApiDeployments.Type,
@@ -109,6 +110,7 @@ func ExtractApiDeployments(taskCtx plugin.SubTaskContext) errors.Error {
 				CommitSha:       bitbucketApiDeployments.Release.Commit.Hash,
 				CommitUrl:       bitbucketApiDeployments.Release.Commit.Links.HTML.Href,
 				Status:          bitbucketApiDeployments.State.Name,
+				Result:          bitbucketApiDeployments.State.Status.Name,
 				StateUrl:        bitbucketApiDeployments.State.URL,
 				CreatedOn:       bitbucketApiDeployments.Release.CreatedOn,
 				StartedOn:       bitbucketApiDeployments.State.StartedOn,
@@ -101,13 +101,7 @@ func ConvertPipelineSteps(taskCtx plugin.SubTaskContext) errors.Error {
 			deploymentErr := db.First(bitbucketDeployment, dal.Where(`step_id=?`, bitbucketPipelineStep.BitbucketId))
 			if deploymentErr == nil {
 				domainTask.Type = devops.DEPLOYMENT
-				if bitbucketDeployment.EnvironmentType == `Production` {
-					domainTask.Environment = devops.PRODUCTION
-				} else if bitbucketDeployment.EnvironmentType == `Staging` {
-					domainTask.Environment = devops.STAGING
-				} else if bitbucketDeployment.EnvironmentType == `Test` {
-					domainTask.Environment = devops.TESTING
-				}
+				domainTask.Environment = getEnvironmentByType(bitbucketDeployment.EnvironmentType)
 			}
 			if domainTask.Type == `` {
 				domainTask.Type = regexEnricher.GetEnrichResult(deploymentPattern, bitbucketPipelineStep.Name, devops.DEPLOYMENT)
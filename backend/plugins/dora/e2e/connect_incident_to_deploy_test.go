@@ -42,6 +42,7 @@ func TestConnectIncidentToDeploymentDataFlow(t *testing.T) {
 	}
 	// import raw data table
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/cicd_tasks.csv", &devops.CICDTask{})
+	dataflowTester.FlushTabler(&devops.CicdDeployment{})
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/board_issues.csv", &ticket.BoardIssue{})
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues.csv", &ticket.Issue{})
@@ -54,3 +55,31 @@ func TestConnectIncidentToDeploymentDataFlow(t *testing.T) {
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
 }
+
+func TestConnectIncidentToDeploymentWithCicdDeploymentsDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+			TransformationRules: tasks.TransformationRules{
+				ProductionPattern: "(?i)deploy",
+			},
+		},
+	}
+	// cicd2 reports its deployments natively, its deployment tasks must not be counted
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/cicd_tasks.csv", &devops.CICDTask{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/cicd_deployments.csv", &devops.CicdDeployment{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/board_issues.csv", &ticket.BoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues.csv", &ticket.Issue{})
+
+	// verify converter
+	dataflowTester.FlushTabler(&crossdomain.ProjectIssueMetric{})
+	dataflowTester.Subtask(tasks.ConnectIncidentToDeploymentMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectIssueMetric{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/project_issue_metrics_with_deployments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
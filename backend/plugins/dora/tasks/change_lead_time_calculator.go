@@ -152,15 +152,28 @@ func CalculateChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
 // buildDeploymentPairs populates the OldDeployCommitSha field of each deploymentPair in the given slice.
 func buildDeploymentPairs(db dal.Dal, data *DoraTaskData) ([]deploymentPair, errors.Error) {
 	// Construct a list of tuple[task, oldPipelineCommitSha, newPipelineCommitSha, taskFinishedDate]
+	// from the deployments reported by the cicd tools, the deployment tasks of cicd pipelines are only
+	// taken into account for the cicd scopes without any of them, or they would be counted twice
 	deploymentClause := []dal.Clause{
-		dal.Select(`ct.id as task_id, cpc.commit_sha as new_deploy_commit_sha,
-			ct.finished_date as task_finished_date, cpc.repo_id as repo_id`),
-		dal.From(`cicd_tasks ct`),
-		dal.Join(`left join cicd_pipeline_commits cpc on ct.pipeline_id = cpc.pipeline_id`),
-		dal.Join(`left join project_mapping pm on pm.row_id = ct.cicd_scope_id`),
-		dal.Where(`ct.environment = ? and ct.type = ? and ct.result = ? and pm.project_name = ? and pm.table = ?`,
-			devops.PRODUCTION, devops.DEPLOYMENT, devops.SUCCESS, data.Options.ProjectName, "cicd_scopes"),
-		dal.Orderby(`cpc.repo_id, ct.started_date`),
+		dal.Select(`d.task_id, d.new_deploy_commit_sha, d.task_finished_date, d.repo_id`),
+		dal.From(`(
+			select ct.id as task_id, cpc.commit_sha as new_deploy_commit_sha,
+				ct.started_date as task_started_date, ct.finished_date as task_finished_date, cpc.repo_id as repo_id
+			from cicd_tasks ct
+			left join cicd_pipeline_commits cpc on ct.pipeline_id = cpc.pipeline_id
+			left join project_mapping pm on pm.row_id = ct.cicd_scope_id
+			where ct.environment = ? and ct.type = ? and ct.result = ? and pm.project_name = ? and pm.table = ?
+				and not exists (select 1 from cicd_deployments scd where scd.cicd_scope_id = ct.cicd_scope_id)
+			union all
+			select cd.id as task_id, cd.commit_sha as new_deploy_commit_sha,
+				cd.started_date as task_started_date, cd.finished_date as task_finished_date, cd.repo_id as repo_id
+			from cicd_deployments cd
+			left join project_mapping pm on pm.row_id = cd.cicd_scope_id
+			where cd.environment = ? and cd.result = ? and pm.project_name = ? and pm.table = ?
+		) d`,
+			devops.PRODUCTION, devops.DEPLOYMENT, devops.SUCCESS, data.Options.ProjectName, "cicd_scopes",
+			devops.PRODUCTION, devops.SUCCESS, data.Options.ProjectName, "cicd_scopes"),
+		dal.Orderby(`d.repo_id, d.task_started_date`),
 	}
 
 	// Initialize deploymentDiffPairs without oldPipelineCommitSha
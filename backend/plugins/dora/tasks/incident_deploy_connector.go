@@ -74,6 +74,8 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 				},
 				ProjectName: data.Options.ProjectName,
 			}
+			// deployment tasks are ignored for the cicd scopes which report deployments natively,
+			// a deployment would be counted twice otherwise
 			cicdTask := &devops.CICDTask{}
 			cicdTakClauses := []dal.Clause{
 				dal.From(cicdTask),
@@ -84,20 +86,51 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 								and cicd_tasks.environment = ?
 								and cicd_tasks.type = ?
 								and pm.table = ?
-								and pm.project_name = ?`,
+								and pm.project_name = ?
+								and not exists (select 1 from cicd_deployments cd where cd.cicd_scope_id = cicd_tasks.cicd_scope_id)`,
 					issue.CreatedDate, devops.SUCCESS, devops.PRODUCTION, devops.DEPLOYMENT, "cicd_scopes", data.Options.ProjectName,
 				),
 				dal.Orderby("cicd_tasks.finished_date DESC"),
 			}
 			err = db.First(cicdTask, cicdTakClauses...)
 			if err != nil {
-				if db.IsErrorNotFound(err) {
-					return nil, nil
-				} else {
+				if !db.IsErrorNotFound(err) {
 					return nil, err
 				}
+				cicdTask = nil
+			}
+			cicdDeployment := &devops.CicdDeployment{}
+			cicdDeploymentClauses := []dal.Clause{
+				dal.From(cicdDeployment),
+				dal.Join("left join project_mapping pm on cicd_deployments.cicd_scope_id = pm.row_id"),
+				dal.Where(
+					`cicd_deployments.finished_date < ?
+								and cicd_deployments.result = ?
+								and cicd_deployments.environment = ?
+								and pm.table = ?
+								and pm.project_name = ?`,
+					issue.CreatedDate, devops.SUCCESS, devops.PRODUCTION, "cicd_scopes", data.Options.ProjectName,
+				),
+				dal.Orderby("cicd_deployments.finished_date DESC"),
+			}
+			err = db.First(cicdDeployment, cicdDeploymentClauses...)
+			if err != nil {
+				if !db.IsErrorNotFound(err) {
+					return nil, err
+				}
+				cicdDeployment = nil
+			}
+			// connect the incident to whichever deployment went to production last before it was created
+			switch {
+			case cicdTask == nil && cicdDeployment == nil:
+				return nil, nil
+			case cicdDeployment == nil:
+				projectIssueMetric.DeploymentId = cicdTask.Id
+			case cicdTask == nil || cicdDeployment.FinishedDate.After(*cicdTask.FinishedDate):
+				projectIssueMetric.DeploymentId = cicdDeployment.Id
+			default:
+				projectIssueMetric.DeploymentId = cicdTask.Id
 			}
-			projectIssueMetric.DeploymentId = cicdTask.Id
 
 			return []interface{}{projectIssueMetric}, nil
 		},
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestGithubDeploymentDataFlow(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+			GithubTransformationRule: &models.GithubTransformationRule{
+				DeploymentPattern: "",
+				ProductionPattern: "",
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_deployments.csv", "_raw_github_api_deployments")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_deployment_statuses.csv", "_raw_github_api_deployment_statuses")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubDeployment{})
+	dataflowTester.FlushTabler(&models.GithubDeploymentStatus{})
+	dataflowTester.Subtask(tasks.ExtractDeploymentsMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractDeploymentStatusesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GithubDeployment{},
+		"./snapshot_tables/_tool_github_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"github_id",
+			"repo_id",
+			"sha",
+			"ref",
+			"task",
+			"environment",
+			"description",
+			"creator_id",
+			"creator_login",
+			"github_created_at",
+			"github_updated_at",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.GithubDeploymentStatus{},
+		"./snapshot_tables/_tool_github_deployment_statuses.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"github_id",
+			"deployment_id",
+			"state",
+			"description",
+			"log_url",
+			"environment_url",
+			"github_created_at",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdDeployment{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdDeployment{},
+		"./snapshot_tables/cicd_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"cicd_scope_id",
+			"name",
+			"result",
+			"status",
+			"original_status",
+			"environment",
+			"original_environment",
+			"ref_name",
+			"repo_id",
+			"commit_sha",
+			"deployer_id",
+			"deployer_name",
+			"url",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+		),
+	)
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestGithubReleaseDataFlow(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_releases.csv", "_raw_github_api_releases")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubRelease{})
+	dataflowTester.Subtask(tasks.ExtractReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GithubRelease{},
+		"./snapshot_tables/_tool_github_releases.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"github_id",
+			"repo_id",
+			"tag_name",
+			"target_commitish",
+			"name",
+			"body",
+			"draft",
+			"prerelease",
+			"author_id",
+			"author_login",
+			"html_url",
+			"github_created_at",
+			"published_at",
+		),
+	)
+
+	// verify conversion, the commits of the releases are resolved from the tags collected by gitextractor
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/refs.csv", &code.Ref{})
+	dataflowTester.FlushTabler(&code.Release{})
+	dataflowTester.Subtask(tasks.ConvertReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.Release{},
+		"./snapshot_tables/releases.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"repo_id",
+			"name",
+			"tag_name",
+			"commit_sha",
+			"description",
+			"url",
+			"author_id",
+			"author_name",
+			"is_draft",
+			"is_prerelease",
+			"created_date",
+			"published_date",
+		),
+	)
+}
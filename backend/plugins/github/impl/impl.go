@@ -67,6 +67,8 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubAccountOrg{},
 		&models.GithubCommit{},
 		&models.GithubCommitStat{},
+		&models.GithubDeployment{},
+		&models.GithubDeploymentStatus{},
 		&models.GithubIssue{},
 		&models.GithubIssueComment{},
 		&models.GithubIssueEvent{},
@@ -79,6 +81,7 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubPrLabel{},
 		&models.GithubPrReview{},
 		&models.GithubPullRequest{},
+		&models.GithubRelease{},
 		&models.GithubRepo{},
 		&models.GithubRepoAccount{},
 		&models.GithubRepoCommit{},
@@ -123,6 +126,14 @@ func (p Github) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectJobsMeta,
 		tasks.ExtractJobsMeta,
 		tasks.ConvertJobsMeta,
+		tasks.CollectDeploymentsMeta,
+		tasks.ExtractDeploymentsMeta,
+		tasks.CollectDeploymentStatusesMeta,
+		tasks.ExtractDeploymentStatusesMeta,
+		tasks.ConvertDeploymentsMeta,
+		tasks.CollectReleasesMeta,
+		tasks.ExtractReleasesMeta,
+		tasks.ConvertReleasesMeta,
 		tasks.EnrichPullRequestIssuesMeta,
 		tasks.ConvertRepoMeta,
 		tasks.ConvertIssuesMeta,
@@ -205,8 +216,9 @@ func (p Github) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"DELETE": api.DeleteConnection,
 		},
 		"connections/:connectionId/scopes/:scopeId": {
-			"GET":   api.GetScope,
-			"PATCH": api.UpdateScope,
+			"GET":    api.GetScope,
+			"PATCH":  api.UpdateScope,
+			"DELETE": api.DeleteScope,
 		},
 		"connections/:connectionId/scopes": {
 			"GET": api.GetScopeList,
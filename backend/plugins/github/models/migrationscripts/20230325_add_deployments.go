@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addDeployments)(nil)
+
+type githubDeployment20230325 struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GithubId        int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId          int    `gorm:"index"`
+	Sha             string `gorm:"type:varchar(255)"`
+	Ref             string `gorm:"type:varchar(255)"`
+	Task            string `gorm:"type:varchar(255)"`
+	Environment     string `gorm:"type:varchar(255)"`
+	Description     string
+	CreatorId       int
+	CreatorLogin    string `gorm:"type:varchar(255)"`
+	GithubCreatedAt time.Time
+	GithubUpdatedAt time.Time
+	archived.NoPKModel
+}
+
+func (githubDeployment20230325) TableName() string {
+	return "_tool_github_deployments"
+}
+
+type githubDeploymentStatus20230325 struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GithubId        int    `gorm:"primaryKey;autoIncrement:false"`
+	DeploymentId    int    `gorm:"index"`
+	State           string `gorm:"type:varchar(100)"`
+	Description     string
+	LogUrl          string `gorm:"type:varchar(255)"`
+	EnvironmentUrl  string `gorm:"type:varchar(255)"`
+	GithubCreatedAt time.Time
+	archived.NoPKModel
+}
+
+func (githubDeploymentStatus20230325) TableName() string {
+	return "_tool_github_deployment_statuses"
+}
+
+type addDeployments struct{}
+
+func (*addDeployments) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &githubDeployment20230325{}, &githubDeploymentStatus20230325{})
+}
+
+func (*addDeployments) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&githubDeployment20230325{}, &githubDeploymentStatus20230325{})
+}
+
+func (*addDeployments) Version() uint64 {
+	return 20230325000001
+}
+
+func (*addDeployments) Name() string {
+	return "add _tool_github_deployments and _tool_github_deployment_statuses"
+}
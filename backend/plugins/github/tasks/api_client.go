@@ -34,8 +34,21 @@ func CreateApiClient(taskCtx plugin.TaskContext, connection *models.GithubConnec
 		return nil, err
 	}
 
-	// create rate limit calculator
-	rateLimiter := &api.ApiRateLimitCalculator{
+	asyncApiClient, err := api.CreateAsyncApiClient(
+		taskCtx,
+		apiClient,
+		newRateLimitCalculator(connection),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return asyncApiClient, nil
+}
+
+// newRateLimitCalculator calculates the rate limit of all tokens of the connection, which must be prepared by
+// api.NewApiClientFromConnection beforehand
+func newRateLimitCalculator(connection *models.GithubConnection) *api.ApiRateLimitCalculator {
+	return &api.ApiRateLimitCalculator{
 		UserRateLimitPerHour: connection.RateLimitPerHour,
 		Method:               http.MethodGet,
 		DynamicRateLimit: func(res *http.Response) (int, time.Duration, errors.Error) {
@@ -60,7 +73,7 @@ func CreateApiClient(taskCtx plugin.TaskContext, connection *models.GithubConnec
 				var e error
 				rateLimit, e = strconv.Atoi(headerRateLimit)
 				if e != nil {
-					return 0, 0, errors.Default.Wrap(err, "failed to parse X-RateLimit-Limit header")
+					return 0, 0, errors.Default.Wrap(e, "failed to parse X-RateLimit-Limit header")
 				}
 			} else {
 				// if we can't find "X-RateLimit-Limit" in header, we will return globalRatelimit in ApiRateLimitCalculator.Calculate
@@ -73,13 +86,4 @@ func CreateApiClient(taskCtx plugin.TaskContext, connection *models.GithubConnec
 			return rateLimit * connection.GetTokensCount(), 1 * time.Hour, nil
 		},
 	}
-	asyncApiClient, err := api.CreateAsyncApiClient(
-		taskCtx,
-		apiClient,
-		rateLimiter,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return asyncApiClient, nil
 }
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitOfSecretReferencedTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	t.Setenv("DEVLAKE_SECRET_GITHUB_TOKEN", "token1,token2,token3")
+	connection := &models.GithubConnection{}
+	connection.Endpoint = server.URL
+	connection.Token = "secret://env/DEVLAKE_SECRET_GITHUB_TOKEN"
+
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {})
+	_, err := api.NewApiClientFromConnection(context.Background(), basicRes, connection)
+	assert.Nil(t, err)
+	// the reference is kept while the tokens are counted
+	assert.Equal(t, "secret://env/DEVLAKE_SECRET_GITHUB_TOKEN", connection.Token)
+	assert.Equal(t, 3, connection.GetTokensCount())
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("X-RateLimit-Limit", "5000")
+	rateLimit, duration, err := newRateLimitCalculator(connection).DynamicRateLimit(res)
+	assert.Nil(t, err)
+	assert.Equal(t, 15000, rateLimit)
+	assert.Equal(t, time.Hour, duration)
+}
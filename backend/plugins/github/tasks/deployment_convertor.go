@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_deployments into domain layer table cicd_deployments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+var deploymentResultRule = &devops.ResultRule{
+	Success: []string{"success"},
+	Failed:  []string{"failure", "error"},
+	Abort:   []string{"inactive"},
+	Default: "",
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	repoId := data.Options.GithubId
+	productionPattern := data.Options.ProductionPattern
+	regexEnricher := api.NewRegexEnricher()
+	err := regexEnricher.AddRegexp(productionPattern)
+	if err != nil {
+		return err
+	}
+
+	// statuses are few per deployment, load them all at once in chronological order
+	var statuses []models.GithubDeploymentStatus
+	err = db.All(
+		&statuses,
+		dal.Select("s.*"),
+		dal.From("_tool_github_deployment_statuses s"),
+		dal.Join(`left join _tool_github_deployments d
+			on d.connection_id = s.connection_id and d.github_id = s.deployment_id`),
+		dal.Where("d.repo_id = ? and d.connection_id = ?", repoId, data.Options.ConnectionId),
+		dal.Orderby("s.github_created_at ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	statusesByDeployment := make(map[int][]models.GithubDeploymentStatus)
+	for _, status := range statuses {
+		statusesByDeployment[status.DeploymentId] = append(statusesByDeployment[status.DeploymentId], status)
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubDeployment{}),
+		dal.Where("repo_id = ? and connection_id = ?", repoId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.GithubDeployment{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GithubAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_DEPLOYMENT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubDeployment{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployment := inputRow.(*models.GithubDeployment)
+			domainDeployment := &devops.CicdDeployment{
+				DomainEntity:        domainlayer.DomainEntity{Id: deploymentIdGen.Generate(data.Options.ConnectionId, deployment.GithubId)},
+				CicdScopeId:         repoIdGen.Generate(data.Options.ConnectionId, deployment.RepoId),
+				Name:                deployment.Description,
+				Status:              devops.IN_PROGRESS,
+				OriginalEnvironment: deployment.Environment,
+				RefName:             deployment.Ref,
+				RepoId:              repoIdGen.Generate(data.Options.ConnectionId, deployment.RepoId),
+				CommitSha:           deployment.Sha,
+				DeployerName:        deployment.CreatorLogin,
+				CreatedDate:         deployment.GithubCreatedAt,
+				StartedDate:         &deployment.GithubCreatedAt,
+			}
+			if deployment.CreatorId != 0 {
+				domainDeployment.DeployerId = accountIdGen.Generate(data.Options.ConnectionId, deployment.CreatorId)
+			}
+			if productionPattern != "" {
+				domainDeployment.Environment = regexEnricher.GetEnrichResult(productionPattern, deployment.Environment, devops.PRODUCTION)
+			} else {
+				domainDeployment.Environment = devops.GetEnvironment(deployment.Environment)
+			}
+			applyDeploymentStatuses(domainDeployment, statusesByDeployment[deployment.GithubId])
+			return []interface{}{domainDeployment}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// applyDeploymentStatuses walks through the statuses of a deployment from the oldest one, the first terminal
+// status decides the result, a later `inactive` only means the deployment was superseded
+func applyDeploymentStatuses(deployment *devops.CicdDeployment, statuses []models.GithubDeploymentStatus) {
+	for i := range statuses {
+		status := &statuses[i]
+		deployment.OriginalStatus = status.State
+		if status.LogUrl != "" {
+			deployment.Url = status.LogUrl
+		}
+		if deployment.Status == devops.DONE {
+			continue
+		}
+		if status.State == "in_progress" {
+			deployment.StartedDate = &status.GithubCreatedAt
+		}
+		if result := devops.GetResult(deploymentResultRule, status.State); result != "" {
+			deployment.Result = result
+			deployment.Status = devops.DONE
+			deployment.FinishedDate = &status.GithubCreatedAt
+			deployment.DurationSec = uint64(deployment.FinishedDate.Sub(*deployment.StartedDate).Seconds())
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "extractDeployments",
+	EntryPoint:       ExtractDeployments,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployment data into tool layer table github_deployments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type GithubApiDeployment struct {
+	Id              int                    `json:"id"`
+	Sha             string                 `json:"sha"`
+	Ref             string                 `json:"ref"`
+	Task            string                 `json:"task"`
+	Environment     string                 `json:"environment"`
+	Description     string                 `json:"description"`
+	Creator         *GithubAccountResponse `json:"creator"`
+	GithubCreatedAt api.Iso8601Time        `json:"created_at"`
+	GithubUpdatedAt api.Iso8601Time        `json:"updated_at"`
+}
+
+func ExtractDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_DEPLOYMENT_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiDeployment := &GithubApiDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiDeployment))
+			if err != nil {
+				return nil, err
+			}
+			githubDeployment := &models.GithubDeployment{
+				ConnectionId:    data.Options.ConnectionId,
+				GithubId:        apiDeployment.Id,
+				RepoId:          data.Options.GithubId,
+				Sha:             apiDeployment.Sha,
+				Ref:             apiDeployment.Ref,
+				Task:            apiDeployment.Task,
+				Environment:     apiDeployment.Environment,
+				Description:     apiDeployment.Description,
+				GithubCreatedAt: apiDeployment.GithubCreatedAt.ToTime(),
+				GithubUpdatedAt: apiDeployment.GithubUpdatedAt.ToTime(),
+			}
+			if apiDeployment.Creator != nil {
+				githubDeployment.CreatorId = apiDeployment.Creator.Id
+				githubDeployment.CreatorLogin = apiDeployment.Creator.Login
+			}
+			return []interface{}{githubDeployment}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractDeploymentStatusesMeta = plugin.SubTaskMeta{
+	Name:             "extractDeploymentStatuses",
+	EntryPoint:       ExtractDeploymentStatuses,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployment status data into tool layer table github_deployment_statuses",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type GithubApiDeploymentStatus struct {
+	Id              int             `json:"id"`
+	State           string          `json:"state"`
+	Description     string          `json:"description"`
+	LogUrl          string          `json:"log_url"`
+	EnvironmentUrl  string          `json:"environment_url"`
+	GithubCreatedAt api.Iso8601Time `json:"created_at"`
+}
+
+func ExtractDeploymentStatuses(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_DEPLOYMENT_STATUS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiStatus := &GithubApiDeploymentStatus{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiStatus))
+			if err != nil {
+				return nil, err
+			}
+			deployment := &SimpleDeployment{}
+			err = errors.Convert(json.Unmarshal(row.Input, deployment))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.GithubDeploymentStatus{
+					ConnectionId:    data.Options.ConnectionId,
+					GithubId:        apiStatus.Id,
+					DeploymentId:    deployment.GithubId,
+					State:           apiStatus.State,
+					Description:     apiStatus.Description,
+					LogUrl:          apiStatus.LogUrl,
+					EnvironmentUrl:  apiStatus.EnvironmentUrl,
+					GithubCreatedAt: apiStatus.GithubCreatedAt.ToTime(),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertReleasesMeta = plugin.SubTaskMeta{
+	Name:             "convertReleases",
+	EntryPoint:       ConvertReleases,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_releases into domain layer table releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	repoId := data.Options.GithubId
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubRelease{}),
+		dal.Where("repo_id = ? and connection_id = ?", repoId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	releaseIdGen := didgen.NewDomainIdGenerator(&models.GithubRelease{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	domainRepoId := repoIdGen.Generate(data.Options.ConnectionId, repoId)
+	tagCommits, err := getTagCommits(db, domainRepoId)
+	if err != nil {
+		return err
+	}
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GithubAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_RELEASE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubRelease{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			release := inputRow.(*models.GithubRelease)
+			domainRelease := &code.Release{
+				DomainEntity:  domainlayer.DomainEntity{Id: releaseIdGen.Generate(data.Options.ConnectionId, release.GithubId)},
+				RepoId:        domainRepoId,
+				Name:          release.Name,
+				TagName:       release.TagName,
+				CommitSha:     tagCommits[fmt.Sprintf("%s:refs/tags/%s", domainRepoId, release.TagName)],
+				Description:   release.Body,
+				Url:           release.HtmlUrl,
+				AuthorName:    release.AuthorLogin,
+				IsDraft:       release.Draft,
+				IsPrerelease:  release.Prerelease,
+				CreatedDate:   release.GithubCreatedAt,
+				PublishedDate: release.PublishedAt,
+			}
+			// github shows the tag in place of a release without a title
+			if domainRelease.Name == "" {
+				domainRelease.Name = release.TagName
+			}
+			// the tag of a draft release may not exist yet, target_commitish is either a branch or a commit sha
+			if domainRelease.CommitSha == "" && commitShaPattern.MatchString(release.TargetCommitish) {
+				domainRelease.CommitSha = release.TargetCommitish
+			}
+			if release.AuthorId != 0 {
+				domainRelease.AuthorId = accountIdGen.Generate(data.Options.ConnectionId, release.AuthorId)
+			}
+			return []interface{}{domainRelease}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var commitShaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// getTagCommits returns the commit sha of the tags of the repo collected by gitextractor, keyed by the id of their refs
+func getTagCommits(db dal.Dal, domainRepoId string) (map[string]string, errors.Error) {
+	var refs []code.Ref
+	err := db.All(&refs, dal.Where("repo_id = ? and id like ?", domainRepoId, domainRepoId+":refs/tags/%"))
+	if err != nil {
+		return nil, err
+	}
+	tagCommits := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		tagCommits[ref.Id] = ref.CommitSha
+	}
+	return tagCommits, nil
+}
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractReleasesMeta = plugin.SubTaskMeta{
+	Name:             "extractReleases",
+	EntryPoint:       ExtractReleases,
+	EnabledByDefault: true,
+	Description:      "Extract raw release data into tool layer table github_releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+type GithubApiRelease struct {
+	Id              int                    `json:"id"`
+	TagName         string                 `json:"tag_name"`
+	TargetCommitish string                 `json:"target_commitish"`
+	Name            string                 `json:"name"`
+	Body            string                 `json:"body"`
+	Draft           bool                   `json:"draft"`
+	Prerelease      bool                   `json:"prerelease"`
+	Author          *GithubAccountResponse `json:"author"`
+	HtmlUrl         string                 `json:"html_url"`
+	GithubCreatedAt api.Iso8601Time        `json:"created_at"`
+	PublishedAt     *api.Iso8601Time       `json:"published_at"`
+}
+
+func ExtractReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_RELEASE_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiRelease := &GithubApiRelease{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiRelease))
+			if err != nil {
+				return nil, err
+			}
+			githubRelease := &models.GithubRelease{
+				ConnectionId:    data.Options.ConnectionId,
+				GithubId:        apiRelease.Id,
+				RepoId:          data.Options.GithubId,
+				TagName:         apiRelease.TagName,
+				TargetCommitish: apiRelease.TargetCommitish,
+				Name:            apiRelease.Name,
+				Body:            apiRelease.Body,
+				Draft:           apiRelease.Draft,
+				Prerelease:      apiRelease.Prerelease,
+				HtmlUrl:         apiRelease.HtmlUrl,
+				GithubCreatedAt: apiRelease.GithubCreatedAt.ToTime(),
+				PublishedAt:     api.Iso8601TimeToTime(apiRelease.PublishedAt),
+			}
+			if apiRelease.Author != nil {
+				githubRelease.AuthorId = apiRelease.Author.Id
+				githubRelease.AuthorLogin = apiRelease.Author.Login
+			}
+			return []interface{}{githubRelease}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -85,6 +85,16 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		githubTasks.ExtractRunsMeta,
 		tasks.CollectGraphqlJobsMeta,
 
+		// collect deployments
+		githubTasks.CollectDeploymentsMeta,
+		githubTasks.ExtractDeploymentsMeta,
+		githubTasks.CollectDeploymentStatusesMeta,
+		githubTasks.ExtractDeploymentStatusesMeta,
+
+		// collect releases
+		githubTasks.CollectReleasesMeta,
+		githubTasks.ExtractReleasesMeta,
+
 		// collect others
 		githubTasks.CollectApiCommentsMeta,
 		githubTasks.ExtractApiCommentsMeta,
@@ -99,6 +109,8 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		// convert to domain layer
 		githubTasks.ConvertRunsMeta,
 		githubTasks.ConvertJobsMeta,
+		githubTasks.ConvertDeploymentsMeta,
+		githubTasks.ConvertReleasesMeta,
 		githubTasks.EnrichPullRequestIssuesMeta,
 		githubTasks.ConvertRepoMeta,
 		githubTasks.ConvertIssuesMeta,
@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
 )
 
 type ScopeRes struct {
@@ -90,3 +91,22 @@ func GetScopeList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 func GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	return scopeHelper.GetScope(input, "gitlab_id")
 }
+
+// DeleteScope delete a gitlab project and all data collected for it
+// @Summary delete a gitlab project and all data collected for it
+// @Description Purge raw, tool layer and domain layer data collected for the gitlab project and reset its collector states.
+// @Description The gitlab project itself would be deleted as well unless dataOnly was set.
+// @Tags plugins/gitlab
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path int true "scope ID"
+// @Param dryRun query bool false "only report the number of rows to be deleted"
+// @Param dataOnly query bool false "keep the gitlab project, only purge the collected data"
+// @Success 200  {object} api.ScopeDeletionRes
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gitlab/connections/{connectionId}/scopes/{scopeId} [DELETE]
+func DeleteScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return scopeHelper.Delete(input, "gitlab_id", func(scope *models.GitlabProject) interface{} {
+		return tasks.GitlabApiParams{ConnectionId: scope.ConnectionId, ProjectId: scope.GitlabId}
+	})
+}
@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabDeploymentDataFlow(t *testing.T) {
+
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId:             1,
+			ProjectId:                44,
+			GitlabTransformationRule: &models.GitlabTransformationRule{},
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_environments.csv", "_raw_gitlab_api_environments")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_deployments.csv", "_raw_gitlab_api_deployments")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabEnvironment{})
+	dataflowTester.FlushTabler(&models.GitlabDeployment{})
+	dataflowTester.Subtask(tasks.ExtractApiEnvironmentsMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractApiDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GitlabEnvironment{},
+		"./snapshot_tables/_tool_gitlab_environments.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"gitlab_id",
+			"project_id",
+			"name",
+			"slug",
+			"external_url",
+			"state",
+			"tier",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.GitlabDeployment{},
+		"./snapshot_tables/_tool_gitlab_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"gitlab_id",
+			"project_id",
+			"iid",
+			"ref",
+			"sha",
+			"status",
+			"environment_id",
+			"environment_name",
+			"user_id",
+			"user_name",
+			"job_id",
+			"pipeline_id",
+			"web_url",
+			"gitlab_created_at",
+			"gitlab_updated_at",
+			"started_at",
+			"finished_at",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdDeployment{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdDeployment{},
+		"./snapshot_tables/cicd_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"cicd_scope_id",
+			"cicd_pipeline_id",
+			"result",
+			"status",
+			"original_status",
+			"environment",
+			"original_environment",
+			"ref_name",
+			"repo_id",
+			"commit_sha",
+			"deployer_id",
+			"deployer_name",
+			"url",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+		),
+	)
+}
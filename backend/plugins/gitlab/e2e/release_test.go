@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabReleaseDataFlow(t *testing.T) {
+
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    44,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_releases.csv", "_raw_gitlab_api_releases")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabRelease{})
+	dataflowTester.Subtask(tasks.ExtractApiReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GitlabRelease{},
+		"./snapshot_tables/_tool_gitlab_releases.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"project_id",
+			"tag_name",
+			"name",
+			"description",
+			"commit_sha",
+			"author_id",
+			"author_username",
+			"upcoming_release",
+			"url",
+			"gitlab_created_at",
+			"released_at",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.Release{})
+	dataflowTester.Subtask(tasks.ConvertReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.Release{},
+		"./snapshot_tables/releases.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"repo_id",
+			"name",
+			"tag_name",
+			"commit_sha",
+			"description",
+			"url",
+			"author_id",
+			"author_name",
+			"is_draft",
+			"is_prerelease",
+			"created_date",
+			"published_date",
+		),
+	)
+}
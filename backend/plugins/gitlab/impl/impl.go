@@ -72,6 +72,8 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabConnection{},
 		&models.GitlabAccount{},
 		&models.GitlabCommit{},
+		&models.GitlabDeployment{},
+		&models.GitlabEnvironment{},
 		&models.GitlabIssue{},
 		&models.GitlabIssueLabel{},
 		&models.GitlabJob{},
@@ -84,6 +86,7 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabPipelineProject{},
 		&models.GitlabProject{},
 		&models.GitlabProjectCommit{},
+		&models.GitlabRelease{},
 		&models.GitlabReviewer{},
 		&models.GitlabTag{},
 	}
@@ -111,6 +114,12 @@ func (p Gitlab) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractApiPipelineDetailsMeta,
 		tasks.CollectApiJobsMeta,
 		tasks.ExtractApiJobsMeta,
+		tasks.CollectApiEnvironmentsMeta,
+		tasks.ExtractApiEnvironmentsMeta,
+		tasks.CollectApiDeploymentsMeta,
+		tasks.ExtractApiDeploymentsMeta,
+		tasks.CollectApiReleasesMeta,
+		tasks.ExtractApiReleasesMeta,
 		tasks.EnrichMergeRequestsMeta,
 		tasks.CollectAccountsMeta,
 		tasks.ExtractAccountsMeta,
@@ -126,6 +135,8 @@ func (p Gitlab) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertPipelineMeta,
 		tasks.ConvertPipelineCommitMeta,
 		tasks.ConvertJobMeta,
+		tasks.ConvertDeploymentsMeta,
+		tasks.ConvertReleasesMeta,
 		tasks.CollectApiCommitsMeta,
 		tasks.ExtractApiCommitsMeta,
 		tasks.ExtractApiMergeRequestDetailsMeta,
@@ -248,8 +259,9 @@ func (p Gitlab) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET":    api.GetConnection,
 		},
 		"connections/:connectionId/scopes/:scopeId": {
-			"GET":   api.GetScope,
-			"PATCH": api.UpdateScope,
+			"GET":    api.GetScope,
+			"PATCH":  api.UpdateScope,
+			"DELETE": api.DeleteScope,
 		},
 		"connections/:connectionId/remote-scopes": {
 			"GET": api.RemoteScopes,
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addDeployments20230325)(nil)
+
+type gitlabDeployment20230325 struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GitlabId        int    `gorm:"primaryKey"`
+	ProjectId       int    `gorm:"index"`
+	Iid             int
+	Ref             string `gorm:"type:varchar(255)"`
+	Sha             string `gorm:"type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	EnvironmentId   int
+	EnvironmentName string `gorm:"type:varchar(255)"`
+	UserId          int
+	UserName        string `gorm:"type:varchar(255)"`
+	JobId           int
+	PipelineId      int
+	WebUrl          string `gorm:"type:varchar(255)"`
+	GitlabCreatedAt time.Time
+	GitlabUpdatedAt *time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	archived.NoPKModel
+}
+
+func (gitlabDeployment20230325) TableName() string {
+	return "_tool_gitlab_deployments"
+}
+
+type gitlabEnvironment20230325 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	GitlabId     int    `gorm:"primaryKey"`
+	ProjectId    int    `gorm:"index"`
+	Name         string `gorm:"type:varchar(255)"`
+	Slug         string `gorm:"type:varchar(255)"`
+	ExternalUrl  string `gorm:"type:varchar(255)"`
+	State        string `gorm:"type:varchar(100)"`
+	Tier         string `gorm:"type:varchar(100)"`
+	archived.NoPKModel
+}
+
+func (gitlabEnvironment20230325) TableName() string {
+	return "_tool_gitlab_environments"
+}
+
+type addDeployments20230325 struct{}
+
+func (*addDeployments20230325) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &gitlabDeployment20230325{}, &gitlabEnvironment20230325{})
+}
+
+func (*addDeployments20230325) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&gitlabDeployment20230325{}, &gitlabEnvironment20230325{})
+}
+
+func (*addDeployments20230325) Version() uint64 {
+	return 20230325000001
+}
+
+func (*addDeployments20230325) Name() string {
+	return "add _tool_gitlab_deployments and _tool_gitlab_environments"
+}
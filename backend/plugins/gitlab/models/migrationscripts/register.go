@@ -33,5 +33,7 @@ func All() []plugin.MigrationScript {
 		new(addStdTypeToIssue221230),
 		new(addIsDetailRequired20230210),
 		new(addConnectionIdToTransformationRule),
+		new(addDeployments20230325),
+		new(addReleases20230326),
 	}
 }
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GitlabRelease is keyed by its tag since gitlab identifies a release by the tag it was created from
+type GitlabRelease struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ProjectId       int    `gorm:"primaryKey;autoIncrement:false"`
+	TagName         string `gorm:"primaryKey;type:varchar(255)"`
+	Name            string `gorm:"type:varchar(255)"`
+	Description     string
+	CommitSha       string `gorm:"type:varchar(40)"`
+	AuthorId        int
+	AuthorUsername  string `gorm:"type:varchar(255)"`
+	UpcomingRelease bool
+	Url             string `gorm:"type:varchar(255)"`
+	GitlabCreatedAt time.Time
+	ReleasedAt      *time.Time
+	common.NoPKModel
+}
+
+func (GitlabRelease) TableName() string {
+	return "_tool_gitlab_releases"
+}
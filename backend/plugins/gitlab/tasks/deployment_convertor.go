@@ -0,0 +1,158 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ConvertDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_deployments into domain layer table cicd_deployments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GitlabTaskData)
+	productionPattern := data.Options.ProductionPattern
+	regexEnricher := api.NewRegexEnricher()
+	err := regexEnricher.AddRegexp(productionPattern)
+	if err != nil {
+		return err
+	}
+
+	var environments []models.GitlabEnvironment
+	err = db.All(&environments, dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+	tiers := make(map[int]string, len(environments))
+	for _, environment := range environments {
+		tiers[environment.GitlabId] = environment.Tier
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabDeployment{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.GitlabDeployment{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GitlabProject{})
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.GitlabPipeline{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GitlabAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.GitlabDeployment{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GitlabApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ProjectId:    data.Options.ProjectId,
+			},
+			Table: RAW_DEPLOYMENT_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			gitlabDeployment := inputRow.(*models.GitlabDeployment)
+
+			startedAt := &gitlabDeployment.GitlabCreatedAt
+			if gitlabDeployment.StartedAt != nil {
+				startedAt = gitlabDeployment.StartedAt
+			}
+			domainDeployment := &devops.CicdDeployment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: deploymentIdGen.Generate(data.Options.ConnectionId, gitlabDeployment.GitlabId),
+				},
+				CicdScopeId: projectIdGen.Generate(data.Options.ConnectionId, gitlabDeployment.ProjectId),
+				Result: devops.GetResult(&devops.ResultRule{
+					Failed:  []string{"failed"},
+					Abort:   []string{"canceled"},
+					Success: []string{"success"},
+					Default: "",
+				}, gitlabDeployment.Status),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{"created", "running", "blocked"},
+					Default:    devops.DONE,
+				}, gitlabDeployment.Status),
+				OriginalStatus:      gitlabDeployment.Status,
+				OriginalEnvironment: gitlabDeployment.EnvironmentName,
+				RefName:             gitlabDeployment.Ref,
+				RepoId:              projectIdGen.Generate(data.Options.ConnectionId, gitlabDeployment.ProjectId),
+				CommitSha:           gitlabDeployment.Sha,
+				DeployerName:        gitlabDeployment.UserName,
+				Url:                 gitlabDeployment.WebUrl,
+				CreatedDate:         gitlabDeployment.GitlabCreatedAt,
+				StartedDate:         startedAt,
+				FinishedDate:        gitlabDeployment.FinishedAt,
+			}
+			if gitlabDeployment.PipelineId != 0 {
+				domainDeployment.CicdPipelineId = pipelineIdGen.Generate(data.Options.ConnectionId, gitlabDeployment.PipelineId)
+			}
+			if gitlabDeployment.UserId != 0 {
+				domainDeployment.DeployerId = accountIdGen.Generate(data.Options.ConnectionId, gitlabDeployment.UserId)
+			}
+			if gitlabDeployment.FinishedAt != nil {
+				domainDeployment.DurationSec = uint64(gitlabDeployment.FinishedAt.Sub(*startedAt).Seconds())
+			}
+			if productionPattern != "" {
+				domainDeployment.Environment = regexEnricher.GetEnrichResult(productionPattern, gitlabDeployment.EnvironmentName, devops.PRODUCTION)
+			} else {
+				domainDeployment.Environment = getEnvironmentByTier(tiers[gitlabDeployment.EnvironmentId], gitlabDeployment.EnvironmentName)
+			}
+
+			return []interface{}{
+				domainDeployment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getEnvironmentByTier relies on the deployment tier of the environment, which is only available since GitLab 13.10
+func getEnvironmentByTier(tier, name string) string {
+	switch tier {
+	case "production":
+		return devops.PRODUCTION
+	case "staging":
+		return devops.STAGING
+	case "testing":
+		return devops.TESTING
+	case "":
+		return devops.GetEnvironment(name)
+	}
+	return ""
+}
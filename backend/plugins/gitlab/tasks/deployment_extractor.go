@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+type GitlabApiDeployment struct {
+	Id        int              `json:"id"`
+	Iid       int              `json:"iid"`
+	Ref       string           `json:"ref"`
+	Sha       string           `json:"sha"`
+	Status    string           `json:"status"`
+	CreatedAt api.Iso8601Time  `json:"created_at"`
+	UpdatedAt *api.Iso8601Time `json:"updated_at"`
+	User      *struct {
+		Id       int    `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Environment struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"environment"`
+	Deployable *struct {
+		Id         int              `json:"id"`
+		WebUrl     string           `json:"web_url"`
+		StartedAt  *api.Iso8601Time `json:"started_at"`
+		FinishedAt *api.Iso8601Time `json:"finished_at"`
+		Pipeline   struct {
+			Id int `json:"id"`
+		} `json:"pipeline"`
+	} `json:"deployable"`
+}
+
+var ExtractApiDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiDeployments",
+	EntryPoint:       ExtractApiDeployments,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployment data into tool layer table GitlabDeployment",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiDeployment := &GitlabApiDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiDeployment))
+			if err != nil {
+				return nil, err
+			}
+			gitlabDeployment := &models.GitlabDeployment{
+				ConnectionId:    data.Options.ConnectionId,
+				GitlabId:        apiDeployment.Id,
+				ProjectId:       data.Options.ProjectId,
+				Iid:             apiDeployment.Iid,
+				Ref:             apiDeployment.Ref,
+				Sha:             apiDeployment.Sha,
+				Status:          apiDeployment.Status,
+				EnvironmentId:   apiDeployment.Environment.Id,
+				EnvironmentName: apiDeployment.Environment.Name,
+				GitlabCreatedAt: apiDeployment.CreatedAt.ToTime(),
+				GitlabUpdatedAt: api.Iso8601TimeToTime(apiDeployment.UpdatedAt),
+			}
+			if apiDeployment.User != nil {
+				gitlabDeployment.UserId = apiDeployment.User.Id
+				gitlabDeployment.UserName = apiDeployment.User.Username
+			}
+			// the job running the deployment, absent for deployments created through the api
+			if apiDeployment.Deployable != nil {
+				gitlabDeployment.JobId = apiDeployment.Deployable.Id
+				gitlabDeployment.PipelineId = apiDeployment.Deployable.Pipeline.Id
+				gitlabDeployment.WebUrl = apiDeployment.Deployable.WebUrl
+				gitlabDeployment.StartedAt = api.Iso8601TimeToTime(apiDeployment.Deployable.StartedAt)
+				gitlabDeployment.FinishedAt = api.Iso8601TimeToTime(apiDeployment.Deployable.FinishedAt)
+			}
+			return []interface{}{gitlabDeployment}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ConvertReleasesMeta = plugin.SubTaskMeta{
+	Name:             "convertReleases",
+	EntryPoint:       ConvertReleases,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_releases into domain layer table releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GitlabTaskData)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabRelease{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	releaseIdGen := didgen.NewDomainIdGenerator(&models.GitlabRelease{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GitlabProject{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GitlabAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.GitlabRelease{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GitlabApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ProjectId:    data.Options.ProjectId,
+			},
+			Table: RAW_RELEASE_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			gitlabRelease := inputRow.(*models.GitlabRelease)
+			domainRelease := &code.Release{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: releaseIdGen.Generate(data.Options.ConnectionId, gitlabRelease.ProjectId, gitlabRelease.TagName),
+				},
+				RepoId:      projectIdGen.Generate(data.Options.ConnectionId, gitlabRelease.ProjectId),
+				Name:        gitlabRelease.Name,
+				TagName:     gitlabRelease.TagName,
+				CommitSha:   gitlabRelease.CommitSha,
+				Description: gitlabRelease.Description,
+				Url:         gitlabRelease.Url,
+				AuthorName:  gitlabRelease.AuthorUsername,
+				// an upcoming release is scheduled for a future date and not public yet
+				IsDraft:       gitlabRelease.UpcomingRelease,
+				CreatedDate:   gitlabRelease.GitlabCreatedAt,
+				PublishedDate: gitlabRelease.ReleasedAt,
+			}
+			if gitlabRelease.AuthorId != 0 {
+				domainRelease.AuthorId = accountIdGen.Generate(data.Options.ConnectionId, gitlabRelease.AuthorId)
+			}
+			return []interface{}{domainRelease}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+type GitlabApiRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Author      *struct {
+		Id       int    `json:"id"`
+		Username string `json:"username"`
+	} `json:"author"`
+	Commit struct {
+		Id string `json:"id"`
+	} `json:"commit"`
+	UpcomingRelease bool `json:"upcoming_release"`
+	Links           struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+	GitlabCreatedAt api.Iso8601Time  `json:"created_at"`
+	ReleasedAt      *api.Iso8601Time `json:"released_at"`
+}
+
+var ExtractApiReleasesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiReleases",
+	EntryPoint:       ExtractApiReleases,
+	EnabledByDefault: true,
+	Description:      "Extract raw release data into tool layer table GitlabRelease",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ExtractApiReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_RELEASE_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiRelease := &GitlabApiRelease{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiRelease))
+			if err != nil {
+				return nil, err
+			}
+			gitlabRelease := &models.GitlabRelease{
+				ConnectionId:    data.Options.ConnectionId,
+				ProjectId:       data.Options.ProjectId,
+				TagName:         apiRelease.TagName,
+				Name:            apiRelease.Name,
+				Description:     apiRelease.Description,
+				CommitSha:       apiRelease.Commit.Id,
+				UpcomingRelease: apiRelease.UpcomingRelease,
+				Url:             apiRelease.Links.Self,
+				GitlabCreatedAt: apiRelease.GitlabCreatedAt.ToTime(),
+				ReleasedAt:      api.Iso8601TimeToTime(apiRelease.ReleasedAt),
+			}
+			if apiRelease.Author != nil {
+				gitlabRelease.AuthorId = apiRelease.Author.Id
+				gitlabRelease.AuthorUsername = apiRelease.Author.Username
+			}
+			return []interface{}{gitlabRelease}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -20,7 +20,6 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
@@ -39,19 +38,12 @@ func Proxy(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Er
 	if err != nil {
 		return nil, err
 	}
-	apiClient, err := helper.NewApiClient(
-		context.TODO(),
-		connection.Endpoint,
-		map[string]string{
-			"Authorization": fmt.Sprintf("Basic %v", connection.GetEncodedToken()),
-		},
-		TimeOut,
-		connection.Proxy,
-		basicRes,
-	)
+	// the client resolves secret references of the connection and sets up the basic auth
+	apiClient, err := helper.NewApiClientFromConnection(context.TODO(), basicRes, connection)
 	if err != nil {
 		return nil, err
 	}
+	apiClient.SetTimeout(TimeOut)
 
 	resp, err := apiClient.Get(input.Params["path"], input.Query, nil)
 	if err != nil {
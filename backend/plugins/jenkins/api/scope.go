@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
 	"strings"
 )
 
@@ -93,3 +94,23 @@ func GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors
 	input.Params["scopeId"] = strings.TrimLeft(input.Params["scopeId"], "/")
 	return scopeHelper.GetScope(input, "full_name")
 }
+
+// DeleteScope delete a jenkins job and all data collected for it
+// @Summary delete a jenkins job and all data collected for it
+// @Description Purge raw, tool layer and domain layer data collected for the jenkins job and reset its collector states.
+// @Description The jenkins job itself would be deleted as well unless dataOnly was set.
+// @Tags plugins/jenkins
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path string true "job's full name"
+// @Param dryRun query bool false "only report the number of rows to be deleted"
+// @Param dataOnly query bool false "keep the jenkins job, only purge the collected data"
+// @Success 200  {object} api.ScopeDeletionRes
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/jenkins/connections/{connectionId}/scopes/{scopeId} [DELETE]
+func DeleteScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	input.Params["scopeId"] = strings.TrimLeft(input.Params["scopeId"], "/")
+	return scopeHelper.Delete(input, "full_name", func(scope *models.JenkinsJob) interface{} {
+		return tasks.JenkinsApiParams{ConnectionId: scope.ConnectionId, FullName: scope.FullName}
+	})
+}
@@ -98,6 +98,25 @@ func GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors
 	return scopeHelper.GetScope(input, "board_id")
 }
 
+// DeleteScope delete a jira board and all data collected for it
+// @Summary delete a jira board and all data collected for it
+// @Description Purge raw, tool layer and domain layer data collected for the jira board and reset its collector states.
+// @Description The jira board itself would be deleted as well unless dataOnly was set.
+// @Tags plugins/jira
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path int true "scope ID"
+// @Param dryRun query bool false "only report the number of rows to be deleted"
+// @Param dataOnly query bool false "keep the jira board, only purge the collected data"
+// @Success 200  {object} api.ScopeDeletionRes
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/jira/connections/{connectionId}/scopes/{scopeId} [DELETE]
+func DeleteScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return scopeHelper.Delete(input, "board_id", func(scope *models.JiraBoard) interface{} {
+		return tasks.JiraApiParams{ConnectionId: scope.ConnectionId, BoardId: scope.BoardId}
+	})
+}
+
 func GetApiJira(op *tasks.JiraOptions, apiClient aha.ApiClientAbstract) (*apiv2models.Board, errors.Error) {
 	boardRes := &apiv2models.Board{}
 	res, err := apiClient.Get(fmt.Sprintf("agile/1.0/board/%d", op.BoardId), nil, nil)
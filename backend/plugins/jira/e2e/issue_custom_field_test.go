@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/jira/impl"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks"
+)
+
+func TestIssueCustomFieldDataFlow(t *testing.T) {
+	var plugin impl.Jira
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jira", plugin)
+
+	taskData := &tasks.JiraTaskData{
+		Options: &tasks.JiraOptions{
+			ConnectionId: 2,
+			BoardId:      8,
+			TransformationRules: &tasks.JiraTransformationRule{
+				CustomFieldMappings: tasks.CustomFieldMappings{
+					{Path: "customfield_10030", Column: "severity"},
+					{Path: "components", Column: "component"},
+					{Path: "customfield_10043", Column: "x_team"},
+					{Path: "customfield_10040", Column: "x_due_date"},
+					{Path: "customfield_10041", Column: "x_story_number"},
+					{Path: "customfield_10042", Column: "x_cost"},
+				},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jira_api_issues_for_custom_fields.csv", "_raw_jira_api_issues")
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues_for_custom_fields.csv", &ticket.Issue{})
+	// the customized columns are created by the customize plugin in production
+	for column, columnType := range map[string]dal.ColumnType{
+		"x_team":         dal.Varchar,
+		"x_due_date":     dal.Time,
+		"x_story_number": dal.Int,
+		"x_cost":         dal.Float,
+	} {
+		err := dataflowTester.Dal.AddColumn("issues", column, columnType)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// verify custom field conversion
+	dataflowTester.Subtask(tasks.ConvertIssueCustomFieldsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues_custom_fields.csv",
+		[]string{
+			"priority",
+			"severity",
+			"component",
+			"x_team",
+			"x_due_date",
+			"x_story_number",
+			"x_cost",
+		},
+	)
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/jira/impl"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks"
+)
+
+func TestVersionDataFlow(t *testing.T) {
+	var plugin impl.Jira
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jira", plugin)
+
+	taskData := &tasks.JiraTaskData{
+		Options: &tasks.JiraOptions{
+			ConnectionId: 2,
+			BoardId:      8,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jira_api_versions.csv", "_raw_jira_api_versions")
+
+	// verify version extraction
+	dataflowTester.FlushTabler(&models.JiraVersion{})
+	dataflowTester.FlushTabler(&models.JiraBoardVersion{})
+	dataflowTester.Subtask(tasks.ExtractVersionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JiraVersion{},
+		"./snapshot_tables/_tool_jira_versions.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"version_id",
+			"project_id",
+			"self",
+			"name",
+			"description",
+			"archived",
+			"released",
+			"start_date",
+			"release_date",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.JiraBoardVersion{},
+		"./snapshot_tables/_tool_jira_board_versions.csv",
+		[]string{"connection_id", "board_id", "version_id"},
+	)
+
+	// verify version conversion
+	dataflowTester.FlushTabler(&ticket.Version{})
+	dataflowTester.Subtask(tasks.ConvertVersionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Version{},
+		"./snapshot_tables/versions.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"board_id",
+			"name",
+			"description",
+			"url",
+			"status",
+			"start_date",
+			"release_date",
+		),
+	)
+
+	// verify issue version conversion
+	dataflowTester.FlushTabler(&ticket.IssueVersion{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_board_issues_for_changelog.csv", &models.JiraBoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issue_versions_for_convertor.csv", &models.JiraIssueVersion{})
+	dataflowTester.Subtask(tasks.ConvertIssueVersionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.IssueVersion{},
+		"./snapshot_tables/issue_versions.csv",
+		e2ehelper.ColumnWithRawData(
+			"issue_id",
+			"version_id",
+			"type",
+		),
+	)
+}
@@ -73,19 +73,23 @@ func (p Jira) GetTablesInfo() []dal.Tabler {
 		&models.JiraBoard{},
 		&models.JiraBoardIssue{},
 		&models.JiraBoardSprint{},
+		&models.JiraBoardVersion{},
 		&models.JiraConnection{},
 		&models.JiraIssue{},
 		&models.JiraIssueChangelogItems{},
 		&models.JiraIssueChangelogs{},
 		&models.JiraIssueCommit{},
 		&models.JiraIssueLabel{},
+		&models.JiraIssueRelationship{},
 		&models.JiraIssueType{},
+		&models.JiraIssueVersion{},
 		&models.JiraProject{},
 		&models.JiraRemotelink{},
 		&models.JiraServerInfo{},
 		&models.JiraSprint{},
 		&models.JiraSprintIssue{},
 		&models.JiraStatus{},
+		&models.JiraVersion{},
 		&models.JiraWorklog{},
 	}
 }
@@ -109,6 +113,8 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractIssuesMeta,
 
 		tasks.ConvertIssueLabelsMeta,
+		tasks.ConvertIssueRelationshipsMeta,
+		tasks.ConvertIssueVersionsMeta,
 
 		tasks.CollectIssueChangelogsMeta,
 		tasks.ExtractIssueChangelogsMeta,
@@ -124,9 +130,13 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectSprintsMeta,
 		tasks.ExtractSprintsMeta,
 
+		tasks.CollectVersionsMeta,
+		tasks.ExtractVersionsMeta,
+
 		tasks.ConvertBoardMeta,
 
 		tasks.ConvertIssuesMeta,
+		tasks.ConvertIssueCustomFieldsMeta,
 
 		tasks.ConvertWorklogsMeta,
 
@@ -135,6 +145,8 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertSprintsMeta,
 		tasks.ConvertSprintIssuesMeta,
 
+		tasks.ConvertVersionsMeta,
+
 		tasks.ConvertIssueCommitsMeta,
 		tasks.ConvertIssueRepoCommitsMeta,
 
@@ -223,6 +235,13 @@ func (p Jira) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]int
 			return nil, errors.BadInput.Wrap(err, "fail to make transformationRule")
 		}
 	}
+	// the rules passed inline in the options are not validated when being saved
+	if op.TransformationRules != nil {
+		err = op.TransformationRules.CustomFieldMappings.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	info, code, err := tasks.GetJiraServerInfo(jiraApiClient)
 	if err != nil || code != http.StatusOK || info == nil {
@@ -284,8 +303,9 @@ func (p Jira) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": api.Proxy,
 		},
 		"connections/:connectionId/scopes/:scopeId": {
-			"GET":   api.GetScope,
-			"PATCH": api.UpdateScope,
+			"GET":    api.GetScope,
+			"PATCH":  api.UpdateScope,
+			"DELETE": api.DeleteScope,
 		},
 		"connections/:connectionId/scopes": {
 			"GET": api.GetScopeList,
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// JiraIssueRelationship is an issue link as seen from one of the issues it connects,
+// with its direction normalized so that it reads "source <outward> target"
+type JiraIssueRelationship struct {
+	ConnectionId   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueId        uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueLinkId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	SourceIssueId  uint64
+	SourceIssueKey string `gorm:"type:varchar(255)"`
+	TargetIssueId  uint64
+	TargetIssueKey string `gorm:"type:varchar(255)"`
+	TypeId         string `gorm:"type:varchar(255)"`
+	TypeName       string `gorm:"type:varchar(255)"`
+	Inward         string `gorm:"type:varchar(255)"`
+	Outward        string `gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (JiraIssueRelationship) TableName() string {
+	return "_tool_jira_issue_relationships"
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addIssueRelationships)(nil)
+
+type jiraIssueRelationship20230323 struct {
+	ConnectionId   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueId        uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueLinkId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	SourceIssueId  uint64
+	SourceIssueKey string `gorm:"type:varchar(255)"`
+	TargetIssueId  uint64
+	TargetIssueKey string `gorm:"type:varchar(255)"`
+	TypeId         string `gorm:"type:varchar(255)"`
+	TypeName       string `gorm:"type:varchar(255)"`
+	Inward         string `gorm:"type:varchar(255)"`
+	Outward        string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (jiraIssueRelationship20230323) TableName() string {
+	return "_tool_jira_issue_relationships"
+}
+
+type addIssueRelationships struct{}
+
+func (*addIssueRelationships) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &jiraIssueRelationship20230323{})
+}
+
+func (*addIssueRelationships) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(&jiraIssueRelationship20230323{})
+}
+
+func (*addIssueRelationships) Version() uint64 {
+	return 20230323000001
+}
+
+func (*addIssueRelationships) Name() string {
+	return "add _tool_jira_issue_relationships"
+}
@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addVersions)(nil)
+
+type jiraVersion20230326 struct {
+	ConnectionId uint64 `gorm:"primaryKey;autoIncrement:false"`
+	VersionId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    uint64
+	Self         string `gorm:"type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string
+	Archived     bool
+	Released     bool
+	StartDate    *time.Time
+	ReleaseDate  *time.Time
+	archived.NoPKModel
+}
+
+func (jiraVersion20230326) TableName() string {
+	return "_tool_jira_versions"
+}
+
+type jiraBoardVersion20230326 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey;autoIncrement:false"`
+	BoardId      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	VersionId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+}
+
+func (jiraBoardVersion20230326) TableName() string {
+	return "_tool_jira_board_versions"
+}
+
+type jiraIssueVersion20230326 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueId      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	VersionId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Type         string `gorm:"primaryKey;type:varchar(100)"`
+	Name         string `gorm:"type:varchar(255)"`
+}
+
+func (jiraIssueVersion20230326) TableName() string {
+	return "_tool_jira_issue_versions"
+}
+
+type addVersions struct{}
+
+func (*addVersions) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&jiraVersion20230326{},
+		&jiraBoardVersion20230326{},
+		&jiraIssueVersion20230326{},
+	)
+}
+
+func (*addVersions) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropTables(
+		&jiraVersion20230326{},
+		&jiraBoardVersion20230326{},
+		&jiraIssueVersion20230326{},
+	)
+}
+
+func (*addVersions) Version() uint64 {
+	return 20230326000001
+}
+
+func (*addVersions) Name() string {
+	return "add _tool_jira_versions, _tool_jira_board_versions and _tool_jira_issue_versions"
+}
@@ -34,5 +34,8 @@ func All() []plugin.MigrationScript {
 		new(addCommitRepoPattern),
 		new(expandRemotelinkUrl),
 		new(addConnectionIdToTransformationRule),
+		new(addIssueRelationships),
+		new(addCustomFieldMappings),
+		new(addVersions),
 	}
 }
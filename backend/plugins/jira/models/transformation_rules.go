@@ -34,6 +34,7 @@ type JiraTransformationRule struct {
 	RemotelinkCommitShaPattern string          `mapstructure:"remotelinkCommitShaPattern,omitempty" json:"remotelinkCommitShaPattern" gorm:"type:varchar(255)"`
 	RemotelinkRepoPattern      json.RawMessage `mapstructure:"remotelinkRepoPattern,omitempty" json:"remotelinkRepoPattern"`
 	TypeMappings               json.RawMessage `mapstructure:"typeMappings,omitempty" json:"typeMappings"`
+	CustomFieldMappings        json.RawMessage `mapstructure:"customFieldMappings,omitempty" json:"customFieldMappings"`
 }
 
 func (r JiraTransformationRule) TableName() string {
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	VERSION_TYPE_FIX     = "FIX"
+	VERSION_TYPE_AFFECTS = "AFFECTS"
+)
+
+type JiraVersion struct {
+	ConnectionId uint64 `gorm:"primaryKey;autoIncrement:false"`
+	VersionId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    uint64
+	Self         string `gorm:"type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string
+	Archived     bool
+	Released     bool
+	StartDate    *time.Time
+	ReleaseDate  *time.Time
+	common.NoPKModel
+}
+
+type JiraBoardVersion struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey;autoIncrement:false"`
+	BoardId      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	VersionId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+}
+
+// JiraIssueVersion is a version listed in either the fixVersions (FIX) or
+// the affectsVersions (AFFECTS) field of an issue
+type JiraIssueVersion struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueId      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	VersionId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Type         string `gorm:"primaryKey;type:varchar(100)"`
+	Name         string `gorm:"type:varchar(255)"`
+}
+
+func (JiraVersion) TableName() string {
+	return "_tool_jira_versions"
+}
+
+func (JiraBoardVersion) TableName() string {
+	return "_tool_jira_board_versions"
+}
+
+func (JiraIssueVersion) TableName() string {
+	return "_tool_jira_issue_versions"
+}
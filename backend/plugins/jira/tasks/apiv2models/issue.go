@@ -63,7 +63,7 @@ type Issue struct {
 				Three2X32 string `json:"32x32"`
 			} `json:"avatarUrls"`
 		} `json:"project"`
-		FixVersions        []interface{}       `json:"fixVersions"`
+		FixVersions        []IssueVersion      `json:"fixVersions"`
 		Aggregatetimespent interface{}         `json:"aggregatetimespent"`
 		Resolution         interface{}         `json:"resolution"`
 		Resolutiondate     *helper.Iso8601Time `json:"resolutiondate"`
@@ -95,8 +95,8 @@ type Issue struct {
 		Labels                        []string           `json:"labels"`
 		Timeestimate                  interface{}        `json:"timeestimate"`
 		Aggregatetimeoriginalestimate interface{}        `json:"aggregatetimeoriginalestimate"`
-		Versions                      []interface{}      `json:"versions"`
-		Issuelinks                    []interface{}      `json:"issuelinks"`
+		Versions                      []IssueVersion     `json:"versions"`
+		Issuelinks                    []IssueLink        `json:"issuelinks"`
 		Assignee                      *Account           `json:"assignee"`
 		Updated                       helper.Iso8601Time `json:"updated"`
 		Status                        struct {
@@ -207,6 +207,29 @@ func (i Issue) toToolLayer(connectionId uint64) *models.JiraIssue {
 	return result
 }
 
+// ExtractRelationships returns the issue links of the issue
+func (i Issue) ExtractRelationships(connectionId uint64) []*models.JiraIssueRelationship {
+	var relationships []*models.JiraIssueRelationship
+	for _, link := range i.Fields.Issuelinks {
+		if relationship := link.ToToolLayer(connectionId, i.ID, i.Key); relationship != nil {
+			relationships = append(relationships, relationship)
+		}
+	}
+	return relationships
+}
+
+// ExtractVersions returns the versions the issue is fixed in and the ones it affects
+func (i Issue) ExtractVersions(connectionId uint64) []*models.JiraIssueVersion {
+	var versions []*models.JiraIssueVersion
+	for _, v := range i.Fields.FixVersions {
+		versions = append(versions, v.ToToolLayer(connectionId, i.ID, models.VERSION_TYPE_FIX))
+	}
+	for _, v := range i.Fields.Versions {
+		versions = append(versions, v.ToToolLayer(connectionId, i.ID, models.VERSION_TYPE_AFFECTS))
+	}
+	return versions
+}
+
 func (i *Issue) SetAllFields(raw datatypes.JSON) errors.Error {
 	var issue2 struct {
 		Expand string          `json:"expand"`
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+type IssueLink struct {
+	ID   uint64 `json:"id,string"`
+	Self string `json:"self"`
+	Type struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Inward  string `json:"inward"`
+		Outward string `json:"outward"`
+		Self    string `json:"self"`
+	} `json:"type"`
+	InwardIssue  *LinkedIssue `json:"inwardIssue"`
+	OutwardIssue *LinkedIssue `json:"outwardIssue"`
+}
+
+type LinkedIssue struct {
+	ID   uint64 `json:"id,string"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+// ToToolLayer returns the link as seen from the given issue, nil if it points nowhere.
+// An outward link reads "issue <outward> linked issue", an inward one "linked issue <outward> issue".
+func (l IssueLink) ToToolLayer(connectionId, issueId uint64, issueKey string) *models.JiraIssueRelationship {
+	relationship := &models.JiraIssueRelationship{
+		ConnectionId: connectionId,
+		IssueId:      issueId,
+		IssueLinkId:  l.ID,
+		TypeId:       l.Type.ID,
+		TypeName:     l.Type.Name,
+		Inward:       l.Type.Inward,
+		Outward:      l.Type.Outward,
+	}
+	switch {
+	case l.OutwardIssue != nil:
+		relationship.SourceIssueId = issueId
+		relationship.SourceIssueKey = issueKey
+		relationship.TargetIssueId = l.OutwardIssue.ID
+		relationship.TargetIssueKey = l.OutwardIssue.Key
+	case l.InwardIssue != nil:
+		relationship.SourceIssueId = l.InwardIssue.ID
+		relationship.SourceIssueKey = l.InwardIssue.Key
+		relationship.TargetIssueId = issueId
+		relationship.TargetIssueKey = issueKey
+	default:
+		return nil
+	}
+	return relationship
+}
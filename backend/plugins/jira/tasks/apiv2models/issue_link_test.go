@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueLink_ToToolLayer(t *testing.T) {
+	link := IssueLink{ID: 10001}
+	link.Type.Name = "Blocks"
+	link.Type.Inward = "is blocked by"
+	link.Type.Outward = "blocks"
+
+	// no linked issue at all
+	assert.Nil(t, link.ToToolLayer(1, 100, "TEST-1"))
+
+	// TEST-1 blocks TEST-2
+	link.OutwardIssue = &LinkedIssue{ID: 200, Key: "TEST-2"}
+	outward := link.ToToolLayer(1, 100, "TEST-1")
+	assert.Equal(t, uint64(100), outward.IssueId)
+	assert.Equal(t, uint64(100), outward.SourceIssueId)
+	assert.Equal(t, "TEST-1", outward.SourceIssueKey)
+	assert.Equal(t, uint64(200), outward.TargetIssueId)
+	assert.Equal(t, "TEST-2", outward.TargetIssueKey)
+
+	// the same link seen from TEST-2: TEST-1 still blocks TEST-2
+	link.OutwardIssue = nil
+	link.InwardIssue = &LinkedIssue{ID: 100, Key: "TEST-1"}
+	inward := link.ToToolLayer(1, 200, "TEST-2")
+	assert.Equal(t, uint64(200), inward.IssueId)
+	assert.Equal(t, uint64(100), inward.SourceIssueId)
+	assert.Equal(t, "TEST-1", inward.SourceIssueKey)
+	assert.Equal(t, uint64(200), inward.TargetIssueId)
+	assert.Equal(t, "TEST-2", inward.TargetIssueKey)
+	assert.Equal(t, uint64(10001), inward.IssueLinkId)
+	assert.Equal(t, "blocks", inward.Outward)
+}
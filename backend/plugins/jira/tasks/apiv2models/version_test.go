@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssue_ExtractVersions(t *testing.T) {
+	var issue Issue
+	err := json.Unmarshal([]byte(`{
+		"id": "100",
+		"key": "TEST-1",
+		"fields": {
+			"fixVersions": [{"self": "https://example.atlassian.net/rest/api/2/version/10001", "id": "10001", "name": "1.1.0"}],
+			"versions": [{"self": "https://example.atlassian.net/rest/api/2/version/10000", "id": "10000", "name": "1.0.0"}]
+		}
+	}`), &issue)
+	assert.Nil(t, err)
+
+	versions := issue.ExtractVersions(1)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, uint64(100), versions[0].IssueId)
+	assert.Equal(t, uint64(10001), versions[0].VersionId)
+	assert.Equal(t, models.VERSION_TYPE_FIX, versions[0].Type)
+	assert.Equal(t, "1.1.0", versions[0].Name)
+	assert.Equal(t, uint64(10000), versions[1].VersionId)
+	assert.Equal(t, models.VERSION_TYPE_AFFECTS, versions[1].Type)
+}
+
+func TestVersion_ToToolLayer(t *testing.T) {
+	var version Version
+	err := json.Unmarshal([]byte(`{
+		"id": 10000,
+		"projectId": 10007,
+		"name": "1.0.0",
+		"released": true,
+		"releaseDate": "2020-06-30"
+	}`), &version)
+	assert.Nil(t, err)
+
+	jiraVersion := version.ToToolLayer(1)
+	assert.Equal(t, uint64(10000), jiraVersion.VersionId)
+	assert.Equal(t, uint64(10007), jiraVersion.ProjectId)
+	assert.True(t, jiraVersion.Released)
+	assert.Nil(t, jiraVersion.StartDate)
+	assert.Equal(t, "2020-06-30", jiraVersion.ReleaseDate.Format("2006-01-02"))
+}
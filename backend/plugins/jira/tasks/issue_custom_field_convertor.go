@@ -0,0 +1,270 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/tidwall/gjson"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertIssueCustomFields
+
+var ConvertIssueCustomFieldsMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueCustomFields",
+	EntryPoint:       ConvertIssueCustomFields,
+	EnabledByDefault: true,
+	Description:      "copy Jira fields onto the columns of domain layer table issues as configured by customFieldMappings",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// ConvertIssueCustomFields must run after ConvertIssues, it reads the fields straight from the raw issues
+// so that any field, including the ones the tool layer does not keep, can be mapped
+func ConvertIssueCustomFields(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	if data.Options.TransformationRules == nil || len(data.Options.TransformationRules.CustomFieldMappings) == 0 {
+		return nil
+	}
+	mappings := data.Options.TransformationRules.CustomFieldMappings
+	// a mapping must never overwrite the columns populated by ConvertIssues, i.e. `id` or `status`
+	err := mappings.Validate()
+	if err != nil {
+		return err
+	}
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	columnTypes, err := verifyCustomFieldColumns(db, mappings)
+	if err != nil {
+		return err
+	}
+	rawDataSubTask, err := api.NewRawDataSubTask(api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: JiraApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			BoardId:      data.Options.BoardId,
+		},
+		Table: RAW_ISSUE_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.From(rawDataSubTask.GetTable()),
+		dal.Where("params = ?", rawDataSubTask.GetParams()),
+		dal.Orderby("id ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	taskCtx.SetProgress(0, -1)
+	ctx := taskCtx.GetContext()
+	row := &api.RawData{}
+	// the updates are committed in batches, updating the issues one by one would take a commit per issue
+	batch := make([]*customFieldUpdate, 0, customFieldBatchSize)
+	for cursor.Next() {
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		default:
+		}
+		err = db.Fetch(cursor, row)
+		if err != nil {
+			return err
+		}
+		issue := gjson.ParseBytes(row.Data)
+		issueId := issueIdGen.Generate(data.Options.ConnectionId, issue.Get("id").Uint())
+		fields := issue.Get("fields")
+		update := &customFieldUpdate{
+			issueId: issueId,
+			sets:    make([]dal.DalSet, 0, len(mappings)),
+		}
+		for _, mapping := range mappings {
+			field := fields.Get(mapping.Path)
+			// the field is not available to the issue, i.e. not on the screen of its type, the column is left as is
+			if !field.Exists() {
+				continue
+			}
+			value, err := convertCustomFieldValue(field, columnTypes[mapping.Column])
+			if err != nil {
+				logger.Warn(err, "failed to convert field %s of issue %s for column %s", mapping.Path, issueId, mapping.Column)
+			}
+			update.sets = append(update.sets, dal.DalSet{
+				ColumnName: mapping.Column,
+				Value:      value,
+			})
+		}
+		if len(update.sets) > 0 {
+			batch = append(batch, update)
+		}
+		if len(batch) == customFieldBatchSize {
+			err = updateCustomFields(db, batch)
+			if err != nil {
+				return err
+			}
+			taskCtx.IncProgress(len(batch))
+			batch = batch[:0]
+		}
+	}
+	err = updateCustomFields(db, batch)
+	if err != nil {
+		return err
+	}
+	taskCtx.IncProgress(len(batch))
+	return nil
+}
+
+const customFieldBatchSize = 500
+
+type customFieldUpdate struct {
+	issueId string
+	sets    []dal.DalSet
+}
+
+// updateCustomFields writes the values of a batch of issues within a single transaction
+func updateCustomFields(db dal.Dal, batch []*customFieldUpdate) (err errors.Error) {
+	if len(batch) == 0 {
+		return nil
+	}
+	tx := db.Begin()
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, update := range batch {
+		err = tx.UpdateColumns(&ticket.Issue{}, update.sets, dal.Where("id = ?", update.issueId))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// verifyCustomFieldColumns makes sure the customized columns were created through the `customize` plugin beforehand,
+// and returns the type of each mapped column so that the values can be converted accordingly
+func verifyCustomFieldColumns(db dal.Dal, mappings CustomFieldMappings) (map[string]dal.ColumnType, errors.Error) {
+	columns, err := db.GetColumns(&ticket.Issue{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]dal.ColumnType, len(columns))
+	for _, column := range columns {
+		existing[column.Name()] = toCustomFieldColumnType(column.DatabaseTypeName())
+	}
+	columnTypes := make(map[string]dal.ColumnType, len(mappings))
+	for _, mapping := range mappings {
+		columnType, ok := existing[mapping.Column]
+		if !ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("column %s does not exist in table issues, create it with the customize plugin first", mapping.Column))
+		}
+		columnTypes[mapping.Column] = columnType
+	}
+	return columnTypes, nil
+}
+
+// toCustomFieldColumnType reduces the database type of a column, i.e. `BIGINT` on MySQL or `INT8` on PostgreSQL,
+// to one of the types supported by the `customize` plugin
+func toCustomFieldColumnType(databaseTypeName string) dal.ColumnType {
+	name := strings.ToLower(databaseTypeName)
+	switch {
+	case strings.Contains(name, "time") || strings.Contains(name, "date"):
+		return dal.Time
+	case strings.Contains(name, "float") || strings.Contains(name, "double") ||
+		strings.Contains(name, "decimal") || strings.Contains(name, "numeric") || name == "real":
+		return dal.Float
+	case strings.Contains(name, "int") && name != "interval":
+		return dal.Int
+	default:
+		return dal.Varchar
+	}
+}
+
+// convertCustomFieldValue converts a Jira field to the type of the column it is mapped to,
+// nil is returned along with the error if the value does not fit the column
+func convertCustomFieldValue(field gjson.Result, columnType dal.ColumnType) (interface{}, errors.Error) {
+	value := getCustomFieldValue(field)
+	if value == nil {
+		return nil, nil
+	}
+	str := strings.TrimSpace(fmt.Sprint(value))
+	switch columnType {
+	case dal.Time:
+		t, err := api.ConvertStringToTime(str)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("%s is not a datetime", str))
+		}
+		return t, nil
+	case dal.Int:
+		i, err := strconv.ParseInt(str, 10, 64)
+		if err == nil {
+			return i, nil
+		}
+		// number fields of Jira are float, i.e. 3.0
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("%s is not an integer", str))
+		}
+		return int64(f), nil
+	case dal.Float:
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("%s is not a number", str))
+		}
+		return f, nil
+	default:
+		return value, nil
+	}
+}
+
+// getCustomFieldValue flattens a Jira field into a single value: options and users are
+// represented by their display value, arrays by the comma separated values of their elements
+func getCustomFieldValue(field gjson.Result) interface{} {
+	switch {
+	case !field.Exists() || field.Type == gjson.Null:
+		return nil
+	case field.IsArray():
+		values := make([]string, 0)
+		for _, element := range field.Array() {
+			if value := getCustomFieldValue(element); value != nil {
+				values = append(values, fmt.Sprint(value))
+			}
+		}
+		return strings.Join(values, ",")
+	case field.IsObject():
+		for _, key := range []string{"value", "displayName", "name", "key"} {
+			if value := field.Get(key); value.Exists() {
+				return value.String()
+			}
+		}
+		return field.Raw
+	default:
+		return field.String()
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetCustomFieldValue(t *testing.T) {
+	fields := gjson.Parse(`{
+		"summary": "a bug",
+		"customfield_10030": {"self": "https://x/10100", "value": "Critical", "id": "10100"},
+		"customfield_10031": [{"value": "frontend"}, {"value": "api"}],
+		"customfield_10032": null,
+		"customfield_10033": 3.5,
+		"customfield_10034": {"accountId": "abc", "displayName": "Tom"},
+		"customfield_10035": ["a", "b"],
+		"components": [{"id": "1", "name": "core"}]
+	}`)
+	assert.Equal(t, "a bug", getCustomFieldValue(fields.Get("summary")))
+	assert.Equal(t, "Critical", getCustomFieldValue(fields.Get("customfield_10030")))
+	assert.Equal(t, "10100", getCustomFieldValue(fields.Get("customfield_10030.id")))
+	assert.Equal(t, "frontend,api", getCustomFieldValue(fields.Get("customfield_10031")))
+	assert.Nil(t, getCustomFieldValue(fields.Get("customfield_10032")))
+	assert.Nil(t, getCustomFieldValue(fields.Get("customfield_19999")))
+	assert.Equal(t, "3.5", getCustomFieldValue(fields.Get("customfield_10033")))
+	assert.Equal(t, "Tom", getCustomFieldValue(fields.Get("customfield_10034")))
+	assert.Equal(t, "a,b", getCustomFieldValue(fields.Get("customfield_10035")))
+	assert.Equal(t, "core", getCustomFieldValue(fields.Get("components")))
+}
+
+func TestToCustomFieldColumnType(t *testing.T) {
+	assert.Equal(t, dal.Varchar, toCustomFieldColumnType("VARCHAR"))
+	assert.Equal(t, dal.Varchar, toCustomFieldColumnType("TEXT"))
+	assert.Equal(t, dal.Int, toCustomFieldColumnType("BIGINT"))
+	assert.Equal(t, dal.Int, toCustomFieldColumnType("INT8"))
+	assert.Equal(t, dal.Float, toCustomFieldColumnType("FLOAT"))
+	assert.Equal(t, dal.Float, toCustomFieldColumnType("FLOAT8"))
+	assert.Equal(t, dal.Float, toCustomFieldColumnType("DOUBLE"))
+	assert.Equal(t, dal.Time, toCustomFieldColumnType("TIMESTAMP"))
+	assert.Equal(t, dal.Time, toCustomFieldColumnType("DATETIME"))
+	assert.Equal(t, dal.Time, toCustomFieldColumnType("TIMESTAMPTZ"))
+}
+
+func TestConvertCustomFieldValue(t *testing.T) {
+	fields := gjson.Parse(`{
+		"customfield_10030": {"value": "Critical"},
+		"customfield_10040": "2023-03-01",
+		"customfield_10041": "2023-03-02T10:20:30.000+0800",
+		"customfield_10050": 5.0,
+		"customfield_10051": "12",
+		"customfield_10052": 3.5,
+		"customfield_10053": "abc",
+		"customfield_10054": null
+	}`)
+	value, err := convertCustomFieldValue(fields.Get("customfield_10030"), dal.Varchar)
+	assert.Nil(t, err)
+	assert.Equal(t, "Critical", value)
+	value, err = convertCustomFieldValue(fields.Get("customfield_10040"), dal.Time)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), value.(time.Time).UTC())
+	value, err = convertCustomFieldValue(fields.Get("customfield_10041"), dal.Time)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 3, 2, 2, 20, 30, 0, time.UTC), value.(time.Time).UTC())
+	value, err = convertCustomFieldValue(fields.Get("customfield_10050"), dal.Int)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+	value, err = convertCustomFieldValue(fields.Get("customfield_10051"), dal.Int)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), value)
+	value, err = convertCustomFieldValue(fields.Get("customfield_10052"), dal.Float)
+	assert.Nil(t, err)
+	assert.Equal(t, 3.5, value)
+	value, err = convertCustomFieldValue(fields.Get("customfield_10053"), dal.Int)
+	assert.NotNil(t, err)
+	assert.Nil(t, value)
+	value, err = convertCustomFieldValue(fields.Get("customfield_10053"), dal.Time)
+	assert.NotNil(t, err)
+	assert.Nil(t, value)
+	value, err = convertCustomFieldValue(fields.Get("customfield_10054"), dal.Float)
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+}
@@ -170,6 +170,12 @@ func extractIssues(data *JiraTaskData, mappings *typeMappings, row *api.RawData)
 		}
 		results = append(results, issueLabel)
 	}
+	for _, relationship := range apiIssue.ExtractRelationships(data.Options.ConnectionId) {
+		results = append(results, relationship)
+	}
+	for _, version := range apiIssue.ExtractVersions(data.Options.ConnectionId) {
+		results = append(results, version)
+	}
 	return results, nil
 }
 
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertIssueRelationshipsMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueRelationships",
+	EntryPoint:       ConvertIssueRelationships,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table jira_issue_relationships into domain layer table issue_relationships",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIssueRelationships(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JiraTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("jir.*"),
+		dal.From("_tool_jira_issue_relationships jir"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues jbi
+              ON jir.connection_id = jbi.connection_id AND jir.issue_id = jbi.issue_id`),
+		dal.Where("jir.connection_id = ? AND jbi.board_id = ?", data.Options.ConnectionId, data.Options.BoardId),
+		dal.Orderby("jir.issue_id ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ISSUE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraIssueRelationship{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			relationship := inputRow.(*models.JiraIssueRelationship)
+			return []interface{}{
+				&ticket.IssueRelationship{
+					SourceIssueId: issueIdGen.Generate(data.Options.ConnectionId, relationship.SourceIssueId),
+					TargetIssueId: issueIdGen.Generate(data.Options.ConnectionId, relationship.TargetIssueId),
+					Type:          getStdRelationshipType(relationship.TypeName, relationship.Outward),
+					OriginalType:  relationship.Outward,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getStdRelationshipType maps the built-in Jira link types, and custom ones named alike, to the standard types
+func getStdRelationshipType(name, outward string) string {
+	for _, s := range []string{strings.ToLower(name), strings.ToLower(outward)} {
+		switch {
+		case strings.Contains(s, "block"):
+			return ticket.RELATIONSHIP_BLOCKS
+		case strings.Contains(s, "duplicat"):
+			return ticket.RELATIONSHIP_DUPLICATES
+		case strings.Contains(s, "clone"):
+			return ticket.RELATIONSHIP_CLONES
+		case strings.Contains(s, "relate"):
+			return ticket.RELATIONSHIP_RELATES
+		}
+	}
+	return ticket.RELATIONSHIP_OTHER
+}
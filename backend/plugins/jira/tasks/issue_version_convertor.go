@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertIssueVersionsMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueVersions",
+	EntryPoint:       ConvertIssueVersions,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table jira_issue_versions into domain layer table issue_versions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIssueVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JiraTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("jiv.*"),
+		dal.From("_tool_jira_issue_versions jiv"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues jbi
+              ON jiv.connection_id = jbi.connection_id AND jiv.issue_id = jbi.issue_id`),
+		dal.Where("jiv.connection_id = ? AND jbi.board_id = ?", data.Options.ConnectionId, data.Options.BoardId),
+		dal.Orderby("jiv.issue_id ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	versionIdGen := didgen.NewDomainIdGenerator(&models.JiraVersion{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ISSUE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraIssueVersion{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			issueVersion := inputRow.(*models.JiraIssueVersion)
+			return []interface{}{
+				&ticket.IssueVersion{
+					IssueId:   issueIdGen.Generate(data.Options.ConnectionId, issueVersion.IssueId),
+					VersionId: versionIdGen.Generate(data.Options.ConnectionId, issueVersion.VersionId),
+					Type:      issueVersion.Type,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -20,6 +20,7 @@ package tasks
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -40,14 +41,45 @@ type TypeMapping struct {
 
 type TypeMappings map[string]TypeMapping
 
+// CustomFieldMapping copies the value found at Path, a gjson path into the `fields` of a Jira issue,
+// onto Column of the domain layer table `issues`
+type CustomFieldMapping struct {
+	Path   string `json:"path"`
+	Column string `json:"column"`
+}
+
+type CustomFieldMappings []CustomFieldMapping
+
+// standard columns of `issues` which may be overwritten by a CustomFieldMapping,
+// the customized columns created by the `customize` plugin are all prefixed with `x_`
+var customFieldMappingStdColumns = map[string]bool{
+	"priority":  true,
+	"severity":  true,
+	"component": true,
+	"epic_key":  true,
+}
+
+func (mappings CustomFieldMappings) Validate() errors.Error {
+	for _, mapping := range mappings {
+		if mapping.Path == "" {
+			return errors.BadInput.New(fmt.Sprintf("empty path is mapped to column %s", mapping.Column))
+		}
+		if !customFieldMappingStdColumns[mapping.Column] && !strings.HasPrefix(mapping.Column, "x_") {
+			return errors.BadInput.New(fmt.Sprintf("column %s is neither a supported standard column nor a customized one", mapping.Column))
+		}
+	}
+	return nil
+}
+
 type JiraTransformationRule struct {
-	ConnectionId               uint64       `mapstructure:"connectionId" json:"connectionId"`
-	Name                       string       `gorm:"type:varchar(255)" validate:"required"`
-	EpicKeyField               string       `json:"epicKeyField"`
-	StoryPointField            string       `json:"storyPointField"`
-	RemotelinkCommitShaPattern string       `json:"remotelinkCommitShaPattern"`
-	RemotelinkRepoPattern      []string     `json:"remotelinkRepoPattern"`
-	TypeMappings               TypeMappings `json:"typeMappings"`
+	ConnectionId               uint64              `mapstructure:"connectionId" json:"connectionId"`
+	Name                       string              `gorm:"type:varchar(255)" validate:"required"`
+	EpicKeyField               string              `json:"epicKeyField"`
+	StoryPointField            string              `json:"storyPointField"`
+	RemotelinkCommitShaPattern string              `json:"remotelinkCommitShaPattern"`
+	RemotelinkRepoPattern      []string            `json:"remotelinkRepoPattern"`
+	TypeMappings               TypeMappings        `json:"typeMappings"`
+	CustomFieldMappings        CustomFieldMappings `json:"customFieldMappings"`
 }
 
 func (r *JiraTransformationRule) ToDb() (*models.JiraTransformationRule, errors.Error) {
@@ -59,6 +91,13 @@ func (r *JiraTransformationRule) ToDb() (*models.JiraTransformationRule, errors.
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error marshaling RemotelinkRepoPattern")
 	}
+	if err1 := r.CustomFieldMappings.Validate(); err1 != nil {
+		return nil, err1
+	}
+	customFieldMappings, err := json.Marshal(r.CustomFieldMappings)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error marshaling CustomFieldMappings")
+	}
 	rule := &models.JiraTransformationRule{
 		ConnectionId:               r.ConnectionId,
 		Name:                       r.Name,
@@ -67,6 +106,7 @@ func (r *JiraTransformationRule) ToDb() (*models.JiraTransformationRule, errors.
 		RemotelinkCommitShaPattern: r.RemotelinkCommitShaPattern,
 		RemotelinkRepoPattern:      remotelinkRepoPattern,
 		TypeMappings:               blob,
+		CustomFieldMappings:        customFieldMappings,
 	}
 	if err1 := rule.VerifyRegexp(); err1 != nil {
 		return nil, err1
@@ -90,6 +130,13 @@ func MakeTransformationRules(rule models.JiraTransformationRule) (*JiraTransform
 			return nil, errors.Default.Wrap(err, "error unMarshaling RemotelinkRepoPattern")
 		}
 	}
+	var customFieldMappings CustomFieldMappings
+	if len(rule.CustomFieldMappings) > 0 {
+		err = json.Unmarshal(rule.CustomFieldMappings, &customFieldMappings)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error unMarshaling CustomFieldMappings")
+		}
+	}
 	result := &JiraTransformationRule{
 		ConnectionId:               rule.ConnectionId,
 		Name:                       rule.Name,
@@ -98,6 +145,7 @@ func MakeTransformationRules(rule models.JiraTransformationRule) (*JiraTransform
 		RemotelinkCommitShaPattern: rule.RemotelinkCommitShaPattern,
 		RemotelinkRepoPattern:      remotelinkRepoPattern,
 		TypeMappings:               typeMapping,
+		CustomFieldMappings:        customFieldMappings,
 	}
 	return result, nil
 }
@@ -23,6 +23,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMakeTransformationRules(t *testing.T) {
@@ -43,6 +44,7 @@ func TestMakeTransformationRules(t *testing.T) {
 				RemotelinkCommitShaPattern: "commit sha pattern",
 				RemotelinkRepoPattern:      []byte(`["abc","efg"]`),
 				TypeMappings:               []byte(`{"10040":{"standardType":"Incident","statusMappings":null}}`),
+				CustomFieldMappings:        []byte(`[{"path":"customfield_10030.value","column":"severity"}]`),
 			}},
 			&JiraTransformationRule{
 				Name:                       "name",
@@ -54,6 +56,7 @@ func TestMakeTransformationRules(t *testing.T) {
 					StandardType:   "Incident",
 					StatusMappings: nil,
 				}},
+				CustomFieldMappings: CustomFieldMappings{{Path: "customfield_10030.value", Column: "severity"}},
 			},
 			nil,
 		},
@@ -82,3 +85,12 @@ func TestMakeTransformationRules(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomFieldMappings_Validate(t *testing.T) {
+	assert.Nil(t, CustomFieldMappings{
+		{Path: "customfield_10030", Column: "severity"},
+		{Path: "customfield_10031", Column: "x_team"},
+	}.Validate())
+	assert.NotNil(t, CustomFieldMappings{{Path: "", Column: "x_team"}}.Validate())
+	assert.NotNil(t, CustomFieldMappings{{Path: "customfield_10030", Column: "title"}}.Validate())
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertVersionsMeta = plugin.SubTaskMeta{
+	Name:             "convertVersions",
+	EntryPoint:       ConvertVersions,
+	EnabledByDefault: true,
+	Description:      "convert Jira versions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+	boardId := data.Options.BoardId
+	logger := taskCtx.GetLogger()
+	db := taskCtx.GetDal()
+	logger.Info("convert versions")
+	clauses := []dal.Clause{
+		dal.Select("tjv.*"),
+		dal.From("_tool_jira_versions tjv"),
+		dal.Join(`LEFT JOIN _tool_jira_board_versions tjbv
+              ON tjbv.version_id = tjv.version_id
+                 AND tjbv.connection_id = tjv.connection_id`),
+		dal.Where("tjv.connection_id = ? AND tjbv.board_id = ?", connectionId, boardId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	domainBoardId := didgen.NewDomainIdGenerator(&models.JiraBoard{}).Generate(connectionId, boardId)
+	versionIdGen := didgen.NewDomainIdGenerator(&models.JiraVersion{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_VERSION_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraVersion{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraVersion := inputRow.(*models.JiraVersion)
+			version := &ticket.Version{
+				DomainEntity: domainlayer.DomainEntity{Id: versionIdGen.Generate(connectionId, jiraVersion.VersionId)},
+				BoardId:      domainBoardId,
+				Name:         jiraVersion.Name,
+				Description:  jiraVersion.Description,
+				Url:          jiraVersion.Self,
+				Status:       getStdVersionStatus(jiraVersion),
+				StartDate:    jiraVersion.StartDate,
+				ReleaseDate:  jiraVersion.ReleaseDate,
+			}
+			return []interface{}{version}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getStdVersionStatus reports archived versions as such even if they were released before
+func getStdVersionStatus(version *models.JiraVersion) string {
+	switch {
+	case version.Archived:
+		return ticket.VERSION_ARCHIVED
+	case version.Released:
+		return ticket.VERSION_RELEASED
+	}
+	return ticket.VERSION_UNRELEASED
+}
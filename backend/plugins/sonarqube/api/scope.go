@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/tasks"
 )
 
 type ScopeReq api.ScopeReq[models.SonarqubeProject]
@@ -85,3 +86,22 @@ func GetScopeList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 func GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	return scopeHelper.GetScope(input, "project_key")
 }
+
+// DeleteScope delete a sonarqube project and all data collected for it
+// @Summary delete a sonarqube project and all data collected for it
+// @Description Purge raw, tool layer and domain layer data collected for the sonarqube project and reset its collector states.
+// @Description The sonarqube project itself would be deleted as well unless dataOnly was set.
+// @Tags plugins/sonarqube
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path string true "project key"
+// @Param dryRun query bool false "only report the number of rows to be deleted"
+// @Param dataOnly query bool false "keep the sonarqube project, only purge the collected data"
+// @Success 200  {object} api.ScopeDeletionRes
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/sonarqube/connections/{connectionId}/scopes/{scopeId} [DELETE]
+func DeleteScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return scopeHelper.Delete(input, "project_key", func(scope *models.SonarqubeProject) interface{} {
+		return tasks.SonarqubeApiParams{ConnectionId: scope.ConnectionId, ProjectKey: scope.ProjectKey}
+	})
+}
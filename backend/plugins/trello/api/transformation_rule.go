@@ -69,7 +69,7 @@ func UpdateTransformationRule(input *plugin.ApiResourceInput) (*plugin.ApiResour
 	if e != nil {
 		return nil, errors.Default.Wrap(e, "the transformation rule ID should be an integer")
 	}
-	var old models.TrelloConnection
+	var old models.TrelloTransformationRule
 	err := basicRes.GetDal().First(&old, dal.Where("id = ?", transformationRuleId))
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error on saving TransformationRule")
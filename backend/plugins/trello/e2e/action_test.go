@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/trello/impl"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+	"github.com/apache/incubator-devlake/plugins/trello/tasks"
+	"testing"
+)
+
+func TestTrelloActionDataFlow(t *testing.T) {
+	var trello impl.Trello
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "trello", trello)
+
+	taskData := &tasks.TrelloTaskData{
+		Options: &tasks.TrelloOptions{
+			ConnectionId: 1,
+			BoardId:      "6402f643d23aa9af56b28f4b",
+			TransformationRules: &tasks.TrelloTransformationRule{
+				StatusMappings: tasks.StatusMappings{
+					"🗒 Backlog":    {StandardStatus: ticket.TODO},
+					"📅 Working On": {StandardStatus: ticket.IN_PROGRESS},
+					"🧑🏾‍💻 Testing [Staging Server]":    {StandardStatus: ticket.IN_PROGRESS},
+					"📆 Sprint - Done [Version: 1.2.0]": {StandardStatus: ticket.DONE},
+				},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_actions.csv", "_raw_trello_actions")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.TrelloAction{})
+	dataflowTester.Subtask(tasks.ExtractActionMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.TrelloAction{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_trello_actions.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_members.csv", &models.TrelloMember{})
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.Subtask(tasks.ConvertActionMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.IssueChangelogs{},
+		"./snapshot_tables/issue_changelogs.csv",
+		[]string{
+			"id",
+			"issue_id",
+			"author_id",
+			"author_name",
+			"field_id",
+			"field_name",
+			"original_from_value",
+			"original_to_value",
+			"from_value",
+			"to_value",
+			"created_date",
+		},
+	)
+}
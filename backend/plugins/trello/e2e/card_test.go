@@ -19,6 +19,7 @@ package e2e
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/trello/impl"
 	"github.com/apache/incubator-devlake/plugins/trello/models"
@@ -34,6 +35,20 @@ func TestTrelloCardDataFlow(t *testing.T) {
 		Options: &tasks.TrelloOptions{
 			ConnectionId: 1,
 			BoardId:      "6402f643d23aa9af56b28f4b",
+			TransformationRules: &tasks.TrelloTransformationRule{
+				StatusMappings: tasks.StatusMappings{
+					"🗒 Backlog":                        {StandardStatus: ticket.TODO},
+					"🗓 Sprint Backlog - [Timeline]":    {StandardStatus: ticket.TODO},
+					"🐞 Bugs":                           {StandardStatus: ticket.TODO},
+					"📅 Working On":                     {StandardStatus: ticket.IN_PROGRESS},
+					"🧑🏾‍💻 Testing [Staging Server]":    {StandardStatus: ticket.IN_PROGRESS},
+					"📆 Sprint - Done [Version: 1.2.0]": {StandardStatus: ticket.DONE},
+					"🗄 Sprint - Done [Version: 1.1.0]": {StandardStatus: ticket.DONE},
+				},
+				TypeMappings: tasks.TypeMappings{
+					"Flagged 🔴": {StandardType: ticket.BUG},
+				},
+			},
 		},
 	}
 
@@ -47,4 +62,47 @@ func TestTrelloCardDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/_tool_trello_cards.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_lists.csv", &models.TrelloList{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_labels.csv", &models.TrelloLabel{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_members.csv", &models.TrelloMember{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_actions.csv", &models.TrelloAction{})
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueLabel{})
+	dataflowTester.Subtask(tasks.ConvertCardMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		[]string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"type",
+			"original_type",
+			"status",
+			"original_status",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+			"parent_issue_id",
+			"creator_id",
+			"creator_name",
+			"assignee_id",
+			"assignee_name",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues.csv",
+		[]string{"board_id", "issue_id"},
+	)
+	dataflowTester.VerifyTable(
+		ticket.IssueLabel{},
+		"./snapshot_tables/issue_labels.csv",
+		[]string{"issue_id", "label_name"},
+	)
 }
@@ -19,6 +19,7 @@ package e2e
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/trello/impl"
 	"github.com/apache/incubator-devlake/plugins/trello/models"
@@ -47,4 +48,38 @@ func TestTrelloCheckItemDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/_tool_trello_check_items.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_actions.csv", &models.TrelloAction{})
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertCheckItemMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues_for_check_items.csv",
+		[]string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"type",
+			"original_type",
+			"status",
+			"original_status",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+			"parent_issue_id",
+			"creator_id",
+			"creator_name",
+			"assignee_id",
+			"assignee_name",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues_for_check_items.csv",
+		[]string{"board_id", "issue_id"},
+	)
 }
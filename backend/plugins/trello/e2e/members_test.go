@@ -19,6 +19,7 @@ package e2e
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/trello/impl"
 	"github.com/apache/incubator-devlake/plugins/trello/models"
@@ -47,4 +48,22 @@ func TestTrelloMemberDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/_tool_trello_members.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&crossdomain.Account{})
+	dataflowTester.Subtask(tasks.ConvertMemberMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.Account{},
+		"./snapshot_tables/accounts.csv",
+		[]string{
+			"id",
+			"email",
+			"full_name",
+			"user_name",
+			"avatar_url",
+			"organization",
+			"created_date",
+			"status",
+		},
+	)
 }
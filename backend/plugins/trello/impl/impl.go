@@ -58,6 +58,8 @@ func (p Trello) GetTablesInfo() []dal.Tabler {
 		&models.TrelloLabel{},
 		&models.TrelloMember{},
 		&models.TrelloCheckItem{},
+		&models.TrelloAction{},
+		&models.TrelloTransformationRule{},
 	}
 }
 
@@ -81,6 +83,15 @@ func (p Trello) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectMemberMeta,
 		tasks.ExtractMemberMeta,
+
+		tasks.CollectActionMeta,
+		tasks.ExtractActionMeta,
+
+		tasks.ConvertBoardMeta,
+		tasks.ConvertMemberMeta,
+		tasks.ConvertCardMeta,
+		tasks.ConvertActionMeta,
+		tasks.ConvertCheckItemMeta,
 	}
 }
 
@@ -99,6 +110,26 @@ func (p Trello) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 	if op.ConnectionId == 0 {
 		return nil, errors.BadInput.New("trello connectionId is invalid")
 	}
+	db := taskCtx.GetDal()
+	if op.TransformationRuleId == 0 {
+		board := &models.TrelloBoard{}
+		err = db.First(board, dal.Where("connection_id = ? AND board_id = ?", op.ConnectionId, op.BoardId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find board %s", op.BoardId))
+		}
+		op.TransformationRuleId = board.TransformationRuleId
+	}
+	if op.TransformationRules == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.TrelloTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.TransformationRules, err = tasks.MakeTransformationRules(transformationRule)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "fail to make transformationRule")
+		}
+	}
 
 	connection := &models.TrelloConnection{}
 	connectionHelper := helper.NewConnectionHelper(
@@ -24,7 +24,7 @@ import (
 type TrelloBoard struct {
 	common.NoPKModel     `json:"-" mapstructure:"-"`
 	ConnectionId         uint64 `json:"connectionId" mapstructure:"connectionId" gorm:"primaryKey"`
-	BoardId              string `json:"boardId" mapstructure:"boardId" gorm:"type:varchar(255)"`
+	BoardId              string `json:"boardId" mapstructure:"boardId" gorm:"primaryKey;type:varchar(255)"`
 	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId"`
 	Name                 string `json:"name" mapstructure:"name" gorm:"type:varchar(255)"`
 }
@@ -25,11 +25,15 @@ import (
 type TrelloCard struct {
 	ID               string `gorm:"primaryKey;type:varchar(255)"`
 	Name             string `gorm:"type:varchar(255)"`
+	Desc             string
 	Closed           bool
+	Due              *time.Time
 	DueComplete      bool
 	DateLastActivity time.Time
-	IDBoard          string `gorm:"type:varchar(255)"`
-	IDList           string `gorm:"type:varchar(255)"`
+	IDBoard          string   `gorm:"type:varchar(255)"`
+	IDList           string   `gorm:"type:varchar(255)"`
+	IDMembers        []string `gorm:"serializer:json;type:text"`
+	IDLabels         []string `gorm:"serializer:json;type:text"`
 	IDShort          int
 	Pos              float64
 	ShortLink        string `gorm:"type:varchar(255)"`
@@ -0,0 +1,145 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.ReversibleMigrationScript = (*addCardActionsAndMappings)(nil)
+
+type trelloBoard20230310Before struct {
+	archived.NoPKModel
+	ConnectionId         uint64 `gorm:"primaryKey"`
+	BoardId              string `gorm:"type:varchar(255)"`
+	TransformationRuleId uint64
+	Name                 string `gorm:"type:varchar(255)"`
+}
+
+func (trelloBoard20230310Before) TableName() string {
+	return "_tool_trello_boards"
+}
+
+type trelloBoard20230310After struct {
+	archived.NoPKModel
+	ConnectionId         uint64 `gorm:"primaryKey"`
+	BoardId              string `gorm:"primaryKey;type:varchar(255)"` // a connection holds many boards
+	TransformationRuleId uint64
+	Name                 string `gorm:"type:varchar(255)"`
+}
+
+func (trelloBoard20230310After) TableName() string {
+	return "_tool_trello_boards"
+}
+
+type trelloCard20230310 struct {
+	Desc      string
+	Due       *time.Time
+	IDMembers string `gorm:"type:text"`
+	IDLabels  string `gorm:"type:text"`
+}
+
+func (trelloCard20230310) TableName() string {
+	return "_tool_trello_cards"
+}
+
+type trelloTransformationRule20230310 struct {
+	StatusMappings json.RawMessage
+	TypeMappings   json.RawMessage
+}
+
+func (trelloTransformationRule20230310) TableName() string {
+	return "_tool_trello_transformation_rules"
+}
+
+type trelloAction20230310 struct {
+	ID              string `gorm:"primaryKey;type:varchar(255)"`
+	Type            string `gorm:"type:varchar(255)"`
+	IDBoard         string `gorm:"type:varchar(255)"`
+	IDCard          string `gorm:"index;type:varchar(255)"`
+	IDMemberCreator string `gorm:"type:varchar(255)"`
+	Date            time.Time
+	IDListBefore    string `gorm:"type:varchar(255)"`
+	ListBeforeName  string `gorm:"type:varchar(255)"`
+	IDListAfter     string `gorm:"type:varchar(255)"`
+	ListAfterName   string `gorm:"type:varchar(255)"`
+	IDCheckItem     string `gorm:"type:varchar(255)"`
+	CheckItemState  string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (trelloAction20230310) TableName() string {
+	return "_tool_trello_actions"
+}
+
+type addCardActionsAndMappings struct{}
+
+func (script *addCardActionsAndMappings) Up(basicRes context.BasicRes) errors.Error {
+	err := migrationhelper.TransformTable(
+		basicRes,
+		script,
+		trelloBoard20230310Before{}.TableName(),
+		func(s *trelloBoard20230310Before) (*trelloBoard20230310After, errors.Error) {
+			return &trelloBoard20230310After{
+				NoPKModel:            s.NoPKModel,
+				ConnectionId:         s.ConnectionId,
+				BoardId:              s.BoardId,
+				TransformationRuleId: s.TransformationRuleId,
+				Name:                 s.Name,
+			}, nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&trelloCard20230310{},
+		&trelloTransformationRule20230310{},
+		&trelloAction20230310{},
+	)
+}
+
+// Down leaves the widened board primary key in place, narrowing it back to the connection would drop boards
+func (*addCardActionsAndMappings) Down(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.DropColumns(trelloCard20230310{}.TableName(), "desc", "due", "id_members", "id_labels")
+	if err != nil {
+		return err
+	}
+	err = db.DropColumns(trelloTransformationRule20230310{}.TableName(), "status_mappings", "type_mappings")
+	if err != nil {
+		return err
+	}
+	return db.DropTables(&trelloAction20230310{})
+}
+
+func (*addCardActionsAndMappings) Version() uint64 {
+	return 20230310000001
+}
+
+func (*addCardActionsAndMappings) Name() string {
+	return "add board_id to the trello board primary key, card actions, card members/labels and status/type mappings"
+}
@@ -18,12 +18,16 @@ limitations under the License.
 package models
 
 import (
+	"encoding/json"
+
 	"github.com/apache/incubator-devlake/core/models/common"
 )
 
 type TrelloTransformationRule struct {
-	common.Model `mapstructure:"-"`
-	Name         string `mapstructure:"name" json:"name" gorm:"type:varchar(255);index:idx_name_trello,unique" validate:"required"`
+	common.Model   `mapstructure:"-"`
+	Name           string          `mapstructure:"name" json:"name" gorm:"type:varchar(255);index:idx_name_trello,unique" validate:"required"`
+	StatusMappings json.RawMessage `mapstructure:"statusMappings,omitempty" json:"statusMappings"`
+	TypeMappings   json.RawMessage `mapstructure:"typeMappings,omitempty" json:"typeMappings"`
 }
 
 func (TrelloTransformationRule) TableName() string {
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+	"reflect"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertAction
+
+var ConvertActionMeta = plugin.SubTaskMeta{
+	Name:             "ConvertAction",
+	EntryPoint:       ConvertAction,
+	EnabledByDefault: true,
+	Description:      "Convert card moves between lists in tool layer table trello_actions into domain layer table issue_changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertAction(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := createRawDataSubTaskArgs(taskCtx, RAW_ACTION_TABLE)
+	db := taskCtx.GetDal()
+
+	memberNames, err := getMemberNames(db)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloAction{}),
+		dal.Where("id_board = ? AND type = ? AND id_list_before != ''", data.Options.BoardId, "updateCard"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.TrelloAction{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			action := inputRow.(*models.TrelloAction)
+			return []interface{}{
+				&ticket.IssueChangelogs{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: getActionIdGen().Generate(action.ID),
+					},
+					IssueId:           getCardIdGen().Generate(action.IDCard),
+					AuthorId:          getMemberIdGen().Generate(action.IDMemberCreator),
+					AuthorName:        memberNames[action.IDMemberCreator],
+					FieldId:           "status",
+					FieldName:         "status",
+					OriginalFromValue: action.ListBeforeName,
+					OriginalToValue:   action.ListAfterName,
+					FromValue:         getStdStatus(data, action.ListBeforeName),
+					ToValue:           getStdStatus(data, action.ListAfterName),
+					CreatedDate:       action.Date,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
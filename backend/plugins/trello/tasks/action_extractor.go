@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+	"time"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractAction
+
+var ExtractActionMeta = plugin.SubTaskMeta{
+	Name:             "ExtractAction",
+	EntryPoint:       ExtractAction,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table trello_actions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type TrelloApiAction struct {
+	ID              string    `json:"id"`
+	IDMemberCreator string    `json:"idMemberCreator"`
+	Type            string    `json:"type"`
+	Date            time.Time `json:"date"`
+	Data            struct {
+		Card       *TrelloApiActionRef `json:"card"`
+		List       *TrelloApiActionRef `json:"list"`
+		ListBefore *TrelloApiActionRef `json:"listBefore"`
+		ListAfter  *TrelloApiActionRef `json:"listAfter"`
+		CheckItem  *struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			State string `json:"state"`
+		} `json:"checkItem"`
+	} `json:"data"`
+}
+
+type TrelloApiActionRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func ExtractAction(taskCtx plugin.SubTaskContext) errors.Error {
+	taskData := taskCtx.GetData().(*TrelloTaskData)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: taskData.Options.ConnectionId,
+				BoardId:      taskData.Options.BoardId,
+			},
+			Table: RAW_ACTION_TABLE,
+		},
+		Extract: func(resData *api.RawData) ([]interface{}, errors.Error) {
+			apiAction := &TrelloApiAction{}
+			err := errors.Convert(json.Unmarshal(resData.Data, apiAction))
+			if err != nil {
+				return nil, err
+			}
+			if apiAction.Data.Card == nil {
+				return nil, nil
+			}
+			action := &models.TrelloAction{
+				ID:              apiAction.ID,
+				Type:            apiAction.Type,
+				IDBoard:         taskData.Options.BoardId,
+				IDCard:          apiAction.Data.Card.ID,
+				IDMemberCreator: apiAction.IDMemberCreator,
+				Date:            apiAction.Date,
+			}
+			// a created card enters its first list, a moved card leaves one list for another
+			if apiAction.Data.List != nil {
+				action.IDListAfter = apiAction.Data.List.ID
+				action.ListAfterName = apiAction.Data.List.Name
+			}
+			if apiAction.Data.ListBefore != nil {
+				action.IDListBefore = apiAction.Data.ListBefore.ID
+				action.ListBeforeName = apiAction.Data.ListBefore.Name
+			}
+			if apiAction.Data.ListAfter != nil {
+				action.IDListAfter = apiAction.Data.ListAfter.ID
+				action.ListAfterName = apiAction.Data.ListAfter.Name
+			}
+			if apiAction.Data.CheckItem != nil {
+				action.IDCheckItem = apiAction.Data.CheckItem.ID
+				action.CheckItemState = apiAction.Data.CheckItem.State
+			}
+			return []interface{}{action}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
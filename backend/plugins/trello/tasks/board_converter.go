@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+	"reflect"
+)
+
+// boards are saved through the scope api, this table only scopes the converted rows
+const RAW_BOARD_TABLE = "trello_boards"
+
+var _ plugin.SubTaskEntryPoint = ConvertBoard
+
+var ConvertBoardMeta = plugin.SubTaskMeta{
+	Name:             "ConvertBoard",
+	EntryPoint:       ConvertBoard,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_boards into domain layer table boards",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertBoard(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := createRawDataSubTaskArgs(taskCtx, RAW_BOARD_TABLE)
+	db := taskCtx.GetDal()
+	clauses := []dal.Clause{
+		dal.From(&models.TrelloBoard{}),
+		dal.Where("connection_id = ? AND board_id = ?", data.Options.ConnectionId, data.Options.BoardId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.TrelloBoard{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			board := inputRow.(*models.TrelloBoard)
+			return []interface{}{
+				&ticket.Board{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: getBoardIdGen().Generate(board.ConnectionId, board.BoardId),
+					},
+					Name:        board.Name,
+					Url:         fmt.Sprintf("https://trello.com/b/%s", board.BoardId),
+					CreatedDate: getCreatedTime(board.BoardId),
+					Type:        "kanban",
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
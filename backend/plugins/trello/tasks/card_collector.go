@@ -34,7 +34,7 @@ var CollectCardMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectCard,
 	EnabledByDefault: true,
 	Description:      "Collect card data from Trello api",
-	DomainTypes:      []string{},
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
 func CollectCard(taskCtx plugin.SubTaskContext) errors.Error {
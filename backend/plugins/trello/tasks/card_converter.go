@@ -0,0 +1,206 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const CARD_ORIGINAL_TYPE = "Card"
+
+var _ plugin.SubTaskEntryPoint = ConvertCard
+
+var ConvertCardMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCard",
+	EntryPoint:       ConvertCard,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_cards into domain layer table issues, board_issues and issue_labels",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertCard(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := createRawDataSubTaskArgs(taskCtx, RAW_CARD_TABLE)
+	db := taskCtx.GetDal()
+	boardId := getBoardIdGen().Generate(data.Options.ConnectionId, data.Options.BoardId)
+
+	listNames, err := getListNames(db, data.Options.BoardId)
+	if err != nil {
+		return err
+	}
+	labelNames, err := getLabelNames(db, data.Options.BoardId)
+	if err != nil {
+		return err
+	}
+	memberNames, err := getMemberNames(db)
+	if err != nil {
+		return err
+	}
+	// the creator of each card, and the last time each card entered each list
+	creators := make(map[string]string)
+	enteredAt := make(map[string]time.Time)
+	var actions []models.TrelloAction
+	err = db.All(&actions,
+		dal.Where("id_board = ? AND type IN ?", data.Options.BoardId, []string{"createCard", "updateCard"}),
+		dal.Orderby("date ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	for _, action := range actions {
+		if action.Type == "createCard" {
+			creators[action.IDCard] = action.IDMemberCreator
+		}
+		if action.IDListAfter != "" {
+			enteredAt[action.IDCard+":"+action.IDListAfter] = action.Date
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloCard{}),
+		dal.Where("id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.TrelloCard{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			card := inputRow.(*models.TrelloCard)
+			updated := card.DateLastActivity
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: getCardIdGen().Generate(card.ID),
+				},
+				Url:            card.Url,
+				IssueKey:       strconv.Itoa(card.IDShort),
+				Title:          card.Name,
+				Description:    card.Desc,
+				Type:           ticket.TASK,
+				OriginalType:   CARD_ORIGINAL_TYPE,
+				OriginalStatus: listNames[card.IDList],
+				Status:         getStdStatus(data, listNames[card.IDList]),
+				CreatedDate:    getCreatedTime(card.ID),
+				UpdatedDate:    &updated,
+			}
+			if creator, ok := creators[card.ID]; ok && creator != "" {
+				issue.CreatorId = getMemberIdGen().Generate(creator)
+				issue.CreatorName = memberNames[creator]
+			}
+			if len(card.IDMembers) > 0 {
+				issue.AssigneeId = getMemberIdGen().Generate(card.IDMembers[0])
+				issue.AssigneeName = memberNames[card.IDMembers[0]]
+			}
+			if issue.Status == ticket.DONE {
+				// a card is resolved when it entered the list it is done in
+				if resolved, ok := enteredAt[card.ID+":"+card.IDList]; ok {
+					issue.ResolutionDate = &resolved
+				} else {
+					issue.ResolutionDate = &updated
+				}
+				if issue.CreatedDate != nil && issue.ResolutionDate.After(*issue.CreatedDate) {
+					issue.LeadTimeMinutes = int64(issue.ResolutionDate.Sub(*issue.CreatedDate).Minutes())
+				}
+			}
+
+			results := make([]interface{}, 0, 2+len(card.IDLabels))
+			for _, labelId := range card.IDLabels {
+				labelName := labelNames[labelId]
+				if labelName == "" {
+					continue
+				}
+				if issue.OriginalType == CARD_ORIGINAL_TYPE {
+					if stdType := getStdType(data, labelName); stdType != "" {
+						issue.Type = stdType
+						issue.OriginalType = labelName
+					}
+				}
+				results = append(results, &ticket.IssueLabel{
+					IssueId:   issue.Id,
+					LabelName: labelName,
+				})
+			}
+			results = append(results, issue, &ticket.BoardIssue{
+				BoardId: boardId,
+				IssueId: issue.Id,
+			})
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func getListNames(db dal.Dal, boardId string) (map[string]string, errors.Error) {
+	var lists []models.TrelloList
+	err := db.All(&lists, dal.Where("id_board = ?", boardId))
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(lists))
+	for _, list := range lists {
+		names[list.ID] = list.Name
+	}
+	return names, nil
+}
+
+// getLabelNames returns the label names of a board, unnamed labels are known by their color
+func getLabelNames(db dal.Dal, boardId string) (map[string]string, errors.Error) {
+	var labels []models.TrelloLabel
+	err := db.All(&labels, dal.Where("id_board = ?", boardId))
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(labels))
+	for _, label := range labels {
+		if label.Name != "" {
+			names[label.ID] = label.Name
+		} else {
+			names[label.ID] = label.Color
+		}
+	}
+	return names, nil
+}
+
+func getMemberNames(db dal.Dal) (map[string]string, errors.Error) {
+	var members []models.TrelloMember
+	err := db.All(&members)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(members))
+	for _, member := range members {
+		names[member.ID] = member.FullName
+	}
+	return names, nil
+}
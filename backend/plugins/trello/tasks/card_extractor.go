@@ -33,6 +33,7 @@ var ExtractCardMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractCard,
 	EnabledByDefault: true,
 	Description:      "Extract raw data into tool layer table trello_cards",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
 type TrelloApiCard struct {
@@ -44,7 +45,7 @@ type TrelloApiCard struct {
 	DateLastActivity      time.Time     `json:"dateLastActivity"`
 	Desc                  string        `json:"desc"`
 	DescData              interface{}   `json:"descData"`
-	Due                   interface{}   `json:"due"`
+	Due                   *time.Time    `json:"due"`
 	DueReminder           interface{}   `json:"dueReminder"`
 	Email                 interface{}   `json:"email"`
 	IDBoard               string        `json:"idBoard"`
@@ -91,11 +92,15 @@ func ExtractCard(taskCtx plugin.SubTaskContext) errors.Error {
 				&models.TrelloCard{
 					ID:               apiCard.ID,
 					Name:             apiCard.Name,
+					Desc:             apiCard.Desc,
 					Closed:           apiCard.Closed,
+					Due:              apiCard.Due,
 					DueComplete:      apiCard.DueComplete,
 					DateLastActivity: apiCard.DateLastActivity,
 					IDBoard:          apiCard.IDBoard,
 					IDList:           apiCard.IDList,
+					IDMembers:        apiCard.IDMembers,
+					IDLabels:         apiCard.IDLabels,
 					IDShort:          apiCard.IDShort,
 					Pos:              apiCard.Pos,
 					ShortLink:        apiCard.ShortLink,
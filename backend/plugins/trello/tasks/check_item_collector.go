@@ -34,7 +34,7 @@ var CollectCheckItemMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectCheckItem,
 	EnabledByDefault: true,
 	Description:      "Collect check item data from Trello api",
-	DomainTypes:      []string{},
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
 func CollectCheckItem(taskCtx plugin.SubTaskContext) errors.Error {
@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+	"reflect"
+	"time"
+)
+
+const CHECK_ITEM_ORIGINAL_TYPE = "CheckItem"
+
+var _ plugin.SubTaskEntryPoint = ConvertCheckItem
+
+var ConvertCheckItemMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCheckItem",
+	EntryPoint:       ConvertCheckItem,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_check_items into sub-task issues in domain layer table issues and board_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertCheckItem(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := createRawDataSubTaskArgs(taskCtx, RAW_CHECK_ITEM_TABLE)
+	db := taskCtx.GetDal()
+	boardId := getBoardIdGen().Generate(data.Options.ConnectionId, data.Options.BoardId)
+
+	// the last time each check item was marked as complete
+	completedAt := make(map[string]time.Time)
+	var actions []models.TrelloAction
+	err := db.All(&actions,
+		dal.Where("id_board = ? AND type = ?", data.Options.BoardId, "updateCheckItemStateOnCard"),
+		dal.Orderby("date ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	for _, action := range actions {
+		if action.CheckItemState == "complete" {
+			completedAt[action.IDCheckItem] = action.Date
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloCheckItem{}),
+		dal.Where("id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.TrelloCheckItem{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			checkItem := inputRow.(*models.TrelloCheckItem)
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: getCheckItemIdGen().Generate(checkItem.ID),
+				},
+				IssueKey:       checkItem.ID,
+				Title:          checkItem.Name,
+				Description:    checkItem.ChecklistName,
+				Type:           ticket.TASK,
+				OriginalType:   CHECK_ITEM_ORIGINAL_TYPE,
+				OriginalStatus: checkItem.State,
+				Status:         ticket.TODO,
+				ParentIssueId:  getCardIdGen().Generate(checkItem.IDCard),
+				CreatedDate:    getCreatedTime(checkItem.ID),
+			}
+			if checkItem.State == "complete" {
+				issue.Status = ticket.DONE
+				if completed, ok := completedAt[checkItem.ID]; ok {
+					issue.ResolutionDate = &completed
+					if issue.CreatedDate != nil && completed.After(*issue.CreatedDate) {
+						issue.LeadTimeMinutes = int64(completed.Sub(*issue.CreatedDate).Minutes())
+					}
+				}
+			}
+			return []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issue.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
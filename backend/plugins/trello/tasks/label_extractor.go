@@ -32,6 +32,7 @@ var ExtractLabelMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractLabel,
 	EnabledByDefault: true,
 	Description:      "Extract raw data into tool layer table trello_labels",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
 type TrelloApiLabel struct {
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var boardIdGen, cardIdGen, checkItemIdGen, actionIdGen, memberIdGen *didgen.DomainIdGenerator
+
+func getBoardIdGen() *didgen.DomainIdGenerator {
+	if boardIdGen == nil {
+		boardIdGen = didgen.NewDomainIdGenerator(&models.TrelloBoard{})
+	}
+	return boardIdGen
+}
+
+func getCardIdGen() *didgen.DomainIdGenerator {
+	if cardIdGen == nil {
+		cardIdGen = didgen.NewDomainIdGenerator(&models.TrelloCard{})
+	}
+	return cardIdGen
+}
+
+func getCheckItemIdGen() *didgen.DomainIdGenerator {
+	if checkItemIdGen == nil {
+		checkItemIdGen = didgen.NewDomainIdGenerator(&models.TrelloCheckItem{})
+	}
+	return checkItemIdGen
+}
+
+func getActionIdGen() *didgen.DomainIdGenerator {
+	if actionIdGen == nil {
+		actionIdGen = didgen.NewDomainIdGenerator(&models.TrelloAction{})
+	}
+	return actionIdGen
+}
+
+func getMemberIdGen() *didgen.DomainIdGenerator {
+	if memberIdGen == nil {
+		memberIdGen = didgen.NewDomainIdGenerator(&models.TrelloMember{})
+	}
+	return memberIdGen
+}
+
+func createRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, rawTable string) (*api.RawDataSubTaskArgs, *TrelloTaskData) {
+	data := taskCtx.GetData().(*TrelloTaskData)
+	return &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: TrelloApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			BoardId:      data.Options.BoardId,
+		},
+		Table: rawTable,
+	}, data
+}
+
+// getStdStatus returns the standard status mapped to the given list, lists without a mapping are OTHER
+func getStdStatus(data *TrelloTaskData, listName string) string {
+	if data.Options.TransformationRules != nil {
+		if mapping, ok := data.Options.TransformationRules.StatusMappings[listName]; ok && mapping.StandardStatus != "" {
+			return strings.ToUpper(mapping.StandardStatus)
+		}
+	}
+	return ticket.OTHER
+}
+
+// getStdType returns the standard type mapped to the given label, or an empty string when there is none
+func getStdType(data *TrelloTaskData, labelName string) string {
+	if data.Options.TransformationRules != nil {
+		if mapping, ok := data.Options.TransformationRules.TypeMappings[labelName]; ok && mapping.StandardType != "" {
+			return strings.ToUpper(mapping.StandardType)
+		}
+	}
+	return ""
+}
+
+// getCreatedTime decodes the creation time embedded in the first 4 bytes of a Trello object id
+func getCreatedTime(id string) *time.Time {
+	if len(id) < 8 {
+		return nil
+	}
+	b, err := hex.DecodeString(id[:8])
+	if err != nil {
+		return nil
+	}
+	created := time.Unix(int64(b[0])<<24|int64(b[1])<<16|int64(b[2])<<8|int64(b[3]), 0).UTC()
+	return &created
+}
@@ -18,12 +18,59 @@ limitations under the License.
 package tasks
 
 import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
 )
 
+type StatusMapping struct {
+	StandardStatus string `json:"standardStatus"`
+}
+
+// StatusMappings maps a list name to a standard status
+type StatusMappings map[string]StatusMapping
+
+type TypeMapping struct {
+	StandardType string `json:"standardType"`
+}
+
+// TypeMappings maps a label name to a standard issue type
+type TypeMappings map[string]TypeMapping
+
+type TrelloTransformationRule struct {
+	Name           string         `gorm:"type:varchar(255)" validate:"required"`
+	StatusMappings StatusMappings `json:"statusMappings"`
+	TypeMappings   TypeMappings   `json:"typeMappings"`
+}
+
+func MakeTransformationRules(rule models.TrelloTransformationRule) (*TrelloTransformationRule, errors.Error) {
+	var statusMappings StatusMappings
+	if len(rule.StatusMappings) > 0 {
+		err := json.Unmarshal(rule.StatusMappings, &statusMappings)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "unable to unmarshal the statusMappings")
+		}
+	}
+	var typeMappings TypeMappings
+	if len(rule.TypeMappings) > 0 {
+		err := json.Unmarshal(rule.TypeMappings, &typeMappings)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "unable to unmarshal the typeMappings")
+		}
+	}
+	return &TrelloTransformationRule{
+		Name:           rule.Name,
+		StatusMappings: statusMappings,
+		TypeMappings:   typeMappings,
+	}, nil
+}
+
 type TrelloOptions struct {
-	ConnectionId         uint64 `json:"connectionId"`
-	BoardId              string `json:"boardId"`
+	ConnectionId         uint64                    `json:"connectionId"`
+	BoardId              string                    `json:"boardId"`
+	TransformationRules  *TrelloTransformationRule `json:"transformationRules"`
 	ScopeId              string
 	TransformationRuleId uint64
 }
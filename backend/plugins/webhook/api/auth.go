@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+const (
+	apiKeyPrefix    = "whk_"
+	signatureHeader = "X-Signature"
+	signaturePrefix = "sha256="
+	// allowAnonymousLegacyEnv lets the connections created before api keys were introduced accept anonymous
+	// requests, it is off by default and only meant to give time to rotate their api keys
+	allowAnonymousLegacyEnv = "WEBHOOK_ALLOW_ANONYMOUS_LEGACY_CONNECTIONS"
+)
+
+func hashApiKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateApiKey sets a new api key to the connection and returns it in plain
+func generateApiKey(connection *models.WebhookConnection) (string, errors.Error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "error generating api key")
+	}
+	apiKey := apiKeyPrefix + hex.EncodeToString(buf)
+	connection.ApiKeyHash = hashApiKey(apiKey)
+	return apiKey, nil
+}
+
+// sign returns the signature of the body in the format of X-Signature header
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func matchApiKeyHash(apiKey string, hash string) bool {
+	return hash != "" && subtle.ConstantTimeCompare([]byte(hashApiKey(apiKey)), []byte(hash)) == 1
+}
+
+// verifyRequest checks the bearer token against the api keys of the connection, or the X-Signature header
+// against the signing secret, anonymous requests are accepted only for legacy connections if allowAnonymousLegacy
+func verifyRequest(connection *models.WebhookConnection, input *plugin.ApiResourceInput, now time.Time, allowAnonymousLegacy bool) errors.Error {
+	if authorization := input.Header.Get("Authorization"); authorization != "" {
+		scheme, apiKey, found := strings.Cut(authorization, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			return errors.Unauthorized.New("bearer token is expected in the Authorization header")
+		}
+		apiKey = strings.TrimSpace(apiKey)
+		if matchApiKeyHash(apiKey, connection.ApiKeyHash) {
+			return nil
+		}
+		if connection.PreviousApiKeyExpiredAt != nil && now.Before(*connection.PreviousApiKeyExpiredAt) &&
+			matchApiKeyHash(apiKey, connection.PreviousApiKeyHash) {
+			return nil
+		}
+		return errors.Unauthorized.New("invalid api key")
+	}
+	if signature := input.Header.Get(signatureHeader); signature != "" {
+		if connection.SigningSecret == "" {
+			return errors.Unauthorized.New("signing secret of the connection is not set")
+		}
+		if !hmac.Equal([]byte(sign(connection.SigningSecret, input.RawBody)), []byte(strings.ToLower(signature))) {
+			return errors.Unauthorized.New("invalid signature")
+		}
+		return nil
+	}
+	if allowAnonymousLegacy && isLegacyConnection(connection) {
+		return nil
+	}
+	return errors.Unauthorized.New("api key or signature is required")
+}
+
+// isLegacyConnection returns true if the connection has neither an api key nor a signing secret
+func isLegacyConnection(connection *models.WebhookConnection) bool {
+	return connection.ApiKeyHash == "" && connection.PreviousApiKeyHash == "" && connection.SigningSecret == ""
+}
+
+// authenticateConnection loads the connection of the request and verifies the request, the last used time of
+// the api key is updated on success
+func authenticateConnection(input *plugin.ApiResourceInput) (*models.WebhookConnection, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	allowAnonymousLegacy := basicRes.GetConfigReader().GetBool(allowAnonymousLegacyEnv)
+	err = verifyRequest(connection, input, now, allowAnonymousLegacy)
+	if err != nil {
+		return nil, err
+	}
+	if allowAnonymousLegacy && isLegacyConnection(connection) {
+		basicRes.GetLogger().Warn(nil, "webhook connection #%d accepts anonymous requests because %s is enabled, which is deprecated, please rotate its api key", connection.ID, allowAnonymousLegacyEnv)
+	}
+	err = basicRes.GetDal().UpdateColumn(
+		&models.WebhookConnection{}, "api_key_last_used_at", now,
+		dal.Where("id = ?", connection.ID),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error updating api_key_last_used_at of the connection")
+	}
+	return connection, nil
+}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWebhookInput(header map[string]string, body string) *plugin.ApiResourceInput {
+	input := &plugin.ApiResourceInput{Header: http.Header{}, RawBody: []byte(body)}
+	for k, v := range header {
+		input.Header.Set(k, v)
+	}
+	return input
+}
+
+func TestVerifyRequestApiKey(t *testing.T) {
+	now := time.Now()
+	connection := &models.WebhookConnection{}
+	apiKey, err := generateApiKey(connection)
+	assert.Nil(t, err)
+	assert.NotEqual(t, apiKey, connection.ApiKeyHash)
+
+	assert.Nil(t, verifyRequest(connection, newWebhookInput(map[string]string{"Authorization": "Bearer " + apiKey}, ""), now, false))
+
+	err = verifyRequest(connection, newWebhookInput(map[string]string{"Authorization": "Bearer whk_forged"}, ""), now, false)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+
+	err = verifyRequest(connection, newWebhookInput(nil, ""), now, false)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
+
+func TestVerifyRequestPreviousApiKey(t *testing.T) {
+	now := time.Now()
+	connection := &models.WebhookConnection{}
+	previousApiKey, _ := generateApiKey(connection)
+	expiredAt := now.Add(time.Minute)
+	connection.PreviousApiKeyHash = connection.ApiKeyHash
+	connection.PreviousApiKeyExpiredAt = &expiredAt
+	_, _ = generateApiKey(connection)
+
+	input := newWebhookInput(map[string]string{"Authorization": "Bearer " + previousApiKey}, "")
+	assert.Nil(t, verifyRequest(connection, input, now, false))
+	err := verifyRequest(connection, input, now.Add(2*time.Minute), false)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
+
+func TestVerifyRequestSignature(t *testing.T) {
+	now := time.Now()
+	body := `{"pipeline_name":"A123"}`
+	connection := &models.WebhookConnection{SigningSecret: "secret"}
+
+	input := newWebhookInput(map[string]string{signatureHeader: sign("secret", []byte(body))}, body)
+	assert.Nil(t, verifyRequest(connection, input, now, false))
+
+	input = newWebhookInput(map[string]string{signatureHeader: sign("forged", []byte(body))}, body)
+	assert.Equal(t, errors.Unauthorized, verifyRequest(connection, input, now, false).GetType())
+
+	input = newWebhookInput(map[string]string{signatureHeader: sign("secret", []byte(body))}, `{"pipeline_name":"B"}`)
+	assert.Equal(t, errors.Unauthorized, verifyRequest(connection, input, now, false).GetType())
+}
+
+func TestVerifyRequestLegacyConnection(t *testing.T) {
+	now := time.Now()
+	connection := &models.WebhookConnection{}
+	// anonymous requests are rejected unless explicitly allowed
+	err := verifyRequest(connection, newWebhookInput(nil, ""), now, false)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	assert.Nil(t, verifyRequest(connection, newWebhookInput(nil, ""), now, true))
+
+	err = verifyRequest(connection, newWebhookInput(map[string]string{"Authorization": "Bearer whk_forged"}, ""), now, true)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+
+	// anonymous requests are rejected once an api key was generated
+	_, _ = generateApiKey(connection)
+	err = verifyRequest(connection, newWebhookInput(nil, ""), now, true)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
@@ -28,7 +28,6 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
-	"github.com/apache/incubator-devlake/plugins/webhook/models"
 	"net/http"
 	"reflect"
 	"time"
@@ -65,12 +64,12 @@ type WebhookTaskRequest struct {
 // @Param body body WebhookTaskRequest true "json body"
 // @Success 200
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
 // @Failure 403  {string} errcode.Error "Forbidden"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/cicd_tasks [POST]
 func PostCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	connection := &models.WebhookConnection{}
-	err := connectionHelper.First(connection, input.Params)
+	connection, err := authenticateConnection(input)
 	if err != nil {
 		return nil, err
 	}
@@ -154,11 +153,11 @@ func PostCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 // @Tags plugins/webhook
 // @Success 200
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/cicd_pipeline/:pipelineName/finish [POST]
 func PostPipelineFinish(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	connection := &models.WebhookConnection{}
-	err := connectionHelper.First(connection, input.Params)
+	connection, err := authenticateConnection(input)
 	if err != nil {
 		return nil, err
 	}
@@ -276,12 +275,12 @@ type WebhookDeployTaskRequest struct {
 // @Param body body WebhookDeployTaskRequest true "json body"
 // @Success 200
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
 // @Failure 403  {string} errcode.Error "Forbidden"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/deployments [POST]
 func PostDeploymentCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	connection := &models.WebhookConnection{}
-	err := connectionHelper.First(connection, input.Params)
+	connection, err := authenticateConnection(input)
 	if err != nil {
 		return nil, err
 	}
@@ -20,29 +20,84 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/webhook/models"
 )
 
 // PostConnections
 // @Summary create webhook connection
-// @Description Create webhook connection, example: {"name":"Webhook data connection name"}
+// @Description Create webhook connection, example: {"name":"Webhook data connection name","signingSecret":"optional secret"}
+// @Description The generated apiKey is only returned in this response, send it as a bearer token to the endpoints of the connection.
 // @Tags plugins/webhook
 // @Param body body models.WebhookConnection true "json body"
-// @Success 200  {object} models.WebhookConnection
+// @Success 200  {object} WebhookConnectionResponse
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/connections [POST]
 func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	// update from request and save to database
 	connection := &models.WebhookConnection{}
-	err := connectionHelper.Create(connection, input)
+	apiKey, err := generateApiKey(connection)
+	if err != nil {
+		return nil, err
+	}
+	err = connectionHelper.Create(connection, input)
 	if err != nil {
 		return nil, err
 	}
-	return &plugin.ApiResourceOutput{Body: connection, Status: http.StatusOK}, nil
+	response := formatConnection(connection)
+	response.ApiKey = apiKey
+	return &plugin.ApiResourceOutput{Body: response, Status: http.StatusOK}, nil
+}
+
+type RotateApiKeyRequest struct {
+	// GracePeriodSeconds keeps the previous api key valid for a while, so runners could be updated without downtime
+	GracePeriodSeconds int `mapstructure:"gracePeriodSeconds" json:"gracePeriodSeconds" validate:"min=0"`
+}
+
+// RotateApiKey
+// @Summary rotate the api key of webhook connection
+// @Description Generate a new api key for the connection, the previous one stays valid for gracePeriodSeconds.
+// @Description The new apiKey is only returned in this response.
+// @Tags plugins/webhook
+// @Param body body RotateApiKeyRequest false "json body"
+// @Success 200  {object} WebhookConnectionResponse
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/{connectionId}/rotate-api-key [POST]
+func RotateApiKey(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	request := &RotateApiKeyRequest{}
+	err := api.Decode(input.Body, request, vld)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid rotation request")
+	}
+	connection := &models.WebhookConnection{}
+	err = connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	connection.PreviousApiKeyHash = ""
+	connection.PreviousApiKeyExpiredAt = nil
+	if request.GracePeriodSeconds > 0 && connection.ApiKeyHash != "" {
+		expiredAt := time.Now().Add(time.Duration(request.GracePeriodSeconds) * time.Second)
+		connection.PreviousApiKeyHash = connection.ApiKeyHash
+		connection.PreviousApiKeyExpiredAt = &expiredAt
+	}
+	apiKey, err := generateApiKey(connection)
+	if err != nil {
+		return nil, err
+	}
+	err = basicRes.GetDal().Update(connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error saving the rotated api key")
+	}
+	response := formatConnection(connection)
+	response.ApiKey = apiKey
+	return &plugin.ApiResourceOutput{Body: response, Status: http.StatusOK}, nil
 }
 
 // PatchConnection
@@ -50,24 +105,28 @@ func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 // @Description Patch webhook connection
 // @Tags plugins/webhook
 // @Param body body models.WebhookConnection true "json body"
-// @Success 200  {object} models.WebhookConnection
+// @Success 200  {object} WebhookConnectionResponse
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/connections/{connectionId} [PATCH]
 func PatchConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	// the masked secret echoed back by the client means unchanged
+	if input.Body["signingSecret"] == signingSecretMask {
+		delete(input.Body, "signingSecret")
+	}
 	connection := &models.WebhookConnection{}
 	err := connectionHelper.Patch(connection, input)
 	if err != nil {
 		return nil, err
 	}
-	return &plugin.ApiResourceOutput{Body: connection}, nil
+	return &plugin.ApiResourceOutput{Body: formatConnection(connection)}, nil
 }
 
 // DeleteConnection
 // @Summary delete a webhook connection
 // @Description Delete a webhook connection
 // @Tags plugins/webhook
-// @Success 200  {object} models.WebhookConnection
+// @Success 200  {object} WebhookConnectionResponse
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/connections/{connectionId} [DELETE]
@@ -78,11 +137,15 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 		return nil, err
 	}
 	err = connectionHelper.Delete(connection)
-	return &plugin.ApiResourceOutput{Body: connection}, err
+	return &plugin.ApiResourceOutput{Body: formatConnection(connection)}, err
 }
 
+const signingSecretMask = "********"
+
 type WebhookConnectionResponse struct {
 	models.WebhookConnection
+	// ApiKey is only returned when it was generated
+	ApiKey                         string `json:"apiKey,omitempty"`
 	PostIssuesEndpoint             string `json:"postIssuesEndpoint"`
 	CloseIssuesEndpoint            string `json:"closeIssuesEndpoint"`
 	PostPipelineTaskEndpoint       string `json:"postPipelineTaskEndpoint"`
@@ -128,6 +191,10 @@ func GetConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, e
 
 func formatConnection(connection *models.WebhookConnection) *WebhookConnectionResponse {
 	response := &WebhookConnectionResponse{WebhookConnection: *connection}
+	// hide the secret, the client could only tell whether it was set
+	if response.SigningSecret != "" {
+		response.SigningSecret = signingSecretMask
+	}
 	response.PostIssuesEndpoint = fmt.Sprintf(`/plugins/webhook/%d/issues`, connection.ID)
 	response.CloseIssuesEndpoint = fmt.Sprintf(`/plugins/webhook/%d/issue/:issueKey/close`, connection.ID)
 	response.PostPipelineTaskEndpoint = fmt.Sprintf(`/plugins/webhook/%d/cicd_tasks`, connection.ID)
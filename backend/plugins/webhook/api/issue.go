@@ -28,7 +28,6 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
-	"github.com/apache/incubator-devlake/plugins/webhook/models"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -60,20 +59,28 @@ type WebhookIssueRequest struct {
 	Component               string     `mapstructure:"component"`
 	//IconURL               string
 	//DeploymentId          string
+	// Relationships replaces every relationship sourced from this issue when present
+	Relationships []WebhookIssueRelationship `mapstructure:"relationships" validate:"dive"`
+}
+
+type WebhookIssueRelationship struct {
+	TargetIssueKey string `mapstructure:"target_issue_key" validate:"required"`
+	Type           string `mapstructure:"type" validate:"oneof=BLOCKS DUPLICATES CLONES RELATES OTHER"`
+	OriginalType   string `mapstructure:"original_type"`
 }
 
 // PostIssue
 // @Summary receive a record as defined and save it
-// @Description receive a record as follow and save it, example: {"url":"","issue_key":"DLK-1234","title":"a feature from DLK","description":"","epic_key":"","type":"BUG","status":"TODO","original_status":"created","story_point":0,"resolution_date":null,"created_date":"2020-01-01T12:00:00+00:00","updated_date":null,"lead_time_minutes":0,"parent_issue_key":"DLK-1200","priority":"","original_estimate_minutes":0,"time_spent_minutes":0,"time_remaining_minutes":0,"creator_id":"user1131","creator_name":"Nick name 1","assignee_id":"user1132","assignee_name":"Nick name 2","severity":"","component":""}
+// @Description receive a record as follow and save it, example: {"url":"","issue_key":"DLK-1234","title":"a feature from DLK","description":"","epic_key":"","type":"BUG","status":"TODO","original_status":"created","story_point":0,"resolution_date":null,"created_date":"2020-01-01T12:00:00+00:00","updated_date":null,"lead_time_minutes":0,"parent_issue_key":"DLK-1200","priority":"","original_estimate_minutes":0,"time_spent_minutes":0,"time_remaining_minutes":0,"creator_id":"user1131","creator_name":"Nick name 1","assignee_id":"user1132","assignee_name":"Nick name 2","severity":"","component":"","relationships":[{"target_issue_key":"DLK-1235","type":"BLOCKS","original_type":"blocks"}]}
 // @Tags plugins/webhook
 // @Param body body WebhookIssueRequest true "json body"
 // @Success 200  {string} noResponse ""
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/issues [POST]
 func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	connection := &models.WebhookConnection{}
-	err := connectionHelper.First(connection, input.Params)
+	connection, err := authenticateConnection(input)
 	if err != nil {
 		return nil, err
 	}
@@ -164,20 +171,46 @@ func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, error
 		return nil, err
 	}
 
+	if request.Relationships != nil {
+		err = saveIssueRelationships(db, connection.ID, domainIssue.Id, request.Relationships)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
 }
 
+func saveIssueRelationships(db dal.Dal, connectionId uint64, sourceIssueId string, relationships []WebhookIssueRelationship) errors.Error {
+	err := db.Delete(&ticket.IssueRelationship{}, dal.Where("source_issue_id = ?", sourceIssueId))
+	if err != nil {
+		return err
+	}
+	for _, relationship := range relationships {
+		err = db.CreateOrUpdate(&ticket.IssueRelationship{
+			SourceIssueId: sourceIssueId,
+			TargetIssueId: fmt.Sprintf("%s:%d:%s", "webhook", connectionId, relationship.TargetIssueKey),
+			Type:          relationship.Type,
+			OriginalType:  relationship.OriginalType,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CloseIssue
 // @Summary set issue's status to DONE
 // @Description set issue's status to DONE
 // @Tags plugins/webhook
 // @Success 200  {string} noResponse ""
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/issue/:issueKey/close [POST]
 func CloseIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	connection := &models.WebhookConnection{}
-	err := connectionHelper.First(connection, input.Params)
+	connection, err := authenticateConnection(input)
 	if err != nil {
 		return nil, err
 	}
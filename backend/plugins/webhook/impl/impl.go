@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/plugins/webhook/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
 	"github.com/apache/incubator-devlake/plugins/webhook/models/migrationscripts"
 )
 
@@ -45,7 +46,9 @@ func (p Webhook) Init(basicRes context.BasicRes) errors.Error {
 }
 
 func (p Webhook) GetTablesInfo() []dal.Tabler {
-	return []dal.Tabler{}
+	return []dal.Tabler{
+		&models.WebhookConnection{},
+	}
 }
 
 func (p Webhook) MakeDataSourcePipelinePlanV200(connectionId uint64, _ []*plugin.BlueprintScopeV200, _ plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
@@ -72,6 +75,9 @@ func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 			"PATCH":  api.PatchConnection,
 			"DELETE": api.DeleteConnection,
 		},
+		"connections/:connectionId/rotate-api-key": {
+			"POST": api.RotateApiKey,
+		},
 		":connectionId/cicd_tasks": {
 			"POST": api.PostCicdTask,
 		},
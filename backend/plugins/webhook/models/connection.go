@@ -18,11 +18,23 @@ limitations under the License.
 package models
 
 import (
+	"time"
+
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
+// WebhookConnection receives data pushed by CI runners and issue trackers. Requests to its endpoints must carry
+// the api key of the connection as a bearer token, or an X-Signature header signed by the SigningSecret.
 type WebhookConnection struct {
 	helper.BaseConnection `mapstructure:",squash"`
+	// SigningSecret is used to verify the `X-Signature: sha256=<hex of hmac-sha256 of body>` header, optional
+	SigningSecret string `mapstructure:"signingSecret" json:"signingSecret" gorm:"serializer:encdec"`
+	// ApiKeyHash is the sha256 of the api key, the api key itself is only returned on generation
+	ApiKeyHash string `mapstructure:"-" json:"-" gorm:"type:varchar(64)"`
+	// PreviousApiKeyHash stays valid until PreviousApiKeyExpiredAt after the api key was rotated
+	PreviousApiKeyHash      string     `mapstructure:"-" json:"-" gorm:"type:varchar(64)"`
+	PreviousApiKeyExpiredAt *time.Time `mapstructure:"-" json:"previousApiKeyExpiredAt"`
+	ApiKeyLastUsedAt        *time.Time `mapstructure:"-" json:"apiKeyLastUsedAt"`
 }
 
 func (WebhookConnection) TableName() string {
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type webhookConnection20230306 struct {
+	SigningSecret           string
+	ApiKeyHash              string `gorm:"type:varchar(64)"`
+	PreviousApiKeyHash      string `gorm:"type:varchar(64)"`
+	PreviousApiKeyExpiredAt *time.Time
+	ApiKeyLastUsedAt        *time.Time
+}
+
+func (webhookConnection20230306) TableName() string {
+	return "_tool_webhook_connections"
+}
+
+type addApiKeyToConnections struct{}
+
+// Up adds the columns only, connections created before have no api key and reject all requests until
+// the api key is rotated, unless WEBHOOK_ALLOW_ANONYMOUS_LEGACY_CONNECTIONS is enabled
+func (*addApiKeyToConnections) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &webhookConnection20230306{})
+}
+
+// Down drops the columns, webhooks would accept unauthenticated requests again
+func (*addApiKeyToConnections) Down(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().DropColumns(
+		webhookConnection20230306{}.TableName(),
+		"signing_secret", "api_key_hash", "previous_api_key_hash", "previous_api_key_expired_at", "api_key_last_used_at",
+	)
+}
+
+func (*addApiKeyToConnections) Version() uint64 {
+	return 20230306110000
+}
+
+func (*addApiKeyToConnections) Name() string {
+	return "add api key and signing secret to _tool_webhook_connections"
+}
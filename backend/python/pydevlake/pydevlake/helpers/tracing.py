@@ -0,0 +1,49 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+import os
+from contextlib import contextmanager
+
+from pydevlake import logger
+
+
+@contextmanager
+def trace_context():
+    """
+    Makes the trace context passed by DevLake in the TRACEPARENT and TRACESTATE
+    environment variables the current OpenTelemetry context, so that the spans
+    created by the plugin become children of the subtask span.
+    """
+    carrier = {}
+    for key in ("traceparent", "tracestate"):
+        value = os.getenv(key.upper())
+        if value:
+            carrier[key] = value
+    if not carrier:
+        yield
+        return
+    try:
+        from opentelemetry import context
+        from opentelemetry.trace.propagation.tracecontext import TraceContextTextMapPropagator
+    except ImportError:
+        logger.debug("OpenTelemetry is not installed, ignoring the trace context")
+        yield
+        return
+    token = context.attach(TraceContextTextMapPropagator().extract(carrier))
+    try:
+        yield
+    finally:
+        context.detach(token)
@@ -20,6 +20,7 @@ from functools import wraps
 from typing import Generator, TextIO, Optional, Union
 
 from pydevlake.context import Context
+from pydevlake.helpers.tracing import trace_context
 from pydevlake.message import Message
 
 
@@ -41,14 +42,15 @@ def plugin_method(func):
 
     @wraps(func)
     def wrapper(self, *args):
-        ret = func(self, *args)
-        if ret is not None:
-            with open_send_channel() as send_ch:
-                if isinstance(ret, Generator):
-                    for each in ret:
-                        send_output(send_ch, each)
-                else:
-                    send_output(send_ch, ret)
+        with trace_context():
+            ret = func(self, *args)
+            if ret is not None:
+                with open_send_channel() as send_ch:
+                    if isinstance(ret, Generator):
+                        for each in ret:
+                            send_output(send_ch, each)
+                    else:
+                        send_output(send_ch, ret)
         return None
 
     return wrapper
@@ -26,8 +26,10 @@ import (
 
 	"github.com/apache/incubator-devlake/core/config"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/metrics"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	_ "github.com/apache/incubator-devlake/server/api/docs"
+	"github.com/apache/incubator-devlake/server/api/migration"
 	"github.com/apache/incubator-devlake/server/api/remote"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/services"
@@ -43,6 +45,7 @@ import (
 const DB_MIGRATION_REQUIRED = `
 New migration scripts detected. Database migration is required to launch DevLake.
 WARNING: Performing migration may wipe collected data for consistency and re-collecting data may be required.
+To review what the pending scripts would do, please send a request to <devlake-endpoint>/migrations/plan.
 To proceed, please send a request to <config-ui-endpoint>/api/proceed-db-migration (or <devlake-endpoint>/proceed-db-migration).
 Alternatively, you may downgrade back to the previous DevLake version.
 `
@@ -58,6 +61,10 @@ func CreateApiService() {
 	v := config.GetConfig()
 	gin.SetMode(v.GetString("MODE"))
 	router := gin.Default()
+	// authenticate every route registered below, including the dynamic ones of remote plugins
+	if services.ApiAuthEnabled() {
+		router.Use(authorize(services.AuthenticateApiKey, projectOfRoute))
+	}
 	remotePluginsEnabled := v.GetBool("ENABLE_REMOTE_PLUGINS")
 	if remotePluginsEnabled {
 		router.POST("/plugins/register", remote.RegisterPlugin(router, registerPluginEndpoints))
@@ -75,6 +82,11 @@ func CreateApiService() {
 		}
 		shared.ApiOutputSuccess(ctx, nil, http.StatusOK)
 	})
+	// migration plan and rollback, available before the db migration is confirmed so it could be reviewed or reverted
+	router.GET("/migrations/plan", migration.GetPlan)
+	router.POST("/migrations/rollback", migration.PostRollback)
+	// Prometheus metrics, available even before the db migration is confirmed
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 	router.Use(func(ctx *gin.Context) {
 		if !services.MigrationRequireConfirmation() {
 			return
@@ -97,7 +109,7 @@ func CreateApiService() {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"PUT", "PATCH", "POST", "GET", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-API-Key"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 		MaxAge:           120 * time.Hour,
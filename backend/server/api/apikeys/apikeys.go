@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikeys
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedApiKeys struct {
+	ApiKeys []*models.ApiKey `json:"apiKeys"`
+	Count   int64            `json:"count"`
+}
+
+// @Summary create an api key
+// @Description create an api key, role could be viewer, operator or admin
+// @Description a key bound to projects may only access those projects with their blueprints and pipelines
+// @Description the plain token is only returned in the response of the creation
+// @Tags framework/api-keys
+// @Accept application/json
+// @Param apiKey body models.ApiKey true "json"
+// @Success 201  {object} models.ApiKey
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-keys [post]
+func Post(c *gin.Context) {
+	key := &models.ApiKey{}
+	err := c.ShouldBind(key)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.CreateApiKey(key)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating api key"))
+		return
+	}
+	shared.ApiOutputSuccess(c, key, http.StatusCreated)
+}
+
+// @Summary get api keys
+// @Description get api keys, tokens are not included
+// @Tags framework/api-keys
+// @Param page query int false "page"
+// @Param pageSize query int false "pageSize"
+// @Success 200  {object} PaginatedApiKeys
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-keys [get]
+func Index(c *gin.Context) {
+	var query services.ApiKeyQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	keys, count, err := services.GetApiKeys(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting api keys"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedApiKeys{ApiKeys: keys, Count: count}, http.StatusOK)
+}
+
+// @Summary revoke an api key
+// @Description revoke an api key
+// @Tags framework/api-keys
+// @Param apiKeyId path int true "api key id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-keys/{apiKeyId} [delete]
+func Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("apiKeyId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad apiKeyId format supplied"))
+		return
+	}
+	err = services.DeleteApiKey(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting api key"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
@@ -0,0 +1,205 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const roleNone = ""
+
+var roleLevels = map[string]int{
+	models.API_KEY_ROLE_VIEWER:   1,
+	models.API_KEY_ROLE_OPERATOR: 2,
+	models.API_KEY_ROLE_ADMIN:    3,
+}
+
+// publicRoutes are accessible without api key
+var publicRoutes = map[string]bool{
+	"GET /ping":          true,
+	"GET /version":       true,
+	"GET /metrics":       true,
+	"GET /swagger/*any":  true,
+	"HEAD /ping":         true,
+	"HEAD /swagger/*any": true,
+}
+
+// selfAuthenticatedRoutePrefixes verify requests by themselves, the webhook plugin checks the api key or the
+// signature of its connections, so CI runners don't need api keys of lake
+var selfAuthenticatedRoutePrefixes = []string{
+	"/plugins/webhook/:connectionId/",
+}
+
+// operatorRoutes are the writing routes an operator is allowed to call, all other writing routes require admin
+var operatorRoutes = map[string]bool{
+	"POST /pipelines":                       true,
+	"DELETE /pipelines/:pipelineId":         true,
+	"POST /pipelines/:pipelineId/rerun":     true,
+	"POST /pipelines/:pipelineId/pause":     true,
+	"POST /pipelines/:pipelineId/resume":    true,
+	"POST /blueprints/:blueprintId/trigger": true,
+	"POST /tasks/:taskId/rerun":             true,
+}
+
+// projectFreeRoutes are the routes a key bound to projects may call besides the ones targeting its projects,
+// lists of projects, blueprints and pipelines are filtered by the handlers
+var projectFreeRoutes = map[string]bool{
+	"GET /projects":   true,
+	"GET /blueprints": true,
+	"GET /pipelines":  true,
+	"GET /plugininfo": true,
+	"GET /plugins":    true,
+}
+
+// requiredRole returns the minimal role to call the route, roleNone for public routes
+func requiredRole(method, path string) string {
+	route := method + " " + path
+	if publicRoutes[route] {
+		return roleNone
+	}
+	for _, prefix := range selfAuthenticatedRoutePrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return roleNone
+		}
+	}
+	if operatorRoutes[route] {
+		return models.API_KEY_ROLE_OPERATOR
+	}
+	if method != http.MethodGet && method != http.MethodHead {
+		return models.API_KEY_ROLE_ADMIN
+	}
+	// connections and notification channels carry credentials
+	if strings.Contains(path, "/connections") ||
+		strings.HasPrefix(path, "/notification-channels") ||
+		strings.HasPrefix(path, "/api-keys") ||
+		strings.HasPrefix(path, "/encryption") ||
+		strings.HasPrefix(path, "/migrations") ||
+		path == "/proceed-db-migration" {
+		return models.API_KEY_ROLE_ADMIN
+	}
+	return models.API_KEY_ROLE_VIEWER
+}
+
+// extractApiKey reads the token from `Authorization: Bearer <token>` or `X-API-Key: <token>`
+func extractApiKey(c *gin.Context) string {
+	if authorization := c.GetHeader("Authorization"); authorization != "" {
+		scheme, token, found := strings.Cut(authorization, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	return c.GetHeader("X-API-Key")
+}
+
+// projectOfRoute returns the project targeted by the request, the second value is false if the route doesn't
+// target any project, blueprint, pipeline or task
+func projectOfRoute(c *gin.Context) (string, bool, errors.Error) {
+	if projectName := c.Param("projectName"); projectName != "" {
+		return strings.TrimPrefix(projectName, "/"), true, nil
+	}
+	resolvers := []struct {
+		param   string
+		resolve func(uint64) (string, errors.Error)
+	}{
+		{"blueprintId", services.GetProjectNameOfBlueprint},
+		{"pipelineId", services.GetProjectNameOfPipeline},
+		{"taskId", services.GetProjectNameOfTask},
+	}
+	for _, r := range resolvers {
+		value := c.Param(r.param)
+		if value == "" {
+			continue
+		}
+		id, parseErr := strconv.ParseUint(value, 10, 64)
+		if parseErr != nil {
+			return "", true, errors.BadInput.Wrap(parseErr, fmt.Sprintf("invalid %s", r.param))
+		}
+		projectName, err := r.resolve(id)
+		return projectName, true, err
+	}
+	return "", false, nil
+}
+
+type apiAuthenticator func(token string) (*models.ApiKey, errors.Error)
+type routeProjectResolver func(c *gin.Context) (string, bool, errors.Error)
+
+// authorize authenticates the request by api key and checks the role and projects of the key
+func authorize(authenticate apiAuthenticator, resolveProject routeProjectResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions {
+			return
+		}
+		path := c.FullPath()
+		if path == "" {
+			// unknown routes would be answered with 404 by gin
+			return
+		}
+		role := requiredRole(c.Request.Method, path)
+		if role == roleNone {
+			return
+		}
+		key, err := authenticate(extractApiKey(c))
+		if err != nil {
+			shared.ApiOutputError(c, err)
+			c.Abort()
+			return
+		}
+		if roleLevels[key.Role] < roleLevels[role] {
+			shared.ApiOutputError(c, errors.Forbidden.New(fmt.Sprintf("role %s is required", role)))
+			c.Abort()
+			return
+		}
+		if len(key.Projects) > 0 && !projectFreeRoutes[c.Request.Method+" "+path] {
+			projectName, ok, err := resolveProject(c)
+			if err != nil {
+				shared.ApiOutputError(c, err)
+				c.Abort()
+				return
+			}
+			if !ok || !containsProject(key.Projects, projectName) {
+				shared.ApiOutputError(c, errors.Forbidden.New("the api key is not allowed to access the resource"))
+				c.Abort()
+				return
+			}
+		}
+		shared.SetApiKey(c, key)
+	}
+}
+
+func containsProject(projects []string, projectName string) bool {
+	if projectName == "" {
+		return false
+	}
+	for _, p := range projects {
+		if p == projectName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredRole(t *testing.T) {
+	assert.Equal(t, roleNone, requiredRole(http.MethodGet, "/ping"))
+	assert.Equal(t, roleNone, requiredRole(http.MethodPost, "/plugins/webhook/:connectionId/deployments"))
+	assert.Equal(t, models.API_KEY_ROLE_VIEWER, requiredRole(http.MethodGet, "/blueprints/:blueprintId"))
+	assert.Equal(t, models.API_KEY_ROLE_OPERATOR, requiredRole(http.MethodPost, "/blueprints/:blueprintId/trigger"))
+	assert.Equal(t, models.API_KEY_ROLE_OPERATOR, requiredRole(http.MethodDelete, "/pipelines/:pipelineId"))
+	assert.Equal(t, models.API_KEY_ROLE_ADMIN, requiredRole(http.MethodPatch, "/blueprints/:blueprintId"))
+	assert.Equal(t, models.API_KEY_ROLE_ADMIN, requiredRole(http.MethodPost, "/push/:tableName"))
+	assert.Equal(t, models.API_KEY_ROLE_ADMIN, requiredRole(http.MethodGet, "/proceed-db-migration"))
+	assert.Equal(t, models.API_KEY_ROLE_ADMIN, requiredRole(http.MethodGet, "/plugins/github/connections/:connectionId"))
+}
+
+func newAuthTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	keys := map[string]*models.ApiKey{
+		"viewer":   {Role: models.API_KEY_ROLE_VIEWER},
+		"operator": {Role: models.API_KEY_ROLE_OPERATOR, Projects: []string{"a"}},
+		"admin":    {Role: models.API_KEY_ROLE_ADMIN},
+	}
+	authenticate := func(token string) (*models.ApiKey, errors.Error) {
+		if key, ok := keys[token]; ok {
+			return key, nil
+		}
+		return nil, errors.Unauthorized.New("invalid api key")
+	}
+	blueprintProjects := map[string]string{"1": "a", "2": "b"}
+	resolveProject := func(c *gin.Context) (string, bool, errors.Error) {
+		projectName, ok := blueprintProjects[c.Param("blueprintId")]
+		return projectName, ok, nil
+	}
+	router := gin.New()
+	router.Use(authorize(authenticate, resolveProject))
+	ok := func(c *gin.Context) {
+		shared.ApiOutputSuccess(c, shared.GetProjectScope(c), http.StatusOK)
+	}
+	router.GET("/ping", ok)
+	router.GET("/blueprints", ok)
+	router.POST("/blueprints/:blueprintId/trigger", ok)
+	router.POST("/push/:tableName", ok)
+	return router
+}
+
+func TestAuthorize(t *testing.T) {
+	router := newAuthTestRouter()
+	cases := []struct {
+		method string
+		path   string
+		header string
+		status int
+	}{
+		{http.MethodGet, "/ping", "", http.StatusOK},
+		{http.MethodGet, "/blueprints", "", http.StatusUnauthorized},
+		{http.MethodGet, "/blueprints", "Bearer unknown", http.StatusUnauthorized},
+		{http.MethodGet, "/blueprints", "Bearer viewer", http.StatusOK},
+		{http.MethodPost, "/blueprints/1/trigger", "Bearer viewer", http.StatusForbidden},
+		{http.MethodPost, "/blueprints/1/trigger", "Bearer operator", http.StatusOK},
+		{http.MethodPost, "/blueprints/2/trigger", "Bearer operator", http.StatusForbidden},
+		{http.MethodPost, "/push/issues", "Bearer operator", http.StatusForbidden},
+		{http.MethodPost, "/push/issues", "Bearer admin", http.StatusOK},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tc.status, w.Code, "%s %s with %s", tc.method, tc.path, tc.header)
+	}
+}
+
+func TestAuthorizeProjectScope(t *testing.T) {
+	router := newAuthTestRouter()
+	req := httptest.NewRequest(http.MethodGet, "/blueprints", nil)
+	req.Header.Set("X-API-Key", "operator")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `["a"]`, w.Body.String())
+}
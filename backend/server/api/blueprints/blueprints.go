@@ -80,6 +80,7 @@ func Index(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.ProjectNames = shared.GetProjectScope(c)
 	blueprints, count, err := services.GetBlueprints(&query)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting blueprints"))
@@ -112,26 +113,37 @@ func Get(c *gin.Context) {
 	shared.ApiOutputSuccess(c, blueprint, http.StatusOK)
 }
 
-// // @Summary delete blueprints
-// // @Description Delete BluePrints
-// // @Tags framework/blueprints
-// // @Param blueprintId path string true "blueprintId"
-// // @Success 200
-// // @Failure 400  {object} shared.ApiBody "Bad Request"
-// // @Failure 500  {object} shared.ApiBody "Internal Error"
-// // @Router /blueprints/{blueprintId} [delete]
-// func Delete(c *gin.Context) {
-// 	pipelineId := c.Param("blueprintId")
-// 	id, err := strconv.ParseUint(pipelineId, 10, 64)
-// 	if err != nil {
-// 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
-// 		return
-// 	}
-// 	err = services.DeleteBlueprint(id)
-// 	if err != nil {
-// 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting blueprint"))
-// 	}
-// }
+// @Summary delete blueprints
+// @Description Delete the blueprint along with its labels and notification channels, pipelines are kept as history.
+// @Description Deletion is refused while any pipeline of the blueprint is queued or running.
+// @Tags framework/blueprints
+// @Param blueprintId path string true "blueprintId"
+// @Param dryRun query bool false "only report what would be removed"
+// @Success 200  {object} services.DeletionReport
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 409  {object} shared.ApiBody "Pipeline Running"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId} [delete]
+func Delete(c *gin.Context) {
+	blueprintId := c.Param("blueprintId")
+	id, err := strconv.ParseUint(blueprintId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
+		return
+	}
+	var query services.DeletionQuery
+	err = c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	report, err := services.DeleteBlueprint(id, &query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting blueprint"))
+		return
+	}
+	shared.ApiOutputSuccess(c, report, http.StatusOK)
+}
 
 // @Summary patch blueprints
 // @Description patch blueprints
@@ -170,6 +182,7 @@ func Patch(c *gin.Context) {
 // @Param blueprintId path string true "blueprintId"
 // @Success 200  {object} models.Pipeline
 // @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 409  {object} shared.ApiBody "Skipped by the concurrency policy of the blueprint"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
 // @Router /blueprints/{blueprintId}/trigger [Post]
 func Trigger(c *gin.Context) {
@@ -216,3 +229,40 @@ func GetBlueprintPipelines(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, shared.ResponsePipelines{Pipelines: pipelines, Count: count}, http.StatusOK)
 }
+
+type PaginatedSkippedTriggers struct {
+	SkippedTriggers []*models.SkippedBlueprintTrigger `json:"skippedTriggers"`
+	Count           int64                             `json:"count"`
+}
+
+// @Summary get skipped triggers by blueprint id
+// @Description get triggers which were skipped because a previous pipeline of the blueprint was not finished
+// @Tags framework/blueprints
+// @Accept application/json
+// @Param blueprintId path int true "blueprint id"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200  {object} PaginatedSkippedTriggers
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId}/skipped-triggers [get]
+func GetSkippedTriggers(c *gin.Context) {
+	var query services.SkippedBlueprintTriggerQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = c.ShouldBindUri(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad request URI format"))
+		return
+	}
+
+	triggers, count, err := services.GetSkippedBlueprintTriggers(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting skipped triggers"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedSkippedTriggers{SkippedTriggers: triggers, Count: count}, http.StatusOK)
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary re-encrypt all encrypted columns by the primary key
+// @Description Re-encrypt values of all `serializer:encdec` columns, i.e. connection tokens and pipeline plans, in the background.
+// @Description Values are decrypted by ENCODE_PREVIOUS_KEYS and encrypted by ENCODE_KEY, ENCODE_KEY_ID must be set.
+// @Description Previous keys could be removed from the configuration once the re-encryption finished without errors.
+// @Tags framework/encryption
+// @Success 202  {object} services.ReencryptionProgress
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 409  {object} shared.ApiBody "Re-encryption Running"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /encryption/reencrypt [post]
+func PostReencrypt(c *gin.Context) {
+	progress, err := services.StartReencryption()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error starting re-encryption"))
+		return
+	}
+	shared.ApiOutputSuccess(c, progress, http.StatusAccepted)
+}
+
+// @Summary get progress of the re-encryption
+// @Description get progress of the running or the last re-encryption
+// @Tags framework/encryption
+// @Success 200  {object} services.ReencryptionProgress
+// @Router /encryption/reencrypt [get]
+func GetReencrypt(c *gin.Context) {
+	shared.ApiOutputSuccess(c, services.GetReencryptionProgress(), http.StatusOK)
+}
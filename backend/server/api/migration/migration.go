@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary get the plan of pending migration scripts
+// @Description Simulate pending migration scripts without writing to the database, list the tables each of them would
+// @Description touch, whether it would drop or delete anything and whether it could be rolled back.
+// @Description Available before the migration is confirmed by /proceed-db-migration
+// @Tags framework/migrations
+// @Success 200  {object} []plugin.MigrationPlanItem
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /migrations/plan [get]
+func GetPlan(c *gin.Context) {
+	plan, err := services.GetMigrationPlan()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error planning migration"))
+		return
+	}
+	shared.ApiOutputSuccess(c, plan, http.StatusOK)
+}
+
+// @Summary roll back the database to a migration version
+// @Description Revert executed migration scripts newer than the version in reverse order, nothing would be reverted
+// @Description if any of them is not reversible. Downgrade DevLake to the matching release after the rollback.
+// @Tags framework/migrations
+// @Accept application/json
+// @Param request body services.MigrationRollbackRequest true "json"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 409  {object} shared.ApiBody "Pipelines Running"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /migrations/rollback [post]
+func PostRollback(c *gin.Context) {
+	request := &services.MigrationRollbackRequest{}
+	err := c.ShouldBind(request)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.RollbackMigration(request)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error rolling back migration"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
@@ -0,0 +1,192 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedNotificationChannels struct {
+	Channels []*models.NotificationChannel `json:"channels"`
+	Count    int64                         `json:"count"`
+}
+
+// @Summary create a notification channel
+// @Description create a notification channel, type could be WEBHOOK, SLACK, TEAMS or EMAIL
+// @Description events could be any of PipelineStarted, TaskFailed and PipelineFinished, empty for all
+// @Tags framework/notification-channels
+// @Accept application/json
+// @Param channel body models.NotificationChannel true "json"
+// @Success 201  {object} models.NotificationChannel
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels [post]
+func PostChannel(c *gin.Context) {
+	channel := &models.NotificationChannel{}
+	err := c.ShouldBind(channel)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.CreateNotificationChannel(channel)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating notification channel"))
+		return
+	}
+	shared.ApiOutputSuccess(c, services.SanitizeNotificationChannel(channel), http.StatusCreated)
+}
+
+// @Summary get notification channels
+// @Description get notification channels
+// @Tags framework/notification-channels
+// @Param projectName query string false "projectName"
+// @Param blueprintId query int false "blueprintId"
+// @Param page query int false "page"
+// @Param pageSize query int false "pageSize"
+// @Success 200  {object} PaginatedNotificationChannels
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels [get]
+func IndexChannels(c *gin.Context) {
+	var query services.NotificationChannelQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	channels, count, err := services.GetNotificationChannels(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting notification channels"))
+		return
+	}
+	for i, channel := range channels {
+		channels[i] = services.SanitizeNotificationChannel(channel)
+	}
+	shared.ApiOutputSuccess(c, PaginatedNotificationChannels{Channels: channels, Count: count}, http.StatusOK)
+}
+
+// @Summary get a notification channel
+// @Description get a notification channel
+// @Tags framework/notification-channels
+// @Param channelId path int true "channel id"
+// @Success 200  {object} models.NotificationChannel
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels/{channelId} [get]
+func GetChannel(c *gin.Context) {
+	id, err := getChannelId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	channel, err := services.GetNotificationChannel(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting notification channel"))
+		return
+	}
+	shared.ApiOutputSuccess(c, services.SanitizeNotificationChannel(channel), http.StatusOK)
+}
+
+// @Summary patch a notification channel
+// @Description patch a notification channel
+// @Tags framework/notification-channels
+// @Accept application/json
+// @Param channelId path int true "channel id"
+// @Success 200  {object} models.NotificationChannel
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels/{channelId} [patch]
+func PatchChannel(c *gin.Context) {
+	id, err := getChannelId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	var body map[string]interface{}
+	err = errors.Convert(c.ShouldBind(&body))
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	channel, err := services.PatchNotificationChannel(id, body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error patching notification channel"))
+		return
+	}
+	shared.ApiOutputSuccess(c, services.SanitizeNotificationChannel(channel), http.StatusOK)
+}
+
+// @Summary delete a notification channel
+// @Description delete a notification channel
+// @Tags framework/notification-channels
+// @Param channelId path int true "channel id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels/{channelId} [delete]
+func DeleteChannel(c *gin.Context) {
+	id, err := getChannelId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	err = services.DeleteNotificationChannel(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting notification channel"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary send a test notification
+// @Description send a test notification to the channel immediately
+// @Tags framework/notification-channels
+// @Param channelId path int true "channel id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels/{channelId}/test [post]
+func TestChannel(c *gin.Context) {
+	id, err := getChannelId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	err = services.TestNotificationChannel(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error sending test notification"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+func getChannelId(c *gin.Context) (uint64, errors.Error) {
+	id, err := strconv.ParseUint(c.Param("channelId"), 10, 64)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "bad channelId format supplied")
+	}
+	return id, nil
+}
@@ -78,6 +78,7 @@ func Index(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.ProjectNames = shared.GetProjectScope(c)
 	pipelines, count, err := services.GetPipelines(&query)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipelines"))
@@ -138,6 +139,54 @@ func Delete(c *gin.Context) {
 	shared.ApiOutputSuccess(c, nil, http.StatusOK)
 }
 
+// @Summary Pause a pipeline
+// @Description Pause a pipeline, running tasks stop at the next subtask boundary and keep the subtasks finished so far
+// @Tags framework/pipelines
+// @Param pipelineId path int true "pipeline ID"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Pipeline not found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/{pipelineId}/pause [post]
+func PostPause(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
+		return
+	}
+	err = services.PausePipeline(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error pausing pipeline"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Resume a paused pipeline
+// @Description Resume a paused pipeline from the subtasks it was stopped at
+// @Tags framework/pipelines
+// @Param pipelineId path int true "pipeline ID"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Pipeline not found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/{pipelineId}/resume [post]
+func PostResume(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
+		return
+	}
+	err = services.ResumePipeline(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error resuming pipeline"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
 // @Summary download logs of a pipeline
 // @Description GET /pipelines/:pipelineId/logging.tar.gz
 // @Tags framework/pipelines
@@ -68,6 +68,7 @@ func GetProjects(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.ProjectNames = shared.GetProjectScope(c)
 	projects, count, err := services.GetProjects(&query)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting projects"))
@@ -133,3 +134,34 @@ func PatchProject(c *gin.Context) {
 
 	shared.ApiOutputSuccess(c, projectOutput, http.StatusCreated)
 }
+
+// @Summary Delete a project
+// @Description Delete a project along with its metric settings, project mappings, blueprint and notification channels.
+// @Description Deletion is refused while any pipeline of the project is queued or running.
+// @Tags framework/projects
+// @Param projectName path string true "project name"
+// @Param dryRun query bool false "only report what would be removed"
+// @Param deleteMetrics query bool false "remove project_pr_metrics and project_issue_metrics of the project as well"
+// @Success 200  {object} services.DeletionReport
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 409  {string} errcode.Error "Pipeline Running"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/:projectName [delete]
+func DeleteProject(c *gin.Context) {
+	projectName := c.Param("projectName")[1:]
+
+	var query services.DeletionQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+
+	report, err := services.DeleteProject(projectName, &query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting project"))
+		return
+	}
+
+	shared.ApiOutputSuccess(c, report, http.StatusOK)
+}
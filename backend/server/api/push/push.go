@@ -28,7 +28,7 @@ import (
 )
 
 /*
-	POST /push/:tableName
+	POST /push/:tableName?mode=upsert&source=deploy-tool
 	[
 		{
 			"id": 1,
@@ -37,28 +37,38 @@ import (
 	]
 */
 // @Summary POST /push/:tableName
-// @Description POST /push/:tableName
+// @Description Write rows into a domain layer table, rows are validated against the columns of the table.
+// @Description Rejected rows are reported by their index in `errors` without failing the others.
 // @Tags framework/push
 // @Accept application/json
 // @Param tableName path string true "table name"
+// @Param mode query string false "insert (default), upsert or delete"
+// @Param source query string false "stamped into _raw_data_params of the rows"
 // @Param data body string true "data"
-// @Success 200  {object} gin.H "{"rowsAffected": rowsAffected}"
+// @Success 200  {object} services.PushResult
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Not a domain layer table"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /push/{tableName} [post]
 func Post(c *gin.Context) {
 	var err error
 	tableName := c.Param("tableName")
-	var rowsToInsert []map[string]interface{}
-	err = c.ShouldBindJSON(&rowsToInsert)
+	var query services.PushQuery
+	err = c.ShouldBindQuery(&query)
 	if err != nil {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
-	rowsAffected, err := services.InsertRow(tableName, rowsToInsert)
+	var rows []map[string]interface{}
+	err = c.ShouldBindJSON(&rows)
 	if err != nil {
-		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("error inserting request body into table %s", tableName)))
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	result, err := services.PushRows(tableName, rows, &query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("error pushing request body into table %s", tableName)))
 		return
 	}
-	shared.ApiOutputSuccess(c, gin.H{"rowsAffected": rowsAffected}, http.StatusOK)
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
 }
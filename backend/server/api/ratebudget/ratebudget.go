@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratebudget
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/server/api/shared"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary list the api rate budgets
+// @Description List the request rates shared by the api clients of the same connection, or the same host when the connection is unknown.
+// @Description Budgets are kept per process, tasks executed by temporal workers are not included.
+// @Tags framework/rate-budgets
+// @Success 200  {object} []api.ApiRateBudgetState
+// @Router /rate-budgets [get]
+func Index(c *gin.Context) {
+	shared.ApiOutputSuccess(c, api.GetApiRateBudgets(), http.StatusOK)
+}
@@ -23,19 +23,24 @@ import (
 	"strings"
 
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/api/apikeys"
 	"github.com/apache/incubator-devlake/server/api/blueprints"
 	"github.com/apache/incubator-devlake/server/api/domainlayer"
+	"github.com/apache/incubator-devlake/server/api/encryption"
+	"github.com/apache/incubator-devlake/server/api/notification"
 	"github.com/apache/incubator-devlake/server/api/ping"
 	"github.com/apache/incubator-devlake/server/api/pipelines"
 	"github.com/apache/incubator-devlake/server/api/plugininfo"
 	"github.com/apache/incubator-devlake/server/api/project"
 	"github.com/apache/incubator-devlake/server/api/push"
+	"github.com/apache/incubator-devlake/server/api/ratebudget"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/task"
 	"github.com/apache/incubator-devlake/server/api/version"
 	"github.com/apache/incubator-devlake/server/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 func RegisterRouter(r *gin.Engine) {
@@ -44,15 +49,18 @@ func RegisterRouter(r *gin.Engine) {
 	r.GET("/pipelines/:pipelineId", pipelines.Get)
 	r.PATCH("/blueprints/:blueprintId", blueprints.Patch)
 	r.POST("/blueprints/:blueprintId/trigger", blueprints.Trigger)
-	// r.DELETE("/blueprints/:blueprintId", blueprints.Delete)
+	r.DELETE("/blueprints/:blueprintId", blueprints.Delete)
 
 	r.GET("/blueprints", blueprints.Index)
 	r.POST("/blueprints", blueprints.Post)
 	r.GET("/blueprints/:blueprintId", blueprints.Get)
 	r.GET("/blueprints/:blueprintId/pipelines", blueprints.GetBlueprintPipelines)
+	r.GET("/blueprints/:blueprintId/skipped-triggers", blueprints.GetSkippedTriggers)
 	r.DELETE("/pipelines/:pipelineId", pipelines.Delete)
 	r.GET("/pipelines/:pipelineId/tasks", task.GetTaskByPipeline)
 	r.POST("/pipelines/:pipelineId/rerun", pipelines.PostRerun)
+	r.POST("/pipelines/:pipelineId/pause", pipelines.PostPause)
+	r.POST("/pipelines/:pipelineId/resume", pipelines.PostResume)
 	r.POST("/tasks/:taskId/rerun", task.PostRerun)
 
 	r.GET("/pipelines/:pipelineId/logging.tar.gz", pipelines.DownloadLogs)
@@ -61,6 +69,24 @@ func RegisterRouter(r *gin.Engine) {
 	r.GET("/version", version.Get)
 	r.POST("/push/:tableName", push.Post)
 	r.GET("/domainlayer/repos", domainlayer.ReposIndex)
+	r.GET("/rate-budgets", ratebudget.Index)
+
+	// notification api
+	r.GET("/notification-channels", notification.IndexChannels)
+	r.POST("/notification-channels", notification.PostChannel)
+	r.GET("/notification-channels/:channelId", notification.GetChannel)
+	r.PATCH("/notification-channels/:channelId", notification.PatchChannel)
+	r.DELETE("/notification-channels/:channelId", notification.DeleteChannel)
+	r.POST("/notification-channels/:channelId/test", notification.TestChannel)
+
+	// api key api
+	r.GET("/api-keys", apikeys.Index)
+	r.POST("/api-keys", apikeys.Post)
+	r.DELETE("/api-keys/:apiKeyId", apikeys.Delete)
+
+	// encryption api
+	r.POST("/encryption/reencrypt", encryption.PostReencrypt)
+	r.GET("/encryption/reencrypt", encryption.GetReencrypt)
 
 	// plugin api
 	r.GET("/plugininfo", plugininfo.Get)
@@ -69,7 +95,7 @@ func RegisterRouter(r *gin.Engine) {
 	// project api
 	r.GET("/projects/*projectName", project.GetProject)
 	r.PATCH("/projects/*projectName", project.PatchProject)
-	//r.DELETE("/projects/:projectName", project.DeleteProject)
+	r.DELETE("/projects/*projectName", project.DeleteProject)
 	r.POST("/projects", project.PostProject)
 	r.GET("/projects", project.GetProjects)
 
@@ -107,15 +133,19 @@ func handlePluginCall(handler plugin.ApiResourceHandler) func(c *gin.Context) {
 			}
 		}
 		input.Query = c.Request.URL.Query()
+		input.Header = c.Request.Header
 		if c.Request.Body != nil {
 			if strings.HasPrefix(c.Request.Header.Get("Content-Type"), "multipart/form-data;") {
 				input.Request = c.Request
 			} else {
-				err = c.ShouldBindJSON(&input.Body)
+				err = c.ShouldBindBodyWith(&input.Body, binding.JSON)
 				if err != nil && err.Error() != "EOF" {
 					shared.ApiOutputError(c, err)
 					return
 				}
+				if rawBody, ok := c.Get(gin.BodyBytesKey); ok {
+					input.RawBody, _ = rawBody.([]byte)
+				}
 			}
 		}
 		output, err := handler(input)
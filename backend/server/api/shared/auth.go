@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"github.com/apache/incubator-devlake/core/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const apiKeyContextKey = "apiKey"
+
+// SetApiKey saves the authenticated ApiKey into the context
+func SetApiKey(c *gin.Context, key *models.ApiKey) {
+	c.Set(apiKeyContextKey, key)
+}
+
+// GetApiKey returns the authenticated ApiKey of the request, nil if authentication is disabled
+func GetApiKey(c *gin.Context) *models.ApiKey {
+	if v, ok := c.Get(apiKeyContextKey); ok {
+		if key, ok := v.(*models.ApiKey); ok {
+			return key
+		}
+	}
+	return nil
+}
+
+// GetProjectScope returns the projects that the request is limited to, nil means no limitation
+func GetProjectScope(c *gin.Context) []string {
+	key := GetApiKey(c)
+	if key == nil || len(key.Projects) == 0 {
+		return nil
+	}
+	return key.Projects
+}
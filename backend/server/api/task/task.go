@@ -50,6 +50,8 @@ type getTaskResponse struct {
 
 // GetTaskByPipeline return most recent tasks
 // @Summary Get tasks, only the most recent tasks will be returned
+// @Description subtaskDetails of each task contain status, error message, records processed, rows written per table,
+// @Description api requests, retries and rate-limit waits of the subtasks
 // @Tags framework/tasks
 // @Accept application/json
 // @Param pipelineId path int true "pipelineId"
@@ -68,6 +70,11 @@ func GetTaskByPipeline(c *gin.Context) {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting tasks"))
 		return
 	}
+	err = services.FillSubtaskDetails(tasks)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting subtasks"))
+		return
+	}
 	shared.ApiOutputSuccess(c, getTaskResponse{Tasks: tasks, Count: len(tasks)}, http.StatusOK)
 }
 
@@ -18,8 +18,13 @@ limitations under the License.
 package main
 
 import (
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/apache/incubator-devlake/core/config"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
 	_ "github.com/apache/incubator-devlake/core/version"
 	"github.com/apache/incubator-devlake/server/api"
 )
@@ -36,5 +41,14 @@ func main() {
 			panic(err)
 		}
 	}
+	defer tracing.Shutdown()
+	// the server runs until it gets terminated, flush the pending spans before exiting
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigc
+		tracing.Shutdown()
+		os.Exit(0)
+	}()
 	api.CreateApiService()
 }
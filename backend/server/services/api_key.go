@@ -0,0 +1,235 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// apiKeyPrefix makes tokens of lake easy to be recognized by secret scanners
+const apiKeyPrefix = "dlk_"
+
+// ApiKeyQuery is a query for GetApiKeys
+type ApiKeyQuery struct {
+	Pagination
+}
+
+// ApiAuthEnabled tells whether requests to the REST server must carry an api key
+func ApiAuthEnabled() bool {
+	return cfg.GetBool("API_AUTH_ENABLED")
+}
+
+// HashApiKey returns the hex encoded sha256 of the token
+func HashApiKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateApiKeyToken() (string, errors.Error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "error generating api key")
+	}
+	return apiKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// CreateApiKey generates a token for the key and saves its hash into database, the plain token is set to
+// `key.Token` and can't be retrieved afterward
+func CreateApiKey(key *models.ApiKey) errors.Error {
+	key.ID = 0
+	err := VerifyStruct(key)
+	if err != nil {
+		return err
+	}
+	token, err := generateApiKeyToken()
+	if err != nil {
+		return err
+	}
+	key.TokenHash = HashApiKey(token)
+	key.LastUsedAt = nil
+	err = db.Create(key)
+	if err != nil {
+		return errors.Default.Wrap(err, "error creating api key")
+	}
+	if len(key.Projects) > 0 {
+		keyProjects := make([]*models.ApiKeyProject, 0, len(key.Projects))
+		for _, projectName := range key.Projects {
+			keyProjects = append(keyProjects, &models.ApiKeyProject{
+				ApiKeyId:    key.ID,
+				ProjectName: projectName,
+			})
+		}
+		err = db.Create(&keyProjects)
+		if err != nil {
+			return errors.Default.Wrap(err, "error creating projects of the api key")
+		}
+	}
+	key.Token = token
+	return nil
+}
+
+// GetApiKeys returns a paginated list of ApiKeys based on `query`
+func GetApiKeys(query *ApiKeyQuery) ([]*models.ApiKey, int64, errors.Error) {
+	clauses := []dal.Clause{dal.From(&models.ApiKey{})}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of api keys")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	keys := make([]*models.ApiKey, 0)
+	err = db.All(&keys, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting api keys")
+	}
+	for _, key := range keys {
+		err = fillApiKeyProjects(key)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return keys, count, nil
+}
+
+// GetApiKey returns the detail of a given ApiKey ID
+func GetApiKey(keyId uint64) (*models.ApiKey, errors.Error) {
+	key := &models.ApiKey{}
+	err := db.First(key, dal.Where("id = ?", keyId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("api key not found")
+		}
+		return nil, errors.Internal.Wrap(err, "error getting the api key from database")
+	}
+	return key, fillApiKeyProjects(key)
+}
+
+// DeleteApiKey revokes the key
+func DeleteApiKey(keyId uint64) errors.Error {
+	key, err := GetApiKey(keyId)
+	if err != nil {
+		return err
+	}
+	err = db.Delete(&models.ApiKeyProject{}, dal.Where("api_key_id = ?", key.ID))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting projects of the api key")
+	}
+	return db.Delete(key)
+}
+
+// AuthenticateApiKey returns the ApiKey of the token. The token configured by API_ADMIN_KEY is accepted as
+// an unrestricted admin key, so the first keys could be created and migrations could be confirmed before the
+// _devlake_api_keys table exists.
+func AuthenticateApiKey(token string) (*models.ApiKey, errors.Error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errors.Unauthorized.New("api key is required")
+	}
+	tokenHash := HashApiKey(token)
+	if adminKey := cfg.GetString("API_ADMIN_KEY"); adminKey != "" {
+		if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(HashApiKey(adminKey))) == 1 {
+			return &models.ApiKey{Name: "API_ADMIN_KEY", Role: models.API_KEY_ROLE_ADMIN}, nil
+		}
+	}
+	key := &models.ApiKey{}
+	err := db.First(key, dal.Where("token_hash = ?", tokenHash))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.Unauthorized.New("invalid api key")
+		}
+		return nil, errors.Internal.Wrap(err, "error getting the api key from database")
+	}
+	now := time.Now()
+	if key.ExpiredAt != nil && key.ExpiredAt.Before(now) {
+		return nil, errors.Unauthorized.New("api key expired")
+	}
+	err = fillApiKeyProjects(key)
+	if err != nil {
+		return nil, err
+	}
+	err = db.UpdateColumn(&models.ApiKey{}, "last_used_at", now, dal.Where("id = ?", key.ID))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error updating last_used_at of the api key")
+	}
+	key.LastUsedAt = &now
+	return key, nil
+}
+
+func fillApiKeyProjects(key *models.ApiKey) errors.Error {
+	key.Projects = make([]string, 0)
+	err := db.Pluck("project_name", &key.Projects, dal.From(&models.ApiKeyProject{}), dal.Where("api_key_id = ?", key.ID))
+	if err != nil {
+		return errors.Default.Wrap(err, "error getting projects of the api key")
+	}
+	return nil
+}
+
+// GetProjectNameOfBlueprint returns the project that the blueprint belongs to, empty if there is none
+func GetProjectNameOfBlueprint(blueprintId uint64) (string, errors.Error) {
+	blueprint := &models.Blueprint{}
+	err := db.First(blueprint, dal.Select("project_name"), dal.Where("id = ?", blueprintId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", errors.NotFound.New("blueprint not found")
+		}
+		return "", errors.Internal.Wrap(err, "error getting the blueprint from database")
+	}
+	return blueprint.ProjectName, nil
+}
+
+// GetProjectNameOfPipeline returns the project that the pipeline belongs to, empty if there is none
+func GetProjectNameOfPipeline(pipelineId uint64) (string, errors.Error) {
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline, dal.Select("blueprint_id"), dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", errors.NotFound.New("pipeline not found")
+		}
+		return "", errors.Internal.Wrap(err, "error getting the pipeline from database")
+	}
+	if pipeline.BlueprintId == 0 {
+		return "", nil
+	}
+	return GetProjectNameOfBlueprint(pipeline.BlueprintId)
+}
+
+// GetProjectNameOfTask returns the project that the task belongs to, empty if there is none
+func GetProjectNameOfTask(taskId uint64) (string, errors.Error) {
+	task := &models.Task{}
+	err := db.First(task, dal.Select("pipeline_id"), dal.Where("id = ?", taskId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", errors.NotFound.New("task not found")
+		}
+		return "", errors.Internal.Wrap(err, "error getting the task from database")
+	}
+	return GetProjectNameOfPipeline(task.PipelineId)
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockApiKeyDal(key *models.ApiKey, projects []string) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.AnythingOfType("*models.ApiKey"), mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*models.ApiKey) = *key
+	}).Return(nil)
+	mockDal.On("Pluck", "project_name", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]string) = projects
+	}).Return(nil)
+	mockDal.On("UpdateColumn", mock.Anything, "last_used_at", mock.Anything, mock.Anything).Return(nil)
+	return mockDal
+}
+
+func TestCreateApiKey(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil)
+	useMockDal(t, mockDal)
+	vld = validator.New()
+
+	key := &models.ApiKey{Name: "team a", Role: models.API_KEY_ROLE_OPERATOR, Projects: []string{"a"}}
+	assert.Nil(t, CreateApiKey(key))
+	assert.Regexp(t, "^dlk_[0-9a-f]{64}$", key.Token)
+	assert.Equal(t, HashApiKey(key.Token), key.TokenHash)
+	mockDal.AssertNumberOfCalls(t, "Create", 2)
+
+	err := CreateApiKey(&models.ApiKey{Name: "bad", Role: "root"})
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
+
+func TestAuthenticateApiKey(t *testing.T) {
+	useConfig(t, viper.New())
+	key := &models.ApiKey{Name: "team a", Role: models.API_KEY_ROLE_VIEWER, TokenHash: HashApiKey("dlk_a")}
+	key.ID = 1
+	mockDal := mockApiKeyDal(key, []string{"a"})
+	useMockDal(t, mockDal)
+
+	authenticated, err := AuthenticateApiKey("dlk_a")
+	assert.Nil(t, err)
+	assert.Equal(t, models.API_KEY_ROLE_VIEWER, authenticated.Role)
+	assert.Equal(t, []string{"a"}, authenticated.Projects)
+	assert.NotNil(t, authenticated.LastUsedAt)
+	mockDal.AssertCalled(t, "UpdateColumn", mock.Anything, "last_used_at", mock.Anything, mock.Anything)
+
+	_, err = AuthenticateApiKey("")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
+
+func TestAuthenticateApiKeyExpired(t *testing.T) {
+	useConfig(t, viper.New())
+	expiredAt := time.Now().Add(-time.Hour)
+	useMockDal(t, mockApiKeyDal(&models.ApiKey{Role: models.API_KEY_ROLE_ADMIN, ExpiredAt: &expiredAt}, nil))
+
+	_, err := AuthenticateApiKey("dlk_a")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
+
+func TestAuthenticateApiKeyNotFound(t *testing.T) {
+	useConfig(t, viper.New())
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Return(errors.NotFound.New("record not found"))
+	mockDal.On("IsErrorNotFound", mock.Anything).Return(true)
+	useMockDal(t, mockDal)
+
+	_, err := AuthenticateApiKey("dlk_unknown")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
+
+func TestAuthenticateAdminApiKey(t *testing.T) {
+	v := viper.New()
+	v.Set("API_ADMIN_KEY", "bootstrap")
+	useConfig(t, v)
+	mockDal := new(mockdal.Dal)
+	useMockDal(t, mockDal)
+
+	key, err := AuthenticateApiKey("bootstrap")
+	assert.Nil(t, err)
+	assert.Equal(t, models.API_KEY_ROLE_ADMIN, key.Role)
+	assert.Empty(t, key.Projects)
+	mockDal.AssertNotCalled(t, "First", mock.Anything, mock.Anything)
+}
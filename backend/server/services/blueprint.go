@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -37,6 +38,8 @@ type BlueprintQuery struct {
 	Enable   *bool  `form:"enable,omitempty"`
 	IsManual *bool  `form:"isManual"`
 	Label    string `form:"label"`
+	// ProjectNames limits the result to blueprints of the given projects when it is not nil
+	ProjectNames []string `form:"-"`
 }
 
 var (
@@ -49,8 +52,10 @@ type BlueprintJob struct {
 
 func (bj BlueprintJob) Run() {
 	blueprint := bj.Blueprint
-	pipeline, err := createPipelineByBlueprint(blueprint)
-	if err != nil {
+	pipeline, err := createPipelineByBlueprint(blueprint, triggeredByCron)
+	if err != nil && err.GetType() == errors.Conflict {
+		blueprintLog.Info("cron job skipped on blueprint:[%d][%s]: %s", blueprint.ID, blueprint.Name, err.Error())
+	} else if err != nil {
 		blueprintLog.Error(err, fmt.Sprintf("run cron job failed on blueprint:[%d][%s]", blueprint.ID, blueprint.Name))
 	} else {
 		blueprintLog.Info("Run new cron job successfully,blueprint id:[%d] pipeline id:[%d]", blueprint.ID, pipeline.ID)
@@ -217,11 +222,37 @@ func PatchBlueprint(id uint64, body map[string]interface{}) (*models.Blueprint,
 	return blueprint, nil
 }
 
+// DeleteBlueprint removes the blueprint with its labels and notification channels, pipelines are kept as history
+func DeleteBlueprint(id uint64, query *DeletionQuery) (*DeletionReport, errors.Error) {
+	return runDeletion(query.DryRun, func(tx dal.Transaction, report *DeletionReport) errors.Error {
+		blueprint := &models.Blueprint{}
+		err := tx.First(blueprint, dal.Where("id = ?", id), dal.Lock(true, false))
+		if err != nil {
+			if tx.IsErrorNotFound(err) {
+				return errors.NotFound.New("blueprint not found")
+			}
+			return errors.Default.Wrap(err, "error getting blueprint from DB")
+		}
+		err = ensureNoActivePipeline(tx, []uint64{id})
+		if err != nil {
+			return err
+		}
+		return report.deleteBlueprints(tx, []uint64{id})
+	})
+}
+
+var blueprintScheduleLock sync.Mutex
+var blueprintScheduleFingerprint string
+
 // ReloadBlueprints FIXME ...
 func ReloadBlueprints(c *cron.Cron) errors.Error {
-	enable := true
-	isManual := false
-	blueprints, _, err := GetDbBlueprints(&BlueprintQuery{Enable: &enable, IsManual: &isManual})
+	// the cron runs on the leader only, which picks changes made on other instances up by syncBlueprintSchedules
+	if !isLeader() {
+		return nil
+	}
+	blueprintScheduleLock.Lock()
+	defer blueprintScheduleLock.Unlock()
+	blueprints, err := getScheduledBlueprints()
 	if err != nil {
 		return err
 	}
@@ -248,11 +279,42 @@ func ReloadBlueprints(c *cron.Cron) errors.Error {
 	if len(blueprints) > 0 {
 		c.Start()
 	}
+	blueprintScheduleFingerprint = fingerprintBlueprints(blueprints)
 	logger.Info("total %d blueprints were scheduled", len(blueprints))
 	return nil
 }
 
-func createPipelineByBlueprint(blueprint *models.Blueprint) (*models.Pipeline, errors.Error) {
+// syncBlueprintSchedules reloads the cron if the scheduled blueprints were changed, possibly by other instances
+func syncBlueprintSchedules() errors.Error {
+	blueprints, err := getScheduledBlueprints()
+	if err != nil {
+		return err
+	}
+	blueprintScheduleLock.Lock()
+	changed := fingerprintBlueprints(blueprints) != blueprintScheduleFingerprint
+	blueprintScheduleLock.Unlock()
+	if !changed {
+		return nil
+	}
+	return ReloadBlueprints(cronManager)
+}
+
+func getScheduledBlueprints() ([]*models.Blueprint, errors.Error) {
+	enable := true
+	isManual := false
+	blueprints, _, err := GetDbBlueprints(&BlueprintQuery{Enable: &enable, IsManual: &isManual})
+	return blueprints, err
+}
+
+func fingerprintBlueprints(blueprints []*models.Blueprint) string {
+	var sb strings.Builder
+	for _, blueprint := range blueprints {
+		sb.WriteString(fmt.Sprintf("%d:%s:%d;", blueprint.ID, blueprint.CronConfig, blueprint.UpdatedAt.UnixNano()))
+	}
+	return sb.String()
+}
+
+func createPipelineByBlueprint(blueprint *models.Blueprint, triggeredBy string) (*models.Pipeline, errors.Error) {
 	var plan plugin.PipelinePlan
 	var err errors.Error
 	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
@@ -270,6 +332,7 @@ func createPipelineByBlueprint(blueprint *models.Blueprint) (*models.Pipeline, e
 	newPipeline.BlueprintId = blueprint.ID
 	newPipeline.Labels = blueprint.Labels
 	newPipeline.SkipOnFail = blueprint.SkipOnFail
+	newPipeline.TriggeredBy = triggeredBy
 	pipeline, err := CreatePipeline(&newPipeline)
 	// Return all created tasks to the User
 	if err != nil {
@@ -375,7 +438,7 @@ func TriggerBlueprint(id uint64) (*models.Pipeline, errors.Error) {
 	if err != nil {
 		return nil, err
 	}
-	pipeline, err := createPipelineByBlueprint(blueprint)
+	pipeline, err := createPipelineByBlueprint(blueprint, triggeredByApi)
 	// done
 	return pipeline, err
 }
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+const (
+	triggeredByCron = "cron"
+	triggeredByApi  = "api"
+)
+
+// replacedPipelineStatus includes paused pipelines as they would be resumed on the same raw tables, they don't
+// cause triggers to be skipped though, or a pipeline left paused would hold the blueprint forever. A resumed
+// pipeline is held in the queue while another one of the blueprint is running
+var replacedPipelineStatus = append([]string{models.TASK_PAUSED}, models.PendingTaskStatus...)
+
+// SkippedBlueprintTriggerQuery is the query of skipped triggers of a blueprint
+type SkippedBlueprintTriggerQuery struct {
+	Pagination
+	BlueprintId uint64 `uri:"blueprintId" form:"-"`
+}
+
+// applyBlueprintConcurrencyPolicy decides whether a new pipeline could be created for the blueprint while its
+// previous pipelines are not finished yet, following the ConcurrencyPolicy of the blueprint.
+// The caller must hold cronLocker so the check and the creation of the pipeline are not interleaved
+func applyBlueprintConcurrencyPolicy(newPipeline *models.NewPipeline) errors.Error {
+	blueprint := &models.Blueprint{}
+	err := db.First(blueprint, dal.Where("id = ?", newPipeline.BlueprintId))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return errors.Default.Wrap(err, "error getting blueprint")
+	}
+	if blueprint.ConcurrencyPolicy == models.BLUEPRINT_CONCURRENCY_ALLOW {
+		return nil
+	}
+	unfinishedPipelineStatus := models.PendingTaskStatus
+	if blueprint.ConcurrencyPolicy == models.BLUEPRINT_CONCURRENCY_REPLACE {
+		unfinishedPipelineStatus = replacedPipelineStatus
+	}
+	var unfinished []models.Pipeline
+	err = db.All(
+		&unfinished,
+		dal.Where("blueprint_id = ? AND status IN ?", newPipeline.BlueprintId, unfinishedPipelineStatus),
+		dal.Orderby("id"),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "query pipelines error")
+	}
+	if len(unfinished) == 0 {
+		return nil
+	}
+	if blueprint.ConcurrencyPolicy == models.BLUEPRINT_CONCURRENCY_REPLACE {
+		// the new pipeline would be held in the queue until the running ones are stopped
+		for _, pipeline := range unfinished {
+			blueprintLog.Info("cancel pipeline #%d to be replaced on blueprint:[%d]", pipeline.ID, newPipeline.BlueprintId)
+			if err := cancelPipeline(pipeline.ID); err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("failed to cancel pipeline #%d", pipeline.ID))
+			}
+		}
+		return nil
+	}
+	reason := fmt.Sprintf("pipeline #%d of the blueprint is %s", unfinished[0].ID, unfinished[0].Status)
+	err = db.Create(&models.SkippedBlueprintTrigger{
+		BlueprintId: newPipeline.BlueprintId,
+		TriggeredBy: newPipeline.TriggeredBy,
+		PipelineId:  unfinished[0].ID,
+		Reason:      reason,
+	})
+	if err != nil {
+		blueprintLog.Error(err, "failed to record the skipped trigger on blueprint:[%d]", newPipeline.BlueprintId)
+	}
+	return errors.Conflict.New(fmt.Sprintf("trigger skipped: %s", reason))
+}
+
+// GetSkippedBlueprintTriggers returns the skipped triggers of a blueprint, latest first
+func GetSkippedBlueprintTriggers(query *SkippedBlueprintTriggerQuery) ([]*models.SkippedBlueprintTrigger, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.SkippedBlueprintTrigger{}),
+		dal.Where("blueprint_id = ?", query.BlueprintId),
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, err
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	triggers := make([]*models.SkippedBlueprintTrigger, 0)
+	err = db.All(&triggers, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting skipped triggers")
+	}
+	return triggers, count, nil
+}
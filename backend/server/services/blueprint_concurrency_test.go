@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockBlueprintDal(policy string, unfinished []models.Pipeline) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.AnythingOfType("*models.Blueprint"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Blueprint).ConcurrencyPolicy = policy
+	}).Return(nil)
+	mockDal.On("All", mock.AnythingOfType("*[]models.Pipeline"), mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]models.Pipeline) = unfinished
+	}).Return(nil)
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil)
+	return mockDal
+}
+
+func TestApplyBlueprintConcurrencyPolicySkip(t *testing.T) {
+	running := models.Pipeline{Status: models.TASK_RUNNING}
+	running.ID = 3
+	mockDal := mockBlueprintDal("", []models.Pipeline{running})
+	useMockDal(t, mockDal)
+
+	err := applyBlueprintConcurrencyPolicy(&models.NewPipeline{BlueprintId: 1, TriggeredBy: triggeredByCron})
+	assert.NotNil(t, err)
+	assert.Equal(t, errors.Conflict, err.GetType())
+	mockDal.AssertCalled(t, "Create", &models.SkippedBlueprintTrigger{
+		BlueprintId: 1,
+		TriggeredBy: triggeredByCron,
+		PipelineId:  3,
+		Reason:      "pipeline #3 of the blueprint is TASK_RUNNING",
+	}, []dal.Clause(nil))
+}
+
+func TestApplyBlueprintConcurrencyPolicyNothingRunning(t *testing.T) {
+	mockDal := mockBlueprintDal(models.BLUEPRINT_CONCURRENCY_SKIP, nil)
+	useMockDal(t, mockDal)
+
+	assert.Nil(t, applyBlueprintConcurrencyPolicy(&models.NewPipeline{BlueprintId: 1}))
+	mockDal.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestApplyBlueprintConcurrencyPolicyAllow(t *testing.T) {
+	mockDal := mockBlueprintDal(models.BLUEPRINT_CONCURRENCY_ALLOW, []models.Pipeline{{Status: models.TASK_RUNNING}})
+	useMockDal(t, mockDal)
+
+	assert.Nil(t, applyBlueprintConcurrencyPolicy(&models.NewPipeline{BlueprintId: 1}))
+	mockDal.AssertNotCalled(t, "All", mock.Anything, mock.Anything)
+}
+
+func TestApplyBlueprintConcurrencyPolicyPausedStatus(t *testing.T) {
+	queriedStatus := func(mockDal *mockdal.Dal) []string {
+		for _, call := range mockDal.Calls {
+			if call.Method == "All" {
+				clause := call.Arguments.Get(1).([]dal.Clause)[0].Data.(dal.DalClause)
+				return clause.Params[1].([]string)
+			}
+		}
+		return nil
+	}
+
+	// a paused pipeline must not hold the blueprint forever
+	mockDal := mockBlueprintDal(models.BLUEPRINT_CONCURRENCY_SKIP, nil)
+	useMockDal(t, mockDal)
+	assert.Nil(t, applyBlueprintConcurrencyPolicy(&models.NewPipeline{BlueprintId: 1}))
+	assert.NotContains(t, queriedStatus(mockDal), models.TASK_PAUSED)
+
+	// but it is replaced along with the running ones
+	mockDal = mockBlueprintDal(models.BLUEPRINT_CONCURRENCY_REPLACE, nil)
+	useMockDal(t, mockDal)
+	assert.Nil(t, applyBlueprintConcurrencyPolicy(&models.NewPipeline{BlueprintId: 1}))
+	assert.Contains(t, queriedStatus(mockDal), models.TASK_PAUSED)
+}
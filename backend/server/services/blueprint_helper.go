@@ -71,6 +71,9 @@ func GetDbBlueprints(query *BlueprintQuery) ([]*models.Blueprint, int64, errors.
 			dal.Where("bl.name = ?", query.Label),
 		)
 	}
+	if query.ProjectNames != nil {
+		clauses = append(clauses, dal.Where("project_name IN ?", query.ProjectNames))
+	}
 
 	// count total records
 	count, err := db.Count(clauses...)
@@ -0,0 +1,138 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// DeletionQuery holds the options of deleting projects and blueprints
+type DeletionQuery struct {
+	// DryRun reports what would be removed without touching anything
+	DryRun bool `form:"dryRun"`
+	// DeleteMetrics removes the project-scoped metric rows, i.e. project_pr_metrics, as well
+	DeleteMetrics bool `form:"deleteMetrics"`
+}
+
+// DeletedRows is the number of rows removed from a table
+type DeletedRows struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+}
+
+// DeletionReport lists all rows removed, or to be removed in dry-run mode, by a deletion
+type DeletionReport struct {
+	DryRun  bool           `json:"dryRun"`
+	Deleted []*DeletedRows `json:"deleted"`
+}
+
+// deleteRows removes rows matching the clauses from the table of `model`, or only counts them in dry-run mode
+func (report *DeletionReport) deleteRows(tx dal.Transaction, model dal.Tabler, clauses ...dal.Clause) errors.Error {
+	count, err := tx.Count(append([]dal.Clause{dal.From(model)}, clauses...)...)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error counting rows of %s", model.TableName()))
+	}
+	if !report.DryRun && count > 0 {
+		err = tx.Delete(model, clauses...)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error deleting rows of %s", model.TableName()))
+		}
+	}
+	report.Deleted = append(report.Deleted, &DeletedRows{Table: model.TableName(), Rows: count})
+	return nil
+}
+
+// ensureNoActivePipeline refuses the deletion if any pipeline of the blueprints is queued or running
+func ensureNoActivePipeline(tx dal.Transaction, blueprintIds []uint64) errors.Error {
+	if len(blueprintIds) == 0 {
+		return nil
+	}
+	count, err := tx.Count(
+		dal.From(&models.Pipeline{}),
+		dal.Where(
+			"blueprint_id IN ? AND status IN ?",
+			blueprintIds,
+			[]string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_RUNNING, models.TASK_PAUSED},
+		),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "error counting active pipelines")
+	}
+	if count > 0 {
+		return errors.Conflict.New(fmt.Sprintf("%d pipeline(s) are queued, running or paused, please cancel them or wait until they finish", count))
+	}
+	return nil
+}
+
+// deleteBlueprints removes the blueprints along with their labels and notification channels,
+// pipelines are kept as history
+func (report *DeletionReport) deleteBlueprints(tx dal.Transaction, blueprintIds []uint64) errors.Error {
+	if len(blueprintIds) == 0 {
+		return nil
+	}
+	err := report.deleteRows(tx, &models.DbBlueprintLabel{}, dal.Where("blueprint_id IN ?", blueprintIds))
+	if err != nil {
+		return err
+	}
+	err = report.deleteRows(tx, &models.NotificationChannel{}, dal.Where("blueprint_id IN ?", blueprintIds))
+	if err != nil {
+		return err
+	}
+	err = report.deleteRows(tx, &models.SkippedBlueprintTrigger{}, dal.Where("blueprint_id IN ?", blueprintIds))
+	if err != nil {
+		return err
+	}
+	return report.deleteRows(tx, &models.Blueprint{}, dal.Where("id IN ?", blueprintIds))
+}
+
+// runDeletion executes `fn` inside a transaction, which would be rolled back in dry-run mode or on failure
+func runDeletion(dryRun bool, fn func(tx dal.Transaction, report *DeletionReport) errors.Error) (*DeletionReport, errors.Error) {
+	report := &DeletionReport{DryRun: dryRun, Deleted: make([]*DeletedRows, 0)}
+	tx := db.Begin()
+	committed := false
+	defer func() {
+		if !committed {
+			if e := tx.Rollback(); e != nil {
+				logger.Error(e, "failed to rollback deletion")
+			}
+		}
+	}()
+	err := fn(tx, report)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return report, nil
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	committed = true
+	// drop the cron jobs of deleted blueprints, the deletion is committed already so a failure here
+	// must not be reported as a failed deletion, the schedules get synced again later on
+	err = ReloadBlueprints(cronManager)
+	if err != nil {
+		logger.Error(err, "error reloading blueprints after deletion")
+	}
+	return report, nil
+}
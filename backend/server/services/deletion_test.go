@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteRowsDryRun(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	tx.On("Count", mock.Anything).Return(int64(3), nil).Once()
+
+	report := &DeletionReport{DryRun: true}
+	err := report.deleteRows(tx, &models.DbBlueprintLabel{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*DeletedRows{{Table: "_devlake_blueprint_labels", Rows: 3}}, report.Deleted)
+	tx.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteRows(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	tx.On("Count", mock.Anything).Return(int64(2), nil).Once()
+	tx.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+	tx.On("Count", mock.Anything).Return(int64(0), nil).Once()
+
+	report := &DeletionReport{}
+	assert.Nil(t, report.deleteRows(tx, &models.Blueprint{}))
+	assert.Nil(t, report.deleteRows(tx, &models.NotificationChannel{}))
+	assert.Len(t, report.Deleted, 2)
+	assert.Equal(t, int64(0), report.Deleted[1].Rows)
+	// nothing to delete from the second table
+	tx.AssertNumberOfCalls(t, "Delete", 1)
+}
+
+func TestEnsureNoActivePipeline(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	assert.Nil(t, ensureNoActivePipeline(tx, nil))
+
+	tx.On("Count", mock.Anything).Return(int64(0), nil).Once()
+	assert.Nil(t, ensureNoActivePipeline(tx, []uint64{1}))
+
+	tx.On("Count", mock.Anything).Return(int64(1), nil).Once()
+	err := ensureNoActivePipeline(tx, []uint64{1})
+	assert.NotNil(t, err)
+	assert.Equal(t, errors.Conflict, err.GetType())
+}
@@ -0,0 +1,269 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+
+	"gorm.io/gorm/schema"
+)
+
+// EncryptedTable is a table with `serializer:encdec` columns
+type EncryptedTable struct {
+	Table       string   `json:"table"`
+	PrimaryKeys []string `json:"primaryKeys"`
+	Columns     []string `json:"columns"`
+}
+
+// ReencryptionProgress reports the progress of re-encrypting all `serializer:encdec` columns by the primary key
+type ReencryptionProgress struct {
+	Running         bool       `json:"running"`
+	KeyId           string     `json:"keyId"`
+	StartedAt       *time.Time `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt"`
+	TotalTables     int        `json:"totalTables"`
+	FinishedTables  int        `json:"finishedTables"`
+	CurrentTable    string     `json:"currentTable"`
+	ScannedRows     int64      `json:"scannedRows"`
+	ReencryptedRows int64      `json:"reencryptedRows"`
+	Errors          []string   `json:"errors"`
+}
+
+var reencryption = struct {
+	sync.Mutex
+	progress ReencryptionProgress
+}{}
+
+// frameworkTables are the tables of lake itself which may contain encrypted columns
+var frameworkTables = []dal.Tabler{
+	&models.Blueprint{},
+	&models.Pipeline{},
+	&models.Task{},
+	&models.NotificationChannel{},
+}
+
+// GetReencryptionProgress returns the progress of the last re-encryption
+func GetReencryptionProgress() *ReencryptionProgress {
+	reencryption.Lock()
+	defer reencryption.Unlock()
+	progress := reencryption.progress
+	progress.Errors = append([]string(nil), progress.Errors...)
+	return &progress
+}
+
+// StartReencryption re-encrypts values of all `serializer:encdec` columns that were not encrypted by the primary
+// key in the background, tables of framework and plugins implementing PluginModel are covered
+func StartReencryption() (*ReencryptionProgress, errors.Error) {
+	keyring, err := plugin.NewEncKeyringFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if keyring.PrimaryId() == "" {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s must be set to tell values of the primary key apart", plugin.EncodeKeyIdEnvStr))
+	}
+	tablers := append([]dal.Tabler{}, frameworkTables...)
+	for _, p := range plugin.AllPlugins() {
+		if pluginModel, ok := p.(plugin.PluginModel); ok {
+			tablers = append(tablers, pluginModel.GetTablesInfo()...)
+		}
+	}
+	tables := findEncryptedTables(tablers)
+
+	reencryption.Lock()
+	defer reencryption.Unlock()
+	if reencryption.progress.Running {
+		return nil, errors.Conflict.New("re-encryption is running")
+	}
+	now := time.Now()
+	reencryption.progress = ReencryptionProgress{
+		Running:     true,
+		KeyId:       keyring.PrimaryId(),
+		StartedAt:   &now,
+		TotalTables: len(tables),
+		Errors:      make([]string, 0),
+	}
+	progress := reencryption.progress
+	go func() {
+		reencryptTables(keyring, tables)
+		reencryption.Lock()
+		defer reencryption.Unlock()
+		finishedAt := time.Now()
+		reencryption.progress.Running = false
+		reencryption.progress.CurrentTable = ""
+		reencryption.progress.FinishedAt = &finishedAt
+		logger.Info("re-encryption finished, %d of %d rows re-encrypted with %d errors",
+			reencryption.progress.ReencryptedRows, reencryption.progress.ScannedRows, len(reencryption.progress.Errors))
+	}()
+	return &progress, nil
+}
+
+func updateReencryptionProgress(update func(progress *ReencryptionProgress)) {
+	reencryption.Lock()
+	defer reencryption.Unlock()
+	update(&reencryption.progress)
+}
+
+func reencryptTables(keyring *plugin.EncKeyring, tables []*EncryptedTable) {
+	for _, table := range tables {
+		updateReencryptionProgress(func(progress *ReencryptionProgress) {
+			progress.CurrentTable = table.Table
+		})
+		logger.Info("re-encrypting %s", table.Table)
+		err := reencryptTable(keyring, table)
+		updateReencryptionProgress(func(progress *ReencryptionProgress) {
+			if err != nil {
+				progress.Errors = append(progress.Errors, err.Messages().Format())
+			}
+			progress.FinishedTables++
+		})
+	}
+}
+
+// reencryptTable re-encrypts the table row by row, errors of rows are recorded and don't stop the process
+func reencryptTable(keyring *plugin.EncKeyring, table *EncryptedTable) errors.Error {
+	cursor, err := db.Cursor(
+		dal.Select(strings.Join(append(append([]string{}, table.PrimaryKeys...), table.Columns...), ", ")),
+		dal.From(table.Table),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error reading %s", table.Table))
+	}
+	defer cursor.Close()
+	where := strings.Join(table.PrimaryKeys, " = ? AND ") + " = ?"
+	for cursor.Next() {
+		pkValues := make([]interface{}, len(table.PrimaryKeys))
+		colValues := make([]sql.NullString, len(table.Columns))
+		dest := make([]interface{}, 0, len(pkValues)+len(colValues))
+		for i := range pkValues {
+			dest = append(dest, &pkValues[i])
+		}
+		for i := range colValues {
+			dest = append(dest, &colValues[i])
+		}
+		err = errors.Convert(cursor.Scan(dest...))
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error scanning %s", table.Table))
+		}
+		updated, rowErr := reencryptRow(keyring, table, where, pkValues, colValues)
+		updateReencryptionProgress(func(progress *ReencryptionProgress) {
+			progress.ScannedRows++
+			if rowErr != nil {
+				progress.Errors = append(progress.Errors, rowErr.Messages().Format())
+			} else if updated {
+				progress.ReencryptedRows++
+			}
+		})
+	}
+	return nil
+}
+
+func reencryptRow(
+	keyring *plugin.EncKeyring,
+	table *EncryptedTable,
+	where string,
+	pkValues []interface{},
+	colValues []sql.NullString,
+) (bool, errors.Error) {
+	sets := make([]dal.DalSet, 0)
+	for i, column := range table.Columns {
+		value := colValues[i]
+		if !value.Valid || value.String == "" || keyring.IsEncryptedByPrimary(value.String) {
+			continue
+		}
+		decrypted, err := keyring.Decrypt(value.String)
+		if err != nil {
+			return false, errors.Default.Wrap(err, fmt.Sprintf("error decrypting %s.%s of %v", table.Table, column, pkValues))
+		}
+		encrypted, err := keyring.Encrypt(decrypted)
+		if err != nil {
+			return false, errors.Default.Wrap(err, fmt.Sprintf("error encrypting %s.%s of %v", table.Table, column, pkValues))
+		}
+		sets = append(sets, dal.DalSet{ColumnName: column, Value: encrypted})
+	}
+	if len(sets) == 0 {
+		return false, nil
+	}
+	err := db.UpdateColumns(table.Table, sets, dal.Where(where, pkValues...))
+	if err != nil {
+		return false, errors.Default.Wrap(err, fmt.Sprintf("error updating %s of %v", table.Table, pkValues))
+	}
+	return true, nil
+}
+
+// findEncryptedTables returns tables with `serializer:encdec` columns, duplicated tables are merged
+func findEncryptedTables(tablers []dal.Tabler) []*EncryptedTable {
+	tables := make([]*EncryptedTable, 0)
+	seen := make(map[string]bool)
+	for _, tabler := range tablers {
+		if seen[tabler.TableName()] {
+			continue
+		}
+		seen[tabler.TableName()] = true
+		table := &EncryptedTable{Table: tabler.TableName()}
+		collectEncryptedColumns(reflect.Indirect(reflect.ValueOf(tabler)).Type(), table)
+		if len(table.Columns) > 0 && len(table.PrimaryKeys) > 0 {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+func collectEncryptedColumns(t reflect.Type, table *EncryptedTable) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	naming := schema.NamingStrategy{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		settings := schema.ParseTagSetting(field.Tag.Get("gorm"), ";")
+		if _, ignored := settings["-"]; ignored {
+			continue
+		}
+		if _, embedded := settings["EMBEDDED"]; field.Anonymous || embedded {
+			collectEncryptedColumns(field.Type, table)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		column := settings["COLUMN"]
+		if column == "" {
+			column = naming.ColumnName("", field.Name)
+		}
+		_, isPrimaryKey := settings["PRIMARYKEY"]
+		if _, ok := settings["PRIMARY_KEY"]; ok {
+			isPrimaryKey = true
+		}
+		if isPrimaryKey {
+			table.PrimaryKeys = append(table.PrimaryKeys, column)
+		}
+		if strings.EqualFold(settings["SERIALIZER"], "encdec") {
+			table.Columns = append(table.Columns, column)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFindEncryptedTables(t *testing.T) {
+	tables := findEncryptedTables(append(frameworkTables, &models.Blueprint{}, &models.Project{}))
+	assert.Equal(t, []*EncryptedTable{
+		{Table: "_devlake_blueprints", PrimaryKeys: []string{"id"}, Columns: []string{"plan", "settings"}},
+		{Table: "_devlake_pipelines", PrimaryKeys: []string{"id"}, Columns: []string{"plan"}},
+		{Table: "_devlake_tasks", PrimaryKeys: []string{"id"}, Columns: []string{"options"}},
+		{Table: "_devlake_notification_channels", PrimaryKeys: []string{"id"}, Columns: []string{"endpoint", "secret", "settings"}},
+	}, tables)
+}
+
+func TestReencryptTable(t *testing.T) {
+	oldKeyring, _ := plugin.NewEncKeyring("k1", "OLDKEY", nil, nil)
+	keyring, _ := plugin.NewEncKeyring("k2", "NEWKEY", map[string]string{"k1": "OLDKEY"}, []string{"k1"})
+	oldValue, _ := oldKeyring.Encrypt("secret")
+	newValue, _ := keyring.Encrypt("other")
+	rows := [][]interface{}{
+		{uint64(1), oldValue},
+		{uint64(2), newValue},
+		{uint64(3), nil},
+	}
+
+	cursor := new(mockdal.Rows)
+	next := 0
+	cursor.On("Next").Return(func() bool {
+		next++
+		return next <= len(rows)
+	})
+	cursor.On("Scan", mock.Anything).Return(func(dest ...interface{}) error {
+		row := rows[next-1]
+		*dest[0].(*interface{}) = row[0]
+		return dest[1].(*sql.NullString).Scan(row[1])
+	})
+	cursor.On("Close").Return(nil)
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Cursor", mock.Anything).Return(cursor, nil)
+	var updated []dal.DalSet
+	mockDal.On("UpdateColumns", "_devlake_tasks", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		updated = append(updated, args.Get(1).([]dal.DalSet)...)
+	}).Return(nil)
+	useMockDal(t, mockDal)
+	useLogger(t, unithelper.DummyLogger())
+	reencryption.progress = ReencryptionProgress{}
+
+	err := reencryptTable(keyring, &EncryptedTable{Table: "_devlake_tasks", PrimaryKeys: []string{"id"}, Columns: []string{"options"}})
+	assert.Nil(t, err)
+	mockDal.AssertNumberOfCalls(t, "UpdateColumns", 1)
+	assert.Len(t, updated, 1)
+	assert.True(t, keyring.IsEncryptedByPrimary(updated[0].Value.(string)))
+	decrypted, _ := keyring.Decrypt(updated[0].Value.(string))
+	assert.Equal(t, "secret", decrypted)
+
+	progress := GetReencryptionProgress()
+	assert.Equal(t, int64(3), progress.ScannedRows)
+	assert.Equal(t, int64(1), progress.ReencryptedRows)
+	assert.Empty(t, progress.Errors)
+}
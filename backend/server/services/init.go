@@ -26,8 +26,6 @@ import (
 	"github.com/apache/incubator-devlake/core/models/migrationscripts"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/runner"
-	"github.com/apache/incubator-devlake/impls/dalgorm"
-	"github.com/apache/incubator-devlake/impls/logruslog"
 	"github.com/go-playground/validator/v10"
 	"github.com/robfig/cron/v3"
 	"sync"
@@ -41,6 +39,7 @@ var basicRes context.BasicRes
 var migrator plugin.Migrator
 var cronManager *cron.Cron
 var cronLocker sync.Mutex
+var servicesStarted sync.Once
 var vld *validator.Validate
 
 const failToCreateCronJob = "created cron job failed"
@@ -79,9 +78,6 @@ func GetMigrator() plugin.Migrator {
 func Init() {
 	InitResources()
 
-	// lock the database to avoid multiple devlake instances from sharing the same one
-	lockDb()
-
 	var err error
 	// now, load the plugins
 	err = runner.LoadPlugins(basicRes)
@@ -110,21 +106,21 @@ func Init() {
 
 // ExecuteMigration executes all pending migration scripts and initialize services module
 func ExecuteMigration() errors.Error {
-	// apply all pending migration scripts
-	err := migrator.Execute()
+	// apply all pending migration scripts, one instance at a time
+	err := withMigrationLock(migrator.Execute)
 	if err != nil {
 		return err
 	}
 
-	// cronjob for blueprint triggering
-	location := cron.WithLocation(time.UTC)
-	cronManager = cron.New(location)
-	if err != nil {
-		panic(err)
-	}
+	// the services might have been started already when migrating again after a rollback
+	servicesStarted.Do(func() {
+		// cronjob for blueprint triggering
+		location := cron.WithLocation(time.UTC)
+		cronManager = cron.New(location)
 
-	// initialize pipeline server, mainly to start the pipeline consuming process
-	pipelineServiceInit()
+		// initialize pipeline server, mainly to start the pipeline consuming process
+		pipelineServiceInit()
+	})
 	return nil
 }
 
@@ -132,19 +128,3 @@ func ExecuteMigration() errors.Error {
 func MigrationRequireConfirmation() bool {
 	return migrator.HasPendingScripts()
 }
-
-func lockDb() {
-	// gorm doesn't support creating a PrepareStmt=false session from a PrepareStmt=true
-	// but the lockDatabase needs PrepareStmt=false for table locking, we have to deal with it here
-	lockingDb, err := runner.NewGormDbEx(cfg, logruslog.Global.Nested("migrator db"), &dal.SessionConfig{
-		PrepareStmt:            false,
-		SkipDefaultTransaction: true,
-	})
-	if err != nil {
-		panic(err)
-	}
-	err = lockDatabase(dalgorm.NewDalgorm(lockingDb))
-	if err != nil {
-		panic(err)
-	}
-}
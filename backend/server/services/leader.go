@@ -0,0 +1,240 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/version"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+)
+
+const defaultLeaderLeaseSeconds = 30
+
+var leaderLog = logruslog.Global.Nested("leader")
+
+// leader is the elector of this instance, it is nil before the services get started
+var leader *leaderElector
+
+// leaderElector competes for the models.LeaderLease with other instances sharing the same database. Any number of
+// instances could serve the api, while only the leader runs the blueprint cron and the pipeline queue.
+// The lease is compared against the local clock of each instance, so clocks should be synchronized well within
+// the lease duration
+type leaderElector struct {
+	name          string
+	holderId      string
+	holderHost    string
+	ttl           time.Duration
+	renewInterval time.Duration
+	now           func() time.Time
+	onElected     func()
+	onDeposed     func()
+
+	mu        sync.Mutex
+	isLeader  bool
+	expiresAt time.Time
+}
+
+func newLeaderElector(name string, ttl time.Duration, onElected func(), onDeposed func()) (*leaderElector, errors.Error) {
+	hostName, e := os.Hostname()
+	if e != nil {
+		return nil, errors.Convert(e)
+	}
+	return &leaderElector{
+		name: name,
+		// a restarted instance with the same host name and pid, i.e. a restarted container, regains the lease at once
+		holderId:      fmt.Sprintf("%s:%d", hostName, os.Getpid()),
+		holderHost:    hostName,
+		ttl:           ttl,
+		renewInterval: ttl / 3,
+		now:           time.Now,
+		onElected:     onElected,
+		onDeposed:     onDeposed,
+	}, nil
+}
+
+// IsLeader returns whether this instance holds the lease
+func (e *leaderElector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.isLeader
+}
+
+// acquireOrRenew takes the lease over if it expired or renews it if it is held by this instance already,
+// the lease held by this instance afterwards is returned
+func (e *leaderElector) acquireOrRenew(now time.Time) (*models.LeaderLease, errors.Error) {
+	// make sure the lease exists, an expired one could be taken over by anyone
+	err := db.CreateIfNotExist(&models.LeaderLease{
+		Name:       e.name,
+		AcquiredAt: now,
+		RenewedAt:  now,
+		ExpiresAt:  now,
+	})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error creating leader lease")
+	}
+	// the condition makes sure only one of the instances would succeed
+	err = db.UpdateColumns(
+		&models.LeaderLease{},
+		[]dal.DalSet{
+			{ColumnName: "holder_id", Value: e.holderId},
+			{ColumnName: "holder_host", Value: e.holderHost},
+			{ColumnName: "version", Value: version.Version},
+			{ColumnName: "renewed_at", Value: now},
+			{ColumnName: "expires_at", Value: now.Add(e.ttl)},
+		},
+		dal.Where("name = ? AND (holder_id = ? OR expires_at < ?)", e.name, e.holderId, now),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error updating leader lease")
+	}
+	lease := &models.LeaderLease{}
+	err = db.First(lease, dal.Where("name = ?", e.name))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error reading leader lease")
+	}
+	if lease.HolderId != e.holderId {
+		return nil, nil
+	}
+	if !e.IsLeader() {
+		lease.AcquiredAt = now
+		err = db.UpdateColumn(lease, "acquired_at", now)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error updating leader lease")
+		}
+	}
+	return lease, nil
+}
+
+// tick acquires or renews the lease and fires the callbacks when the leadership changed
+func (e *leaderElector) tick() {
+	now := e.now()
+	lease, err := e.acquireOrRenew(now)
+	e.mu.Lock()
+	wasLeader := e.isLeader
+	if err != nil {
+		leaderLog.Error(err, "failed to acquire or renew the leader lease")
+		// nobody would take the lease over before it expires, step down only if it might expire before the next tick
+		e.isLeader = wasLeader && now.Add(e.renewInterval).Before(e.expiresAt)
+	} else {
+		e.isLeader = lease != nil
+		if lease != nil {
+			e.expiresAt = lease.ExpiresAt
+		}
+	}
+	elected := e.isLeader
+	e.mu.Unlock()
+	metrics.SetLeader(elected)
+	if elected && !wasLeader {
+		leaderLog.Info("instance %s became the leader", e.holderId)
+		if e.onElected != nil {
+			e.onElected()
+		}
+	} else if !elected && wasLeader {
+		leaderLog.Warn(nil, "instance %s lost the leadership", e.holderId)
+		if e.onDeposed != nil {
+			e.onDeposed()
+		}
+	}
+}
+
+// run keeps competing for the lease forever
+func (e *leaderElector) run() {
+	e.tick()
+	ticker := time.NewTicker(e.renewInterval)
+	for range ticker.C {
+		e.tick()
+		if e.IsLeader() && !backgroundJobsPaused.Load() {
+			err := syncBlueprintSchedules()
+			if err != nil {
+				leaderLog.Error(err, "failed to sync blueprint schedules")
+			}
+			err = processCancelRequests()
+			if err != nil {
+				leaderLog.Error(err, "failed to process cancel requests")
+			}
+		}
+	}
+}
+
+// isLeader returns whether this instance is the leader, it is always false before the services get started
+func isLeader() bool {
+	return leader != nil && leader.IsLeader()
+}
+
+// GetLeaderLease returns the current leader lease
+func GetLeaderLease() (*models.LeaderLease, errors.Error) {
+	lease := &models.LeaderLease{}
+	err := db.First(lease, dal.Where("name = ?", models.LEADER_LEASE_SCHEDULER))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("no leader was elected yet")
+		}
+		return nil, errors.Default.Wrap(err, "error getting leader lease")
+	}
+	return lease, nil
+}
+
+// startLeaderElection competes for the scheduler lease in the background, the leader runs the blueprint cron and
+// the pipeline queue
+func startLeaderElection(pipelineMaxParallel int64) {
+	leaseSeconds := cfg.GetInt("LEADER_LEASE_SECONDS")
+	if leaseSeconds <= 0 {
+		leaseSeconds = defaultLeaderLeaseSeconds
+	}
+	var queueStarted sync.Once
+	var err errors.Error
+	leader, err = newLeaderElector(
+		models.LEADER_LEASE_SCHEDULER,
+		time.Duration(leaseSeconds)*time.Second,
+		func() {
+			// pipelines left running must have been stranded by the previous leader
+			if temporalClient == nil {
+				err := recoverStrandedPipelines()
+				if err != nil {
+					panic(err)
+				}
+			}
+			err := ReloadBlueprints(cronManager)
+			if err != nil {
+				panic(err)
+			}
+			queueStarted.Do(func() {
+				go RunPipelineInQueue(pipelineMaxParallel)
+			})
+		},
+		func() {
+			// a standby would take the running pipelines over as stranded ones, exit to make sure they would not be
+			// executed twice, the instance is expected to be restarted by the supervisor and join as a standby
+			cronManager.Stop()
+			leaderLog.Error(nil, "exiting since the leadership was lost")
+			os.Exit(1)
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	go leader.run()
+}
@@ -0,0 +1,181 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockLeaseDal simulates the conditional update of the lease against the holder and the expiry time
+func mockLeaseDal(lease *models.LeaderLease, updateErr *errors.Error) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("CreateIfNotExist", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("UpdateColumns", mock.AnythingOfType("*models.LeaderLease"), mock.Anything, mock.Anything).Return(
+		func(_ interface{}, set []dal.DalSet, _ ...dal.Clause) errors.Error {
+			if *updateErr != nil {
+				return *updateErr
+			}
+			values := make(map[string]interface{})
+			for _, s := range set {
+				values[s.ColumnName] = s.Value
+			}
+			renewedAt := values["renewed_at"].(time.Time)
+			if lease.HolderId == values["holder_id"] || lease.ExpiresAt.Before(renewedAt) {
+				lease.HolderId = values["holder_id"].(string)
+				lease.ExpiresAt = values["expires_at"].(time.Time)
+			}
+			return nil
+		},
+	)
+	mockDal.On("First", mock.AnythingOfType("*models.LeaderLease"), mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*models.LeaderLease) = *lease
+	}).Return(nil)
+	mockDal.On("UpdateColumn", mock.Anything, "acquired_at", mock.Anything, mock.Anything).Return(nil)
+	return mockDal
+}
+
+func TestLeaderElector(t *testing.T) {
+	now := time.Now()
+	lease := &models.LeaderLease{Name: models.LEADER_LEASE_SCHEDULER, HolderId: "other", ExpiresAt: now.Add(10 * time.Second)}
+	var updateErr errors.Error
+	useMockDal(t, mockLeaseDal(lease, &updateErr))
+
+	elected, deposed := 0, 0
+	e, err := newLeaderElector(models.LEADER_LEASE_SCHEDULER, 30*time.Second, func() { elected++ }, func() { deposed++ })
+	assert.Nil(t, err)
+	e.now = func() time.Time { return now }
+
+	// the lease held by another instance is not expired yet
+	e.tick()
+	assert.False(t, e.IsLeader())
+	assert.Equal(t, 0, elected)
+
+	// take it over once expired
+	now = now.Add(11 * time.Second)
+	e.tick()
+	assert.True(t, e.IsLeader())
+	assert.Equal(t, e.holderId, lease.HolderId)
+	assert.Equal(t, 1, elected)
+
+	// renewing does not elect again
+	now = now.Add(10 * time.Second)
+	e.tick()
+	assert.True(t, e.IsLeader())
+	assert.Equal(t, 1, elected)
+
+	// keep the leadership on database errors as long as the lease would not expire before the next tick
+	updateErr = errors.Default.New("connection refused")
+	now = now.Add(10 * time.Second)
+	e.tick()
+	assert.True(t, e.IsLeader())
+	now = now.Add(10 * time.Second)
+	e.tick()
+	assert.False(t, e.IsLeader())
+	assert.Equal(t, 1, deposed)
+
+	// taken over by another instance meanwhile
+	updateErr = nil
+	now = now.Add(10 * time.Second)
+	e.tick()
+	assert.True(t, e.IsLeader())
+	lease.HolderId = "other"
+	e.tick()
+	assert.False(t, e.IsLeader())
+	assert.Equal(t, 2, elected)
+	assert.Equal(t, 2, deposed)
+}
+
+func TestCancelPipelineOnStandby(t *testing.T) {
+	// running pipelines are cancelled by the leader, a standby records the request only
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.AnythingOfType("*models.Pipeline"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Pipeline).Status = models.TASK_RUNNING
+	}).Return(nil)
+	mockDal.On("UpdateColumn", mock.AnythingOfType("*models.Pipeline"), "cancel_requested", true, mock.Anything).Return(nil).Once()
+	useMockDal(t, mockDal)
+
+	assert.Nil(t, CancelPipeline(1))
+	mockDal.AssertExpectations(t)
+}
+
+func TestProcessCancelRequests(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Pluck", "id", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]uint64) = []uint64{7}
+	}).Return(nil).Once()
+	// the pipeline got queued again meanwhile
+	mockDal.On("First", mock.AnythingOfType("*models.Pipeline"), mock.Anything).Run(func(args mock.Arguments) {
+		pipeline := args.Get(0).(*models.Pipeline)
+		pipeline.ID = 7
+		pipeline.Status = models.TASK_CREATED
+	}).Return(nil)
+	mockDal.On("Update", mock.AnythingOfType("*models.Pipeline"), mock.Anything).Run(func(args mock.Arguments) {
+		assert.Equal(t, models.TASK_CANCELLED, args.Get(0).(*models.Pipeline).Status)
+	}).Return(nil).Once()
+	mockDal.On("UpdateColumn", mock.AnythingOfType("*models.Task"), "status", models.TASK_CANCELLED, mock.Anything).Return(nil).Once()
+	mockDal.On("UpdateColumn", mock.AnythingOfType("*models.Pipeline"), "cancel_requested", false, mock.Anything).Return(nil).Once()
+	useMockDal(t, mockDal)
+
+	assert.Nil(t, processCancelRequests())
+	mockDal.AssertExpectations(t)
+}
+
+func TestPausePipelinePickedUpByLeader(t *testing.T) {
+	// the pipeline is pending when read, then the leader on another instance starts running it
+	statuses := []string{models.TASK_CREATED, models.TASK_RUNNING}
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.AnythingOfType("*models.Pipeline"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Pipeline).Status = statuses[0]
+		statuses = statuses[1:]
+	}).Return(nil)
+	var updates []string
+	mockDal.On("UpdateColumn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		clauses := args.Get(3).([]dal.Clause)
+		updates = append(updates, args.String(1)+": "+clauses[0].Data.(dal.DalClause).Expr)
+	}).Return(nil)
+	useMockDal(t, mockDal)
+
+	assert.Nil(t, PausePipeline(1))
+	assert.Equal(t, []string{
+		"status: id = ? AND status IN ?",
+		"pause_requested: id = ? AND status = ?",
+	}, updates)
+}
+
+func TestPausePipelineFinishedMeanwhile(t *testing.T) {
+	statuses := []string{models.TASK_CREATED, models.TASK_COMPLETED}
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.AnythingOfType("*models.Pipeline"), mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Pipeline).Status = statuses[0]
+		statuses = statuses[1:]
+	}).Return(nil)
+	mockDal.On("UpdateColumn", mock.Anything, "status", mock.Anything, mock.Anything).Return(nil).Once()
+	useMockDal(t, mockDal)
+
+	err := PausePipeline(1)
+	assert.Equal(t, errors.BadInput, err.GetType())
+	mockDal.AssertExpectations(t)
+}
@@ -18,66 +18,51 @@ limitations under the License.
 package services
 
 import (
-	"os"
-	"time"
-
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
-	"github.com/apache/incubator-devlake/core/version"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	"github.com/apache/incubator-devlake/impls/logruslog"
 )
 
-// long last transaction for database locking
-var lockingTx dal.Transaction
-
-// lockDatabase prevents multiple devlake instances from sharing the same lockDatabase
-// check the models.LockingHistory for the detail
-func lockDatabase(db dal.Dal) errors.Error {
-	// first, register the instance
-	err := db.AutoMigrate(&models.LockingHistory{})
+// withMigrationLock runs fn while holding the lock of the _devlake_locking_stub table, so that the instances
+// sharing the same database, i.e. replicas started together, migrate it one after another. An instance waits
+// for the lock as long as the database allows, then finds the scripts applied by the others executed already
+func withMigrationLock(fn func() errors.Error) errors.Error {
+	// gorm doesn't support creating a PrepareStmt=false session from a PrepareStmt=true
+	// but table locking needs PrepareStmt=false, we have to deal with it here
+	lockingDb, err := runner.NewGormDbEx(cfg, logruslog.Global.Nested("migrator db"), &dal.SessionConfig{
+		PrepareStmt:            false,
+		SkipDefaultTransaction: true,
+	})
 	if err != nil {
 		return err
 	}
-	hostName, e := os.Hostname()
-	if e != nil {
-		return errors.Convert(e)
-	}
-	lockingHistory := &models.LockingHistory{
-		HostName: hostName,
-		Version:  version.Version,
-	}
-	err = db.Create(lockingHistory)
-	if err != nil {
-		return err
+	if sqlDb, e := lockingDb.DB(); e == nil {
+		defer sqlDb.Close()
 	}
-	// 2. obtain the lock
+	db := dalgorm.NewDalgorm(lockingDb)
 	err = db.AutoMigrate(&models.LockingStub{})
 	if err != nil {
 		return err
 	}
-	lockingTx = db.Begin()
-	c := make(chan error, 1)
-
-	// This prevent multiple devlake instances from sharing the same database by locking the migration history table
-	// However, it would not work if any older devlake instances were already using the database.
-	go func() {
-		switch db.Dialect() {
-		case "mysql":
-			c <- lockingTx.Exec("LOCK TABLE _devlake_locking_stub WRITE")
-		case "postgres":
-			c <- lockingTx.Exec("LOCK TABLE _devlake_locking_stub IN EXCLUSIVE MODE")
+	// the lock is bound to the connection of the transaction, it is released when the transaction ends
+	lockingTx := db.Begin()
+	defer func() {
+		if db.Dialect() == "mysql" {
+			_ = lockingTx.Exec("UNLOCK TABLES")
 		}
+		_ = lockingTx.Rollback()
 	}()
-
-	select {
-	case err := <-c:
-		if err != nil {
-			return errors.Convert(err)
-		}
-	case <-time.After(2 * time.Second):
-		return errors.Default.New("locking _devlake_locking_stub timeout, the database might be locked by another devlake instance")
+	switch db.Dialect() {
+	case "mysql":
+		err = lockingTx.Exec("LOCK TABLE _devlake_locking_stub WRITE")
+	case "postgres":
+		err = lockingTx.Exec("LOCK TABLE _devlake_locking_stub IN EXCLUSIVE MODE")
+	}
+	if err != nil {
+		return errors.Default.Wrap(err, "error locking _devlake_locking_stub for migration")
 	}
-	// 3. update the record
-	lockingHistory.Succeeded = true
-	return db.Update(lockingHistory)
+	return fn()
 }
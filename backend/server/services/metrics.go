@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/metrics"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dbStatsCollector reads pipeline/task statistics from the database at scraping time, so the numbers
+// stay correct across restarts and reflect pipelines queued by other means
+type dbStatsCollector struct {
+	pipelines        *prometheus.Desc
+	tasks            *prometheus.Desc
+	queueDepth       *prometheus.Desc
+	oldestRunningAge *prometheus.Desc
+	scrapeError      *prometheus.Desc
+}
+
+func newDbStatsCollector() *dbStatsCollector {
+	ns := metrics.Namespace()
+	return &dbStatsCollector{
+		pipelines: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "", "pipelines"),
+			"Number of pipelines by status.",
+			[]string{"status"}, nil,
+		),
+		tasks: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "", "tasks"),
+			"Number of tasks by status.",
+			[]string{"status"}, nil,
+		),
+		queueDepth: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "", "pipeline_queue_depth"),
+			"Number of pipelines waiting to be executed.",
+			nil, nil,
+		),
+		oldestRunningAge: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "", "oldest_running_task_age_seconds"),
+			"Seconds since the longest running task began, 0 if no task is running. Useful for detecting stuck tasks.",
+			nil, nil,
+		),
+		scrapeError: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "", "db_stats_scrape_error"),
+			"1 if reading statistics from the database failed during the last scrape.",
+			nil, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pipelines
+	ch <- c.tasks
+	ch <- c.queueDepth
+	ch <- c.oldestRunningAge
+	ch <- c.scrapeError
+}
+
+type statusCount struct {
+	Status string
+	Count  int64
+}
+
+// Collect implements prometheus.Collector
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	failed := false
+	var queueDepth int64
+	pipelineCounts, err := countByStatus(&models.Pipeline{})
+	if err != nil {
+		failed = true
+	}
+	for _, pc := range pipelineCounts {
+		ch <- prometheus.MustNewConstMetric(c.pipelines, prometheus.GaugeValue, float64(pc.Count), pc.Status)
+		if pc.Status == models.TASK_CREATED || pc.Status == models.TASK_RERUN {
+			queueDepth += pc.Count
+		}
+	}
+	if !failed {
+		ch <- prometheus.MustNewConstMetric(c.queueDepth, prometheus.GaugeValue, float64(queueDepth))
+	}
+	taskCounts, err := countByStatus(&models.Task{})
+	if err != nil {
+		failed = true
+	}
+	for _, tc := range taskCounts {
+		ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(tc.Count), tc.Status)
+	}
+	oldest := &models.Task{}
+	err = db.First(
+		oldest,
+		dal.Where("status = ? AND began_at IS NOT NULL", models.TASK_RUNNING),
+		dal.Orderby("began_at ASC"),
+	)
+	if err == nil {
+		age := time.Since(*oldest.BeganAt).Seconds()
+		ch <- prometheus.MustNewConstMetric(c.oldestRunningAge, prometheus.GaugeValue, age)
+	} else if db.IsErrorNotFound(err) {
+		ch <- prometheus.MustNewConstMetric(c.oldestRunningAge, prometheus.GaugeValue, 0)
+	} else {
+		failed = true
+	}
+	scrapeError := 0.0
+	if failed {
+		scrapeError = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.scrapeError, prometheus.GaugeValue, scrapeError)
+}
+
+func countByStatus(table interface{}) ([]statusCount, errors.Error) {
+	counts := make([]statusCount, 0)
+	err := db.All(
+		&counts,
+		dal.Select("status, count(*) AS count"),
+		dal.From(table),
+		dal.Groupby("status"),
+	)
+	if err != nil {
+		globalPipelineLog.Warn(err, "failed to collect statistics from database")
+		return nil, err
+	}
+	return counts, nil
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// backgroundJobsPaused is set while the database is being rolled back
+var backgroundJobsPaused atomic.Bool
+
+// MigrationRollbackRequest specifies the version to roll back to
+type MigrationRollbackRequest struct {
+	Version uint64 `json:"version" validate:"required"`
+}
+
+// GetMigrationPlan returns what the pending migration scripts would do to the database
+func GetMigrationPlan() ([]*plugin.MigrationPlanItem, errors.Error) {
+	return migrator.Plan()
+}
+
+// RollbackMigration reverts executed migration scripts newer than the specified version, pipelines must not be
+// running since the tables they write to may be altered or dropped
+func RollbackMigration(request *MigrationRollbackRequest) errors.Error {
+	if err := vld.Struct(request); err != nil {
+		return errors.BadInput.Wrap(err, "invalid rollback request")
+	}
+	// keep the scheduler, the pipeline queue and the retry loops of this instance away from the tables being reverted
+	resume := pauseBackgroundJobs()
+	defer resume()
+	running, err := db.Count(
+		dal.From(&models.Pipeline{}),
+		dal.Where("status IN ?", []string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_RUNNING, models.TASK_PAUSED}),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "error counting running pipelines")
+	}
+	if running > 0 {
+		return errors.Conflict.New(fmt.Sprintf("%d pipelines are running, please wait for them to finish", running))
+	}
+	logger.Info("rolling back database to migration version %d", request.Version)
+	return withMigrationLock(func() errors.Error {
+		return migrator.Rollback(request.Version)
+	})
+}
+
+// pauseBackgroundJobs stops the blueprint cron, holds the pipeline queue and suspends the periodic jobs of the
+// leader, the returned function resumes them
+func pauseBackgroundJobs() func() {
+	backgroundJobsPaused.Store(true)
+	if cronManager != nil {
+		// wait for the triggered blueprints to create their pipelines, they need the cronLocker
+		<-cronManager.Stop().Done()
+	}
+	// no pipeline could be created or picked up from the queue while the cronLocker is held
+	cronLocker.Lock()
+	return func() {
+		cronLocker.Unlock()
+		if cronManager != nil && isLeader() {
+			cronManager.Start()
+		}
+		backgroundJobsPaused.Store(false)
+	}
+}
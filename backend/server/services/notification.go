@@ -22,34 +22,80 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"github.com/apache/incubator-devlake/core/errors"
-	"github.com/apache/incubator-devlake/core/models"
-	"io"
 	"math/rand"
 	"net/http"
-	"strings"
 	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 )
 
 func init() {
 	rand.Seed(time.Now().UnixNano())
 }
 
-// NotificationService FIXME ...
+const (
+	defaultNotificationTimeout       = 10 * time.Second
+	defaultNotificationMaxAttempts   = 5
+	defaultNotificationRetryInterval = 30 * time.Second
+	maxNotificationRetryInterval     = time.Hour
+	notificationRetryBatchSize       = 100
+	notificationDeliveryWorkers      = 4
+	notificationDeliveryQueueSize    = 100
+)
+
+// NotificationService delivers notifications to the legacy NOTIFICATION_ENDPOINT and all matching
+// NotificationChannels. Every delivery is recorded in `_devlake_notifications` and retried with
+// exponential backoff until it succeeded or MaxAttempts was reached.
 type NotificationService struct {
-	EndPoint string
-	Secret   string
+	EndPoint      string
+	Secret        string
+	MaxAttempts   int
+	RetryInterval time.Duration
+	client        *http.Client
+	deliveries    chan *notificationDelivery
+}
+
+// notificationDelivery is the first delivery attempt of a notification, made by the background workers
+type notificationDelivery struct {
+	notification *models.Notification
+	channel      *models.NotificationChannel
 }
 
-// NewNotificationService FIXME ...
+// NewNotificationService creates a NotificationService, the legacy webhook would be disabled if endpoint was empty
 func NewNotificationService(endpoint, secret string) *NotificationService {
-	return &NotificationService{
-		EndPoint: endpoint,
-		Secret:   secret,
+	n := &NotificationService{
+		EndPoint:      endpoint,
+		Secret:        secret,
+		MaxAttempts:   defaultNotificationMaxAttempts,
+		RetryInterval: defaultNotificationRetryInterval,
+		client:        &http.Client{Timeout: defaultNotificationTimeout},
+		deliveries:    make(chan *notificationDelivery, notificationDeliveryQueueSize),
+	}
+	for i := 0; i < notificationDeliveryWorkers; i++ {
+		go n.runDeliveryWorker()
+	}
+	return n
+}
+
+// runDeliveryWorker makes the first delivery attempts off the pipeline runner, slow destinations would not hold
+// the pipelines up
+func (n *NotificationService) runDeliveryWorker() {
+	for delivery := range n.deliveries {
+		err := n.deliver(delivery.notification, delivery.channel)
+		if err != nil {
+			globalPipelineLog.Warn(err, "notification #%d delivery attempt %d failed", delivery.notification.ID, delivery.notification.Attempts)
+		}
 	}
 }
 
-// PipelineNotification FIXME ...
+// SetTimeout sets the timeout of every single delivery attempt
+func (n *NotificationService) SetTimeout(timeout time.Duration) {
+	n.client.Timeout = timeout
+}
+
+// PipelineNotification is the payload sent to the legacy NOTIFICATION_ENDPOINT
 type PipelineNotification struct {
 	PipelineID uint64
 	CreatedAt  time.Time
@@ -59,48 +105,223 @@ type PipelineNotification struct {
 	Status     string
 }
 
-// PipelineStatusChanged FIXME ...
+// NotificationEvent is the payload sent to NotificationChannels
+type NotificationEvent struct {
+	Event        models.NotificationType `json:"event"`
+	PipelineID   uint64                  `json:"pipelineId"`
+	PipelineName string                  `json:"pipelineName"`
+	BlueprintId  uint64                  `json:"blueprintId,omitempty"`
+	ProjectName  string                  `json:"projectName,omitempty"`
+	TaskId       uint64                  `json:"taskId,omitempty"`
+	Plugin       string                  `json:"plugin,omitempty"`
+	Status       string                  `json:"status"`
+	Message      string                  `json:"message,omitempty"`
+	BeganAt      *time.Time              `json:"beganAt,omitempty"`
+	FinishedAt   *time.Time              `json:"finishedAt,omitempty"`
+}
+
+// PipelineStatusChanged sends the legacy notification to NOTIFICATION_ENDPOINT
 func (n *NotificationService) PipelineStatusChanged(params PipelineNotification) errors.Error {
-	return n.sendNotification(models.NotificationPipelineStatusChanged, params)
+	if n.EndPoint == "" {
+		return nil
+	}
+	return n.enqueue(models.NotificationPipelineStatusChanged, nil, params.PipelineID, params)
 }
 
-func (n *NotificationService) sendNotification(notificationType models.NotificationType, data interface{}) errors.Error {
-	var dataJson, err = json.Marshal(data)
+// Notify delivers the event to all channels that are bound to the pipeline and subscribe to the event
+func (n *NotificationService) Notify(event *NotificationEvent) errors.Error {
+	channels, err := findNotificationChannels(event.BlueprintId, event.ProjectName)
 	if err != nil {
-		return errors.Convert(err)
+		return err
+	}
+	for _, channel := range channels {
+		if !channel.Subscribes(event.Event) {
+			continue
+		}
+		err = n.enqueue(event.Event, channel, event.PipelineID, event)
+		if err != nil {
+			globalPipelineLog.Error(err, "failed to notify channel #%d", channel.ID)
+		}
 	}
+	return nil
+}
 
-	var notification models.Notification
-	notification.Data = string(dataJson)
-	notification.Type = notificationType
-	notification.Endpoint = n.EndPoint
-	nonce := randSeq(16)
-	notification.Nonce = nonce
+// enqueue records the notification into database and hands the first delivery attempt over to the workers
+func (n *NotificationService) enqueue(
+	notificationType models.NotificationType,
+	channel *models.NotificationChannel,
+	pipelineId uint64,
+	data interface{},
+) errors.Error {
+	notification, err := n.record(notificationType, channel, pipelineId, data)
+	if err != nil {
+		return err
+	}
+	select {
+	case n.deliveries <- &notificationDelivery{notification: notification, channel: channel}:
+	default:
+		// the retrying loop would deliver it when it is due
+		globalPipelineLog.Warn(nil, "notification queue is full, notification #%d is left for retrying", notification.ID)
+	}
+	return nil
+}
 
-	err = db.Create(&notification)
+// record saves the notification into database as pending
+func (n *NotificationService) record(
+	notificationType models.NotificationType,
+	channel *models.NotificationChannel,
+	pipelineId uint64,
+	data interface{},
+) (*models.Notification, errors.Error) {
+	dataJson, err := errors.Convert01(json.Marshal(data))
+	if err != nil {
+		return nil, err
+	}
+	// the retrying loop would pick it up if the first attempt was interrupted
+	nextRetryAt := time.Now().Add(n.client.Timeout + n.RetryInterval)
+	notification := &models.Notification{
+		Type:        notificationType,
+		PipelineId:  pipelineId,
+		Endpoint:    n.EndPoint,
+		Nonce:       randSeq(16),
+		Data:        string(dataJson),
+		Status:      models.NOTIFICATION_PENDING,
+		NextRetryAt: &nextRetryAt,
+	}
+	if channel != nil {
+		notification.ChannelId = channel.ID
+		notification.Endpoint = channel.Name
+	}
+	err = db.Create(notification)
 	if err != nil {
-		return errors.Convert(err)
+		return nil, err
 	}
+	return notification, nil
+}
 
-	sign := n.signature(notification.Data, fmt.Sprintf("%d-%s", notification.ID, nonce))
-	url := fmt.Sprintf("%s?nouce=%d-%s&sign=%s", n.EndPoint, notification.ID, nonce, sign)
+// deliver makes one attempt to send the notification and schedules the next one if it failed
+func (n *NotificationService) deliver(notification *models.Notification, channel *models.NotificationChannel) errors.Error {
+	var sender notificationSender
+	if channel == nil {
+		sender = &webhookSender{client: n.client, endpoint: n.EndPoint, secret: n.Secret}
+	} else {
+		var err errors.Error
+		sender, err = n.getSender(channel)
+		if err != nil {
+			return n.recordAttempt(notification, 0, "", err)
+		}
+	}
+	responseCode, response, err := sender.send(notification)
+	return n.recordAttempt(notification, responseCode, response, err)
+}
 
-	resp, err := http.Post(url, "application/json", strings.NewReader(notification.Data))
-	if err != nil {
-		return errors.Convert(err)
+func (n *NotificationService) recordAttempt(notification *models.Notification, responseCode int, response string, err errors.Error) errors.Error {
+	notification.Attempts += 1
+	notification.ResponseCode = responseCode
+	notification.Response = response
+	if err == nil {
+		notification.Status = models.NOTIFICATION_SUCCEEDED
+		notification.LastError = ""
+		notification.NextRetryAt = nil
+	} else {
+		notification.LastError = err.Error()
+		if notification.Attempts >= n.MaxAttempts {
+			notification.Status = models.NOTIFICATION_FAILED
+			notification.NextRetryAt = nil
+		} else {
+			nextRetryAt := time.Now().Add(notificationBackoff(n.RetryInterval, notification.Attempts))
+			notification.NextRetryAt = &nextRetryAt
+		}
+	}
+	dbErr := db.Update(notification)
+	if dbErr != nil {
+		return dbErr
 	}
+	return err
+}
+
+func (n *NotificationService) getSender(channel *models.NotificationChannel) (notificationSender, errors.Error) {
+	switch channel.Type {
+	case models.NOTIFICATION_CHANNEL_WEBHOOK:
+		return &webhookSender{client: n.client, endpoint: channel.Endpoint, secret: channel.Secret}, nil
+	case models.NOTIFICATION_CHANNEL_SLACK:
+		return &slackSender{client: n.client, endpoint: channel.Endpoint}, nil
+	case models.NOTIFICATION_CHANNEL_TEAMS:
+		return &teamsSender{client: n.client, endpoint: channel.Endpoint}, nil
+	case models.NOTIFICATION_CHANNEL_EMAIL:
+		settings := &models.EmailChannelSettings{}
+		err := errors.Convert(json.Unmarshal(channel.Settings, settings))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid email settings")
+		}
+		return &emailSender{settings: settings, timeout: n.client.Timeout}, nil
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unsupported notification channel type %s", channel.Type))
+}
 
-	notification.ResponseCode = resp.StatusCode
-	respBody, err := io.ReadAll(resp.Body)
+// RetryPendingNotifications makes another attempt for all notifications that are due for retrying
+func (n *NotificationService) RetryPendingNotifications() errors.Error {
+	var notifications []*models.Notification
+	err := db.All(
+		&notifications,
+		dal.Where("status = ? AND next_retry_at <= ?", models.NOTIFICATION_PENDING, time.Now()),
+		dal.Orderby("id ASC"),
+		dal.Limit(notificationRetryBatchSize),
+	)
 	if err != nil {
-		return errors.Convert(err)
+		return err
+	}
+	for _, notification := range notifications {
+		var channel *models.NotificationChannel
+		if notification.ChannelId != 0 {
+			channel = &models.NotificationChannel{}
+			err = db.First(channel, dal.Where("id = ?", notification.ChannelId))
+			if err != nil {
+				// the channel might be deleted, give up
+				notification.Attempts = n.MaxAttempts - 1
+				_ = n.recordAttempt(notification, 0, "", errors.Default.Wrap(err, "failed to load notification channel"))
+				continue
+			}
+		} else if n.EndPoint == "" {
+			continue
+		}
+		err = n.deliver(notification, channel)
+		if err != nil {
+			globalPipelineLog.Warn(err, "notification #%d delivery attempt %d failed", notification.ID, notification.Attempts)
+		}
+	}
+	return nil
+}
+
+// runNotificationRetryLoop retries pending notifications periodically
+func runNotificationRetryLoop(n *NotificationService) {
+	ticker := time.NewTicker(10 * time.Second)
+	for range ticker.C {
+		// notifications are retried by the leader only to avoid duplicated deliveries
+		if !isLeader() || backgroundJobsPaused.Load() {
+			continue
+		}
+		err := n.RetryPendingNotifications()
+		if err != nil {
+			globalPipelineLog.Error(err, "failed to retry pending notifications")
+		}
+	}
+}
+
+// notificationBackoff doubles the interval after each failed attempt
+func notificationBackoff(interval time.Duration, attempts int) time.Duration {
+	backoff := interval
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxNotificationRetryInterval {
+			return maxNotificationRetryInterval
+		}
 	}
-	notification.Response = string(respBody)
-	return db.Update(notification)
+	return backoff
 }
 
-func (n *NotificationService) signature(input, nouce string) string {
-	sum := sha256.Sum256([]byte(input + n.Secret + nouce))
+func signature(input, secret, nouce string) string {
+	sum := sha256.Sum256([]byte(input + secret + nouce))
 	return hex.EncodeToString(sum[:])
 }
 
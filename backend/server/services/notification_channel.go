@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// NotificationChannelQuery is a query for GetNotificationChannels
+type NotificationChannelQuery struct {
+	Pagination
+	ProjectName string `form:"projectName"`
+	BlueprintId uint64 `form:"blueprintId"`
+}
+
+// CreateNotificationChannel validates and saves the channel into database
+func CreateNotificationChannel(channel *models.NotificationChannel) errors.Error {
+	channel.ID = 0
+	err := validateNotificationChannel(channel)
+	if err != nil {
+		return err
+	}
+	err = db.Create(channel)
+	if err != nil {
+		return errors.Default.Wrap(err, "error creating notification channel")
+	}
+	return nil
+}
+
+// GetNotificationChannels returns a paginated list of NotificationChannels based on `query`
+func GetNotificationChannels(query *NotificationChannelQuery) ([]*models.NotificationChannel, int64, errors.Error) {
+	clauses := []dal.Clause{dal.From(&models.NotificationChannel{})}
+	if query.ProjectName != "" {
+		clauses = append(clauses, dal.Where("project_name = ?", query.ProjectName))
+	}
+	if query.BlueprintId != 0 {
+		clauses = append(clauses, dal.Where("blueprint_id = ?", query.BlueprintId))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of notification channels")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	channels := make([]*models.NotificationChannel, 0)
+	err = db.All(&channels, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting notification channels")
+	}
+	return channels, count, nil
+}
+
+// GetNotificationChannel returns the detail of a given NotificationChannel ID
+func GetNotificationChannel(channelId uint64) (*models.NotificationChannel, errors.Error) {
+	channel := &models.NotificationChannel{}
+	err := db.First(channel, dal.Where("id = ?", channelId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("notification channel not found")
+		}
+		return nil, errors.Internal.Wrap(err, "error getting the notification channel from database")
+	}
+	return channel, nil
+}
+
+// PatchNotificationChannel updates the channel with the fields in body
+func PatchNotificationChannel(channelId uint64, body map[string]interface{}) (*models.NotificationChannel, errors.Error) {
+	channel, err := GetNotificationChannel(channelId)
+	if err != nil {
+		return nil, err
+	}
+	// the client may send back the masked endpoint it got, keep the stored one in that case
+	if endpoint, ok := body["endpoint"].(string); ok && endpoint == maskEndpoint(channel.Endpoint) {
+		delete(body, "endpoint")
+	}
+	err = helper.DecodeMapStruct(body, channel)
+	if err != nil {
+		return nil, err
+	}
+	channel.ID = channelId
+	err = validateNotificationChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(channel)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error updating notification channel")
+	}
+	return channel, nil
+}
+
+// DeleteNotificationChannel removes the channel, pending notifications of the channel would be given up
+func DeleteNotificationChannel(channelId uint64) errors.Error {
+	channel, err := GetNotificationChannel(channelId)
+	if err != nil {
+		return err
+	}
+	return db.Delete(channel)
+}
+
+// TestNotificationChannel sends a fake PipelineFinished event to the channel synchronously
+func TestNotificationChannel(channelId uint64) errors.Error {
+	channel, err := GetNotificationChannel(channelId)
+	if err != nil {
+		return err
+	}
+	notification, err := notificationService.record(models.NotificationPipelineFinished, channel, 0, &NotificationEvent{
+		Event:        models.NotificationPipelineFinished,
+		PipelineName: "test notification",
+		BlueprintId:  channel.BlueprintId,
+		ProjectName:  channel.ProjectName,
+		Status:       models.TASK_COMPLETED,
+		Message:      fmt.Sprintf("this is a test notification for channel %s", channel.Name),
+	})
+	if err != nil {
+		return err
+	}
+	return notificationService.deliver(notification, channel)
+}
+
+// SanitizeNotificationChannel hides secrets of the channel before it is sent to the client
+func SanitizeNotificationChannel(channel *models.NotificationChannel) *models.NotificationChannel {
+	sanitized := *channel
+	sanitized.Secret = ""
+	sanitized.Endpoint = maskEndpoint(channel.Endpoint)
+	if sanitized.Type == models.NOTIFICATION_CHANNEL_EMAIL && len(sanitized.Settings) > 0 {
+		settings := &models.EmailChannelSettings{}
+		if json.Unmarshal(sanitized.Settings, settings) == nil {
+			settings.Password = ""
+			sanitized.Settings, _ = json.Marshal(settings)
+		}
+	}
+	return &sanitized
+}
+
+// maskEndpoint keeps only the scheme and the host of the endpoint, the path and the query of a slack or
+// teams webhook are the credential itself
+func maskEndpoint(endpoint string) string {
+	if endpoint == "" {
+		return ""
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "***"
+	}
+	if u.Path == "" && u.RawQuery == "" && u.User == nil {
+		return endpoint
+	}
+	return fmt.Sprintf("%s://%s/***", u.Scheme, u.Host)
+}
+
+func validateNotificationChannel(channel *models.NotificationChannel) errors.Error {
+	err := VerifyStruct(channel)
+	if err != nil {
+		return err
+	}
+	if channel.Type == models.NOTIFICATION_CHANNEL_EMAIL {
+		settings := &models.EmailChannelSettings{}
+		err = errors.Convert(json.Unmarshal(channel.Settings, settings))
+		if err != nil {
+			return errors.BadInput.Wrap(err, "invalid email settings")
+		}
+		return VerifyStruct(settings)
+	}
+	if channel.Endpoint == "" {
+		return errors.BadInput.New("endpoint is required")
+	}
+	return nil
+}
+
+// findNotificationChannels returns enabled channels bound to the blueprint, the project or to nothing at all
+func findNotificationChannels(blueprintId uint64, projectName string) ([]*models.NotificationChannel, errors.Error) {
+	channels := make([]*models.NotificationChannel, 0)
+	err := db.All(
+		&channels,
+		dal.Where(
+			"enable = ? AND ((project_name = '' AND blueprint_id = 0) OR (blueprint_id <> 0 AND blueprint_id = ?) OR (project_name <> '' AND project_name = ?))",
+			true, blueprintId, projectName,
+		),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting notification channels")
+	}
+	return channels, nil
+}
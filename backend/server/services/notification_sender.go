@@ -0,0 +1,276 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// notificationSender sends a recorded notification to a specific kind of destination
+type notificationSender interface {
+	send(notification *models.Notification) (responseCode int, response string, err errors.Error)
+}
+
+// webhookSender posts the signed json payload to an arbitrary endpoint
+type webhookSender struct {
+	client   *http.Client
+	endpoint string
+	secret   string
+}
+
+func (s *webhookSender) send(notification *models.Notification) (int, string, errors.Error) {
+	nonce := fmt.Sprintf("%d-%s", notification.ID, notification.Nonce)
+	sign := signature(notification.Data, s.secret, nonce)
+	separator := "?"
+	if strings.Contains(s.endpoint, "?") {
+		separator = "&"
+	}
+	url := fmt.Sprintf("%s%snouce=%s&sign=%s", s.endpoint, separator, nonce, sign)
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(notification.Data))
+	if err != nil {
+		return 0, "", errors.Convert(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-DevLake-Event", string(notification.Type))
+	return doNotificationRequest(s.client, req)
+}
+
+// slackSender posts a message to a Slack-compatible incoming webhook
+type slackSender struct {
+	client   *http.Client
+	endpoint string
+}
+
+func (s *slackSender) send(notification *models.Notification) (int, string, errors.Error) {
+	event, err := decodeNotificationEvent(notification)
+	if err != nil {
+		return 0, "", err
+	}
+	title, lines := formatNotificationEvent(event)
+	text := fmt.Sprintf("*%s*\n%s", title, strings.Join(lines, "\n"))
+	return postNotificationJson(s.client, s.endpoint, map[string]interface{}{"text": text})
+}
+
+// teamsSender posts a MessageCard to a Microsoft Teams-compatible incoming webhook
+type teamsSender struct {
+	client   *http.Client
+	endpoint string
+}
+
+func (s *teamsSender) send(notification *models.Notification) (int, string, errors.Error) {
+	event, err := decodeNotificationEvent(notification)
+	if err != nil {
+		return 0, "", err
+	}
+	title, lines := formatNotificationEvent(event)
+	themeColor := "2EB67D"
+	if event.Status == models.TASK_FAILED || event.Event == models.NotificationTaskFailed {
+		themeColor = "E01E5A"
+	}
+	return postNotificationJson(s.client, s.endpoint, map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    title,
+		"themeColor": themeColor,
+		"title":      title,
+		"text":       strings.Join(lines, "<br>"),
+	})
+}
+
+// emailSender sends the notification via SMTP
+type emailSender struct {
+	settings *models.EmailChannelSettings
+	timeout  time.Duration
+}
+
+func (s *emailSender) send(notification *models.Notification) (int, string, errors.Error) {
+	event, err := decodeNotificationEvent(notification)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(s.settings.To) == 0 {
+		return 0, "", errors.BadInput.New("no recipient for email notification")
+	}
+	title, lines := formatNotificationEvent(event)
+	var msg bytes.Buffer
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", s.settings.From))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(s.settings.To, ", ")))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", encodeHeader(title)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	msg.WriteString(strings.Join(lines, "\r\n"))
+	var auth smtp.Auth
+	if s.settings.Username != "" {
+		auth = smtp.PlainAuth("", s.settings.Username, s.settings.Password, s.settings.Host)
+	}
+	e := s.sendMail(auth, msg.Bytes())
+	if e != nil {
+		return 0, "", errors.Default.Wrap(e, "failed to send email")
+	}
+	return 0, "", nil
+}
+
+// sendMail does what smtp.SendMail does, but gives up once the timeout elapsed instead of hanging on an
+// unresponsive server
+func (s *emailSender) sendMail(auth smtp.Auth, msg []byte) error {
+	timeout := s.timeout
+	if timeout <= 0 {
+		timeout = defaultNotificationTimeout
+	}
+	addr := net.JoinHostPort(s.settings.Host, strconv.Itoa(s.settings.Port))
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	err = conn.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, s.settings.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		err = c.StartTLS(&tls.Config{ServerName: s.settings.Host})
+		if err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		err = c.Auth(auth)
+		if err != nil {
+			return err
+		}
+	}
+	err = c.Mail(s.settings.From)
+	if err != nil {
+		return err
+	}
+	for _, to := range s.settings.To {
+		err = c.Rcpt(to)
+		if err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(msg)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// encodeHeader keeps the value on a single line, names of pipelines are given by users and must not inject
+// headers, and encodes it in case it is not plain ascii
+func encodeHeader(value string) string {
+	value = strings.Join(strings.FieldsFunc(value, func(r rune) bool { return r == '\r' || r == '\n' }), " ")
+	return mime.QEncoding.Encode("utf-8", value)
+}
+
+func decodeNotificationEvent(notification *models.Notification) (*NotificationEvent, errors.Error) {
+	event := &NotificationEvent{}
+	err := errors.Convert(json.Unmarshal([]byte(notification.Data), event))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to decode notification data")
+	}
+	return event, nil
+}
+
+// formatNotificationEvent renders the event into a title and a list of human-readable lines
+func formatNotificationEvent(event *NotificationEvent) (string, []string) {
+	var title string
+	switch event.Event {
+	case models.NotificationPipelineStarted:
+		title = fmt.Sprintf("[DevLake] Pipeline #%d %s started", event.PipelineID, event.PipelineName)
+	case models.NotificationTaskFailed:
+		title = fmt.Sprintf("[DevLake] Task #%d (%s) of pipeline #%d %s failed", event.TaskId, event.Plugin, event.PipelineID, event.PipelineName)
+	default:
+		title = fmt.Sprintf("[DevLake] Pipeline #%d %s finished with %s", event.PipelineID, event.PipelineName, event.Status)
+	}
+	lines := []string{fmt.Sprintf("Status: %s", event.Status)}
+	if event.ProjectName != "" {
+		lines = append(lines, fmt.Sprintf("Project: %s", event.ProjectName))
+	}
+	if event.BlueprintId != 0 {
+		lines = append(lines, fmt.Sprintf("Blueprint: #%d", event.BlueprintId))
+	}
+	if event.BeganAt != nil {
+		lines = append(lines, fmt.Sprintf("Began at: %s", event.BeganAt.Format(time.RFC3339)))
+	}
+	if event.FinishedAt != nil {
+		lines = append(lines, fmt.Sprintf("Finished at: %s", event.FinishedAt.Format(time.RFC3339)))
+	}
+	if event.Message != "" {
+		lines = append(lines, fmt.Sprintf("Message: %s", event.Message))
+	}
+	return title, lines
+}
+
+func postNotificationJson(client *http.Client, endpoint string, body interface{}) (int, string, errors.Error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return 0, "", errors.Convert(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, "", errors.Convert(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doNotificationRequest(client, req)
+}
+
+func doNotificationRequest(client *http.Client, req *http.Request) (int, string, errors.Error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", errors.Convert(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, "", errors.Convert(err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.StatusCode, string(respBody), errors.Default.New(
+			fmt.Sprintf("notification endpoint responded with status %d", resp.StatusCode),
+		)
+	}
+	return resp.StatusCode, string(respBody), nil
+}
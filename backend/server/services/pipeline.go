@@ -28,14 +28,17 @@ import (
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/metrics"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/worker/app"
 	"github.com/google/uuid"
 	v11 "go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -50,14 +53,29 @@ type PipelineQuery struct {
 	Pending     int    `form:"pending"`
 	BlueprintId uint64 `uri:"blueprintId" form:"blueprint_id"`
 	Label       string `form:"label"`
+	// ProjectNames limits the result to pipelines of blueprints of the given projects when it is not nil
+	ProjectNames []string `form:"-"`
 }
 
 func pipelineServiceInit() {
 	// notification
 	var notificationEndpoint = cfg.GetString("NOTIFICATION_ENDPOINT")
 	var notificationSecret = cfg.GetString("NOTIFICATION_SECRET")
-	if strings.TrimSpace(notificationEndpoint) != "" {
-		notificationService = NewNotificationService(notificationEndpoint, notificationSecret)
+	notificationService = NewNotificationService(strings.TrimSpace(notificationEndpoint), notificationSecret)
+	if notificationTimeout := cfg.GetInt("NOTIFICATION_TIMEOUT_SECONDS"); notificationTimeout > 0 {
+		notificationService.SetTimeout(time.Duration(notificationTimeout) * time.Second)
+	}
+	if notificationMaxAttempts := cfg.GetInt("NOTIFICATION_MAX_ATTEMPTS"); notificationMaxAttempts > 0 {
+		notificationService.MaxAttempts = notificationMaxAttempts
+	}
+	if notificationRetryInterval := cfg.GetInt("NOTIFICATION_RETRY_INTERVAL_SECONDS"); notificationRetryInterval > 0 {
+		notificationService.RetryInterval = time.Duration(notificationRetryInterval) * time.Second
+	}
+	go runNotificationRetryLoop(notificationService)
+
+	// expose pipeline/task statistics of the database to prometheus
+	if err := metrics.Register(newDbStatsCollector()); err != nil {
+		globalPipelineLog.Warn(errors.Convert(err), "failed to register database metrics collector")
 	}
 
 	// temporal client
@@ -66,42 +84,13 @@ func pipelineServiceInit() {
 		// TODO: logger
 		var err error
 		temporalClient, err = client.NewClient(client.Options{
-			HostPort: temporalUrl,
+			HostPort:           temporalUrl,
+			ContextPropagators: []workflow.ContextPropagator{app.NewTracingPropagator()},
 		})
 		if err != nil {
 			panic(err)
 		}
 		watchTemporalPipelines()
-	} else {
-		// standalone mode: reset pipeline status
-		errMsg := "The process was terminated unexpectedly"
-		err := db.UpdateColumns(
-			&models.Pipeline{},
-			[]dal.DalSet{
-				{ColumnName: "status", Value: models.TASK_FAILED},
-				{ColumnName: "message", Value: errMsg},
-			},
-			dal.Where("status = ?", models.TASK_RUNNING),
-		)
-		if err != nil {
-			panic(err)
-		}
-		err = db.UpdateColumns(
-			&models.Task{},
-			[]dal.DalSet{
-				{ColumnName: "status", Value: models.TASK_FAILED},
-				{ColumnName: "message", Value: errMsg},
-			},
-			dal.Where("status = ?", models.TASK_RUNNING),
-		)
-		if err != nil {
-			panic(err)
-		}
-	}
-
-	err := ReloadBlueprints(cronManager)
-	if err != nil {
-		panic(err)
 	}
 
 	var pipelineMaxParallel = cfg.GetInt64("PIPELINE_MAX_PARALLEL")
@@ -112,8 +101,8 @@ func pipelineServiceInit() {
 		globalPipelineLog.Warn(nil, `pipelineMaxParallel=0 means pipeline will be run No Limit`)
 		pipelineMaxParallel = 10000
 	}
-	// run pipeline with independent goroutine
-	go RunPipelineInQueue(pipelineMaxParallel)
+	// the leader runs the blueprint cron and the pipeline queue
+	startLeaderElection(pipelineMaxParallel)
 }
 
 // CreatePipeline and return the model
@@ -186,6 +175,16 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 			// prepare query to find an appropriate pipeline to execute
 			err := db.First(dbPipeline,
 				dal.Where("status IN ?", []string{models.TASK_CREATED, models.TASK_RERUN}),
+				// hold the pipeline while another one of the same blueprint is still running, i.e. being replaced
+				dal.Where(
+					`NOT EXISTS (
+						SELECT 1 FROM _devlake_pipelines rp
+						JOIN _devlake_blueprints ON _devlake_blueprints.id = rp.blueprint_id
+						WHERE rp.blueprint_id = _devlake_pipelines.blueprint_id AND rp.status = ? AND
+							(_devlake_blueprints.concurrency_policy IS NULL OR _devlake_blueprints.concurrency_policy != ?)
+					)`,
+					models.TASK_RUNNING, models.BLUEPRINT_CONCURRENCY_ALLOW,
+				),
 				dal.Join(
 					`left join _devlake_pipeline_labels ON
 						_devlake_pipeline_labels.pipeline_id = _devlake_pipelines.id AND
@@ -211,15 +210,27 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 			time.Sleep(time.Second)
 		}
 
-		// mark the pipeline running
+		// mark the pipeline running unless it was paused by another instance since it was picked
 		err = db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
 			{ColumnName: "status", Value: models.TASK_RUNNING},
 			{ColumnName: "message", Value: ""},
 			{ColumnName: "began_at", Value: time.Now()},
-		}, dal.Where("id = ?", dbPipeline.ID))
+		}, dal.Where("id = ? AND status IN ?", dbPipeline.ID, []string{models.TASK_CREATED, models.TASK_RERUN}))
 		if err != nil {
 			panic(err)
 		}
+		err = db.First(dbPipeline, dal.Where("id = ?", dbPipeline.ID))
+		if err != nil {
+			panic(err)
+		}
+		if dbPipeline.Status != models.TASK_RUNNING {
+			globalPipelineLog.Info("pipeline #%d turned %s before running, skip it", dbPipeline.ID, dbPipeline.Status)
+			sema.Release(1)
+			continue
+		}
+		go func(pipelineId uint64) {
+			_ = NotifyPipelineStarted(pipelineId)
+		}(dbPipeline.ID)
 
 		// add pipelineParallelLabels to runningParallelLabels
 		var pipelineParallelLabels []string
@@ -259,6 +270,10 @@ func watchTemporalPipelines() {
 	go func() {
 		// run forever
 		for range ticker.C {
+			// only the leader keeps the status in sync with temporal
+			if !isLeader() {
+				continue
+			}
 			// load all running pipeline from database
 			runningDbPipelines := make([]models.Pipeline, 0)
 			err := db.All(&runningDbPipelines, dal.Where("status = ?", models.TASK_RUNNING))
@@ -348,7 +363,8 @@ func getTemporalWorkflowId(pipelineId uint64) string {
 	return fmt.Sprintf("pipeline #%d", pipelineId)
 }
 
-// NotifyExternal FIXME ...
+// NotifyExternal sends the PipelineFinished event to notification channels and the legacy PipelineStatusChanged
+// notification to NOTIFICATION_ENDPOINT
 func NotifyExternal(pipelineId uint64) errors.Error {
 	if notificationService == nil {
 		return nil
@@ -368,9 +384,72 @@ func NotifyExternal(pipelineId uint64) errors.Error {
 	})
 	if err != nil {
 		globalPipelineLog.Error(err, "failed to send notification: %v", err)
+	}
+	e := notifyPipelineEvent(models.NotificationPipelineFinished, pipeline, nil)
+	if err == nil {
+		err = e
+	}
+	return err
+}
+
+// NotifyPipelineStarted sends the PipelineStarted event to notification channels
+func NotifyPipelineStarted(pipelineId uint64) errors.Error {
+	if notificationService == nil {
+		return nil
+	}
+	pipeline, err := GetPipeline(pipelineId)
+	if err != nil {
 		return err
 	}
-	return nil
+	return notifyPipelineEvent(models.NotificationPipelineStarted, pipeline, nil)
+}
+
+// NotifyTaskFailed sends the TaskFailed event to notification channels
+func NotifyTaskFailed(taskId uint64) errors.Error {
+	if notificationService == nil {
+		return nil
+	}
+	task, err := GetTask(taskId)
+	if err != nil {
+		return err
+	}
+	pipeline, err := GetPipeline(task.PipelineId)
+	if err != nil {
+		return err
+	}
+	return notifyPipelineEvent(models.NotificationTaskFailed, pipeline, task)
+}
+
+func notifyPipelineEvent(notificationType models.NotificationType, pipeline *models.Pipeline, task *models.Task) errors.Error {
+	event := &NotificationEvent{
+		Event:        notificationType,
+		PipelineID:   pipeline.ID,
+		PipelineName: pipeline.Name,
+		BlueprintId:  pipeline.BlueprintId,
+		Status:       pipeline.Status,
+		Message:      pipeline.Message,
+		BeganAt:      pipeline.BeganAt,
+		FinishedAt:   pipeline.FinishedAt,
+	}
+	if task != nil {
+		event.TaskId = task.ID
+		event.Plugin = task.Plugin
+		event.Status = task.Status
+		event.Message = task.Message
+		event.BeganAt = task.BeganAt
+		event.FinishedAt = task.FinishedAt
+	}
+	if pipeline.BlueprintId != 0 {
+		blueprint, err := GetDbBlueprint(pipeline.BlueprintId)
+		if err == nil {
+			event.ProjectName = blueprint.ProjectName
+		}
+	}
+	err := notificationService.Notify(event)
+	if err != nil {
+		globalPipelineLog.Error(err, "failed to send %s notification for pipeline #%d", notificationType, pipeline.ID)
+	}
+	return err
 }
 
 // CancelPipeline FIXME ...
@@ -378,11 +457,31 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 	// prevent RunPipelineInQueue from consuming pending pipelines
 	cronLocker.Lock()
 	defer cronLocker.Unlock()
+	return cancelPipeline(pipelineId)
+}
+
+// cancelPipeline cancels the pipeline, cronLocker must be held by the caller
+func cancelPipeline(pipelineId uint64) errors.Error {
 	pipeline := &models.Pipeline{}
 	err := db.First(pipeline, dal.Where("id = ?", pipelineId))
 	if err != nil {
 		return errors.BadInput.New("pipeline not found")
 	}
+	if pipeline.Status == models.TASK_PAUSED {
+		// nothing is running, cancel the tasks which are yet to be finished
+		err = db.UpdateColumn(
+			&models.Task{},
+			"status", models.TASK_CANCELLED,
+			dal.Where("pipeline_id = ? AND status IN ?", pipelineId, []string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_PAUSED}),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, "faile to update pipeline tasks")
+		}
+		return errors.Default.Wrap(
+			db.UpdateColumn(pipeline, "status", models.TASK_CANCELLED),
+			"faile to update pipeline",
+		)
+	}
 	if pipeline.Status == models.TASK_CREATED || pipeline.Status == models.TASK_RERUN {
 		pipeline.Status = models.TASK_CANCELLED
 		err = db.Update(pipeline)
@@ -405,6 +504,14 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 	if temporalClient != nil {
 		return errors.Convert(temporalClient.CancelWorkflow(context.Background(), getTemporalWorkflowId(pipelineId), ""))
 	}
+	if !isLeader() {
+		// running tasks could only be cancelled by the leader running them, which picks the request up later on
+		err = db.UpdateColumn(pipeline, "cancel_requested", true)
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to request cancelling the pipeline")
+		}
+		return nil
+	}
 	pendingTasks, count, err := GetTasks(&TaskQuery{PipelineId: pipelineId, Pending: 1, Pagination: Pagination{PageSize: -1}})
 	if err != nil {
 		return errors.Convert(err)
@@ -418,6 +525,111 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 	return errors.Convert(err)
 }
 
+// processCancelRequests cancels the pipelines requested to be cancelled by the other instances, it runs on the leader
+func processCancelRequests() errors.Error {
+	var pipelineIds []uint64
+	err := db.Pluck("id", &pipelineIds, dal.From(&models.Pipeline{}), dal.Where("cancel_requested = ?", true))
+	if err != nil {
+		return errors.Default.Wrap(err, "error loading pipelines requested to be cancelled")
+	}
+	for _, pipelineId := range pipelineIds {
+		globalPipelineLog.Info("cancelling pipeline #%d as requested", pipelineId)
+		err = CancelPipeline(pipelineId)
+		if err != nil {
+			globalPipelineLog.Error(err, "failed to cancel pipeline #%d as requested", pipelineId)
+		}
+		err = db.UpdateColumn(&models.Pipeline{}, "cancel_requested", false, dal.Where("id = ?", pipelineId))
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error clearing the cancel request of pipeline #%d", pipelineId))
+		}
+	}
+	return nil
+}
+
+// PausePipeline asks a running pipeline to stop at the next subtask boundary, subtasks finished so far
+// are kept and would not be executed again when the pipeline gets resumed. A pending pipeline is paused
+// immediately
+func PausePipeline(pipelineId uint64) errors.Error {
+	// prevent RunPipelineInQueue from consuming the pipeline meanwhile
+	cronLocker.Lock()
+	defer cronLocker.Unlock()
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.New("pipeline not found")
+		}
+		return errors.Default.Wrap(err, "error getting pipeline")
+	}
+	if pipeline.Status == models.TASK_CREATED || pipeline.Status == models.TASK_RERUN {
+		// the cronLocker guards the local queue only, the leader on another instance might pick the pipeline up
+		// meanwhile, so the pipeline is paused only if it is still pending
+		err = db.UpdateColumn(
+			&models.Pipeline{}, "status", models.TASK_PAUSED,
+			dal.Where("id = ? AND status IN ?", pipelineId, []string{models.TASK_CREATED, models.TASK_RERUN}),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to pause pipeline")
+		}
+		err = db.First(pipeline, dal.Where("id = ?", pipelineId))
+		if err != nil {
+			return errors.Default.Wrap(err, "error getting pipeline")
+		}
+		if pipeline.Status == models.TASK_PAUSED {
+			return nil
+		}
+	}
+	if pipeline.Status != models.TASK_RUNNING {
+		return errors.BadInput.New(fmt.Sprintf("pipeline in status %s could not be paused", pipeline.Status))
+	}
+	// the pipeline runner takes care of the status once all running tasks are stopped
+	err = db.UpdateColumn(
+		&models.Pipeline{}, "pause_requested", true,
+		dal.Where("id = ? AND status = ?", pipelineId, models.TASK_RUNNING),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to pause pipeline")
+	}
+	return nil
+}
+
+// ResumePipeline puts a paused pipeline back into the queue, paused tasks would continue from the
+// subtasks they were stopped at
+func ResumePipeline(pipelineId uint64) errors.Error {
+	cronLocker.Lock()
+	defer cronLocker.Unlock()
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.New("pipeline not found")
+		}
+		return errors.Default.Wrap(err, "error getting pipeline")
+	}
+	if pipeline.Status != models.TASK_PAUSED {
+		return errors.BadInput.New("pipeline is not paused")
+	}
+	err = db.UpdateColumns(
+		&models.Task{},
+		[]dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_CREATED},
+			{ColumnName: "resuming", Value: true},
+		},
+		dal.Where("pipeline_id = ? AND status = ?", pipelineId, models.TASK_PAUSED),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to resume pipeline tasks")
+	}
+	err = db.UpdateColumns(pipeline, []dal.DalSet{
+		{ColumnName: "status", Value: models.TASK_CREATED},
+		{ColumnName: "pause_requested", Value: false},
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to resume pipeline")
+	}
+	return nil
+}
+
 // getPipelineLogsPath gets the logs directory of this pipeline
 func getPipelineLogsPath(pipeline *models.Pipeline) (string, errors.Error) {
 	pipelineLog := GetPipelineLogger(pipeline)
@@ -31,49 +31,27 @@ func CreateDbPipeline(newPipeline *models.NewPipeline) (*models.Pipeline, errors
 	cronLocker.Lock()
 	defer cronLocker.Unlock()
 	if newPipeline.BlueprintId > 0 {
-		clauses := []dal.Clause{
-			dal.From(&models.Pipeline{}),
-			dal.Where("blueprint_id = ? AND status IN ?", newPipeline.BlueprintId, models.PendingTaskStatus),
-		}
-		count, err := db.Count(clauses...)
-		if err != nil {
-			return nil, errors.Default.Wrap(err, "query pipelines error")
-		}
-		// some pipeline is ruunning , get the detail and output them.
-		if count > 0 {
-			cursor, err := db.Cursor(clauses...)
-			if err != nil {
-				return nil, errors.Default.Wrap(err, fmt.Sprintf("query pipelines error but count it success. count:%d", count))
-			}
-			defer cursor.Close()
-			fetched := 0
-			errstr := ""
-			for cursor.Next() {
-				pipeline := &models.Pipeline{}
-				err = db.Fetch(cursor, pipeline)
-				if err != nil {
-					return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to Fetch pipelines fetched:[%d],count:[%d]", fetched, count))
-				}
-				fetched++
-
-				errstr += fmt.Sprintf("pipeline:[%d] on state:[%s] Pending it\r\n", pipeline.ID, pipeline.Status)
-			}
-			return nil, errors.Default.New(fmt.Sprintf("the blueprint is running fetched:[%d],count:[%d]:\r\n%s", fetched, count, errstr))
+		if err := applyBlueprintConcurrencyPolicy(newPipeline); err != nil {
+			return nil, err
 		}
 	}
+	if _, err := newPipeline.Plan.Dependencies(); err != nil {
+		return nil, err
+	}
 	planByte, err := errors.Convert01(json.Marshal(newPipeline.Plan))
 	if err != nil {
 		return nil, err
 	}
 	// create pipeline object from posted data
 	dbPipeline := &models.Pipeline{
-		Name:          newPipeline.Name,
-		FinishedTasks: 0,
-		Status:        models.TASK_CREATED,
-		Message:       "",
-		SpentSeconds:  0,
-		Plan:          planByte,
-		SkipOnFail:    newPipeline.SkipOnFail,
+		Name:               newPipeline.Name,
+		FinishedTasks:      0,
+		Status:             models.TASK_CREATED,
+		Message:            "",
+		SpentSeconds:       0,
+		Plan:               planByte,
+		SkipOnFail:         newPipeline.SkipOnFail,
+		MaxConcurrentTasks: newPipeline.MaxConcurrentTasks,
 	}
 	if newPipeline.BlueprintId != 0 {
 		dbPipeline.BlueprintId = newPipeline.BlueprintId
@@ -155,6 +133,12 @@ func GetDbPipelines(query *PipelineQuery) ([]*models.Pipeline, int64, errors.Err
 			dal.Where("pl.name = ?", query.Label),
 		)
 	}
+	if query.ProjectNames != nil {
+		clauses = append(clauses, dal.Where(
+			"blueprint_id IN (SELECT id FROM _devlake_blueprints WHERE project_name IN ?)",
+			query.ProjectNames,
+		))
+	}
 
 	// count total records
 	count, err := db.Count(clauses...)
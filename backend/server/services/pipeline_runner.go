@@ -24,8 +24,10 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/metrics"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/core/tracing"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"github.com/apache/incubator-devlake/worker/app"
 	"go.temporal.io/sdk/client"
@@ -33,16 +35,18 @@ import (
 )
 
 type pipelineRunner struct {
+	// ctx carries the span of the pipeline
+	ctx      context.Context
 	logger   log.Logger
 	pipeline *models.Pipeline
 }
 
 func (p *pipelineRunner) runPipelineStandalone() errors.Error {
-	return runner.RunPipeline(
+	return runner.RunPipelineDag(
 		basicRes.ReplaceLogger(p.logger),
 		p.pipeline.ID,
-		func(taskIds []uint64) errors.Error {
-			return RunTasksStandalone(p.logger, taskIds)
+		func(taskId uint64) errors.Error {
+			return RunTasksStandalone(p.ctx, p.logger, []uint64{taskId})
 		},
 	)
 }
@@ -59,7 +63,7 @@ func (p *pipelineRunner) runPipelineViaTemporal() errors.Error {
 	}
 	p.logger.Info("enqueue pipeline #%d into temporal task queue", p.pipeline.ID)
 	workflow, err := temporalClient.ExecuteWorkflow(
-		context.Background(),
+		p.ctx,
 		workflowOpts,
 		app.DevLakePipelineWorkflow,
 		configJson,
@@ -82,7 +86,7 @@ func (p *pipelineRunner) runPipelineViaTemporal() errors.Error {
 func GetPipelineLogger(pipeline *models.Pipeline) log.Logger {
 	pipelineLogger := globalPipelineLog.Nested(
 		fmt.Sprintf("pipeline #%d", pipeline.ID),
-	)
+	).WithFields(log.Fields{log.FIELD_PIPELINE_ID: pipeline.ID})
 	loggingPath := logruslog.GetPipelineLoggerPath(pipelineLogger.GetConfig(), pipeline)
 	stream, err := logruslog.GetFileStream(loggingPath)
 	if err != nil {
@@ -97,12 +101,22 @@ func GetPipelineLogger(pipeline *models.Pipeline) log.Logger {
 }
 
 // runPipeline start a pipeline actually
-func runPipeline(pipelineId uint64) errors.Error {
+func runPipeline(pipelineId uint64) (err errors.Error) {
 	ppl, err := GetPipeline(pipelineId)
 	if err != nil {
 		return err
 	}
+	ctx, span := tracing.Start(
+		context.Background(),
+		"pipeline",
+		tracing.ATTR_PIPELINE_ID.Int64(int64(ppl.ID)),
+		tracing.ATTR_BLUEPRINT_ID.Int64(int64(ppl.BlueprintId)),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
 	pipelineRun := pipelineRunner{
+		ctx:      ctx,
 		logger:   GetPipelineLogger(ppl),
 		pipeline: ppl,
 	}
@@ -120,6 +134,21 @@ func runPipeline(pipelineId uint64) errors.Error {
 	if e != nil {
 		return errors.Default.Wrap(err, fmt.Sprintf("Unable to get pipeline %d.", pipelineId))
 	}
+	paused, e := isPipelinePaused(dbPipeline)
+	if e != nil {
+		return e
+	}
+	if paused {
+		globalPipelineLog.Info("pipeline #%d paused", pipelineId)
+		return errors.Default.Wrap(
+			db.UpdateColumns(dbPipeline, []dal.DalSet{
+				{ColumnName: "status", Value: models.TASK_PAUSED},
+				{ColumnName: "pause_requested", Value: false},
+			}),
+			"update pipeline state failed",
+		)
+	}
+	dbPipeline.PauseRequested = false
 	// finished, update database
 	finishedAt := time.Now()
 	dbPipeline.FinishedAt = &finishedAt
@@ -140,10 +169,50 @@ func runPipeline(pipelineId uint64) errors.Error {
 		globalPipelineLog.Error(err, "update pipeline state failed")
 		return err
 	}
+	if dbPipeline.BeganAt != nil {
+		metricStatus := dbPipeline.Status
+		if isCancelled {
+			metricStatus = models.TASK_CANCELLED
+		}
+		metrics.ObservePipelineFinished(metricStatus, finishedAt.Sub(*dbPipeline.BeganAt))
+	}
+	// tasks executed by temporal workers are out of our sight, report the failed ones now
+	if temporalClient != nil {
+		notifyFailedTasks(dbPipeline)
+	}
 	// notify external webhook
 	return NotifyExternal(pipelineId)
 }
 
+// isPipelinePaused tells if the pipeline stopped because of pausing, which is when it was asked to
+// and there are tasks left to be resumed
+func isPipelinePaused(pipeline *models.Pipeline) (bool, errors.Error) {
+	if !pipeline.PauseRequested {
+		return false, nil
+	}
+	count, err := db.Count(
+		dal.From(&models.Task{}),
+		dal.Where("pipeline_id = ? AND status IN ?", pipeline.ID, []string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_PAUSED}),
+	)
+	if err != nil {
+		return false, errors.Default.Wrap(err, "failed to count paused tasks")
+	}
+	return count > 0, nil
+}
+
+func notifyFailedTasks(pipeline *models.Pipeline) {
+	tasks, err := GetLatestTasksOfPipeline(pipeline)
+	if err != nil {
+		globalPipelineLog.Error(err, "failed to load tasks of pipeline #%d", pipeline.ID)
+		return
+	}
+	for _, task := range tasks {
+		if task.Status == models.TASK_FAILED {
+			_ = NotifyTaskFailed(task.ID)
+		}
+	}
+}
+
 // ComputePipelineStatus determines pipleline status by its latest(rerun included) tasks statuses
 // 1. TASK_COMPLETED: all tasks were executed sucessfully
 // 2. TASK_FAILED: SkipOnFail=false with failed task(s)
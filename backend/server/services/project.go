@@ -29,6 +29,8 @@ import (
 // ProjectQuery used to query projects as the api project input
 type ProjectQuery struct {
 	Pagination
+	// ProjectNames limits the result to the given projects when it is not nil
+	ProjectNames []string `form:"-"`
 }
 
 // GetProjects returns a paginated list of Projects based on `query`
@@ -40,6 +42,9 @@ func GetProjects(query *ProjectQuery) ([]*models.Project, int64, errors.Error) {
 	clauses := []dal.Clause{
 		dal.From(&models.Project{}),
 	}
+	if query.ProjectNames != nil {
+		clauses = append(clauses, dal.Where("name IN ?", query.ProjectNames))
+	}
 
 	count, err := db.Count(clauses...)
 	if err != nil {
@@ -299,3 +304,57 @@ func makeProjectOutput(baseProject *models.BaseProject) (*models.ApiOutputProjec
 	}
 	return projectOutput, err
 }
+
+// DeleteProject removes the project with its metric settings, project mappings, blueprint and notification channels.
+// Project-scoped metric rows would be removed as well if `query.DeleteMetrics` was set
+func DeleteProject(name string, query *DeletionQuery) (*DeletionReport, errors.Error) {
+	if name == "" {
+		return nil, errors.BadInput.New("project name is missing")
+	}
+	return runDeletion(query.DryRun, func(tx dal.Transaction, report *DeletionReport) errors.Error {
+		project := &models.Project{}
+		err := tx.First(project, dal.Where("name = ?", name), dal.Lock(true, false))
+		if err != nil {
+			if tx.IsErrorNotFound(err) {
+				return errors.NotFound.New(fmt.Sprintf("could not find project [%s] in DB", name))
+			}
+			return errors.Default.Wrap(err, "error getting project from DB")
+		}
+		var blueprintIds []uint64
+		err = tx.Pluck("id", &blueprintIds, dal.From(&models.Blueprint{}), dal.Where("project_name = ?", name))
+		if err != nil {
+			return errors.Default.Wrap(err, "error getting blueprints of the project")
+		}
+		err = ensureNoActivePipeline(tx, blueprintIds)
+		if err != nil {
+			return err
+		}
+		err = report.deleteBlueprints(tx, blueprintIds)
+		if err != nil {
+			return err
+		}
+		err = report.deleteRows(tx, &models.NotificationChannel{}, dal.Where("project_name = ?", name))
+		if err != nil {
+			return err
+		}
+		err = report.deleteRows(tx, &models.ProjectMetricSetting{}, dal.Where("project_name = ?", name))
+		if err != nil {
+			return err
+		}
+		err = report.deleteRows(tx, &crossdomain.ProjectMapping{}, dal.Where("project_name = ?", name))
+		if err != nil {
+			return err
+		}
+		if query.DeleteMetrics {
+			err = report.deleteRows(tx, &crossdomain.ProjectPrMetric{}, dal.Where("project_name = ?", name))
+			if err != nil {
+				return err
+			}
+			err = report.deleteRows(tx, &crossdomain.ProjectIssueMetric{}, dal.Where("project_name = ?", name))
+			if err != nil {
+				return err
+			}
+		}
+		return report.deleteRows(tx, &models.Project{}, dal.Where("name = ?", name))
+	})
+}
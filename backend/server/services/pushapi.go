@@ -18,15 +18,281 @@ limitations under the License.
 package services
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// Modes of the push api
+const (
+	PushModeInsert = "insert"
+	PushModeUpsert = "upsert"
+	PushModeDelete = "delete"
 )
 
-// InsertRow FIXME ...
-func InsertRow(table string, rows []map[string]interface{}) (int64, errors.Error) {
-	err := db.Create(rows, dal.From(table))
+// pushRawDataTable is stamped into `_raw_data_table` of the pushed rows to tell them apart from the collected ones
+const pushRawDataTable = "push_api"
+
+// PushQuery is the query of the push api
+type PushQuery struct {
+	// Mode is one of insert, upsert and delete, defaults to insert
+	Mode string `form:"mode"`
+	// Source is stamped into `_raw_data_params` of the pushed rows, so they could be resent or purged as a whole
+	Source string `form:"source"`
+}
+
+// PushRowError describes why a row was rejected
+type PushRowError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+// PushResult is the outcome of a push
+type PushResult struct {
+	RowsAffected int64           `json:"rowsAffected"`
+	Errors       []*PushRowError `json:"errors"`
+}
+
+// pushTable holds the columns of the table being pushed into
+type pushTable struct {
+	name        string
+	columns     map[string]dal.ColumnMeta
+	primaryKeys []string
+}
+
+// PushRows writes rows into a domain layer table, each row is validated against the columns of the table and
+// written on its own, so a bad row doesn't prevent the others from being written
+func PushRows(tableName string, rows []map[string]interface{}, query *PushQuery) (*PushResult, errors.Error) {
+	mode := query.Mode
+	if mode == "" {
+		mode = PushModeInsert
+	}
+	if mode != PushModeInsert && mode != PushModeUpsert && mode != PushModeDelete {
+		return nil, errors.BadInput.New(fmt.Sprintf("unknown mode %s", mode))
+	}
+	if !isDomainTable(tableName) {
+		return nil, errors.Forbidden.New(fmt.Sprintf("table %s is not a domain layer table", tableName))
+	}
+	columns, err := db.GetColumns(dal.DefaultTabler{Name: tableName}, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error getting columns of table %s", tableName))
+	}
+	table := &pushTable{name: tableName, columns: make(map[string]dal.ColumnMeta, len(columns))}
+	for _, column := range columns {
+		table.columns[column.Name()] = column
+		if isPrimaryKey, ok := column.PrimaryKey(); ok && isPrimaryKey {
+			table.primaryKeys = append(table.primaryKeys, column.Name())
+		}
+	}
+	if mode != PushModeInsert && len(table.primaryKeys) == 0 {
+		return nil, errors.BadInput.New(fmt.Sprintf("table %s has no primary key to %s by", tableName, mode))
+	}
+
+	result := &PushResult{Errors: make([]*PushRowError, 0)}
+	for i, row := range rows {
+		affected, err := table.push(row, mode, query.Source)
+		if err != nil {
+			result.Errors = append(result.Errors, &PushRowError{Index: i, Message: err.Messages().Format()})
+			continue
+		}
+		result.RowsAffected += affected
+	}
+	return result, nil
+}
+
+func isDomainTable(tableName string) bool {
+	for _, table := range domaininfo.GetDomainTablesInfo() {
+		if table.TableName() == tableName {
+			return true
+		}
+	}
+	return false
+}
+
+func (table *pushTable) push(row map[string]interface{}, mode string, source string) (int64, errors.Error) {
+	values := make(map[string]interface{}, len(row))
+	for name, value := range row {
+		column, ok := table.columns[name]
+		if !ok {
+			return 0, errors.Default.New(fmt.Sprintf("unknown column %s", name))
+		}
+		converted, err := convertPushValue(column, value)
+		if err != nil {
+			return 0, errors.Default.Wrap(err, fmt.Sprintf("invalid value of column %s", name))
+		}
+		values[name] = converted
+	}
+	if mode == PushModeInsert {
+		table.stampLineage(values, source)
+		err := db.Create(values, dal.From(table.name))
+		if err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	conditions := make([]string, 0, len(table.primaryKeys))
+	params := make([]interface{}, 0, len(table.primaryKeys))
+	for _, primaryKey := range table.primaryKeys {
+		value, ok := values[primaryKey]
+		if !ok || value == nil {
+			return 0, errors.Default.New(fmt.Sprintf("primary key %s is missing", primaryKey))
+		}
+		conditions = append(conditions, fmt.Sprintf("%s = ?", primaryKey))
+		params = append(params, value)
+	}
+	where := strings.Join(conditions, " AND ")
+
+	// the row is locked from being read to being written, so concurrent pushes of the same row are serialized
+	tx := db.Begin()
+	affected, err := table.write(tx, values, mode, source, dal.Where(where, params...))
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			logger.Error(e, "failed to rollback push to %s", table.name)
+		}
+		return 0, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// write upserts or deletes the row matched by the primary keys within the transaction
+func (table *pushTable) write(tx dal.Transaction, values map[string]interface{}, mode string, source string, where dal.Clause) (int64, errors.Error) {
+	existing := make(map[string]interface{})
+	err := tx.First(&existing, dal.From(table.name), where, dal.Lock(true, false))
+	exists := err == nil
+	if err != nil && !tx.IsErrorNotFound(err) {
+		return 0, err
+	}
+
+	if mode == PushModeDelete {
+		if !exists {
+			return 0, nil
+		}
+		err = tx.Delete(&dal.DefaultTabler{Name: table.name}, dal.From(table.name), where)
+		if err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	table.stampLineage(values, source)
+	if !exists {
+		err = tx.Create(values, dal.From(table.name))
+		if err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	sets := make([]dal.DalSet, 0, len(values))
+	for name, value := range values {
+		if table.isPrimaryKey(name) {
+			continue
+		}
+		sets = append(sets, dal.DalSet{ColumnName: name, Value: value})
+	}
+	if len(sets) == 0 {
+		return 0, nil
+	}
+	err = tx.UpdateColumns(table.name, sets, where)
 	if err != nil {
 		return 0, err
 	}
 	return 1, nil
 }
+
+func (table *pushTable) isPrimaryKey(name string) bool {
+	for _, primaryKey := range table.primaryKeys {
+		if primaryKey == name {
+			return true
+		}
+	}
+	return false
+}
+
+// stampLineage fills the `_raw_data_*` columns unless they were given by the row
+func (table *pushTable) stampLineage(values map[string]interface{}, source string) {
+	lineage := map[string]interface{}{
+		"_raw_data_table":  pushRawDataTable,
+		"_raw_data_params": source,
+	}
+	for name, value := range lineage {
+		if _, ok := table.columns[name]; !ok {
+			continue
+		}
+		if _, ok := values[name]; !ok {
+			values[name] = value
+		}
+	}
+}
+
+// convertPushValue checks the JSON value against the database type of the column, and converts it into
+// the go type expected by the driver
+func convertPushValue(column dal.ColumnMeta, value interface{}) (interface{}, errors.Error) {
+	if value == nil {
+		if nullable, ok := column.Nullable(); ok && !nullable {
+			return nil, errors.Default.New("null is not allowed")
+		}
+		return nil, nil
+	}
+	databaseType := strings.ToUpper(column.DatabaseTypeName())
+	switch {
+	case strings.Contains(databaseType, "BOOL"):
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, errors.Default.New(fmt.Sprintf("%s expects a boolean", databaseType))
+	case strings.Contains(databaseType, "INT") || strings.Contains(databaseType, "SERIAL"):
+		switch v := value.(type) {
+		case bool:
+			// mysql stores booleans as tinyint
+			return v, nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int64(v), nil
+			}
+		}
+		return nil, errors.Default.New(fmt.Sprintf("%s expects an integer", databaseType))
+	case strings.Contains(databaseType, "DECIMAL") || strings.Contains(databaseType, "NUMERIC") ||
+		strings.Contains(databaseType, "FLOAT") || strings.Contains(databaseType, "DOUBLE") ||
+		strings.Contains(databaseType, "REAL"):
+		if f, ok := value.(float64); ok {
+			return f, nil
+		}
+		return nil, errors.Default.New(fmt.Sprintf("%s expects a number", databaseType))
+	case strings.Contains(databaseType, "DATE") || strings.Contains(databaseType, "TIME"):
+		if s, ok := value.(string); ok {
+			t, err := api.ConvertStringToTime(s)
+			if err != nil {
+				return nil, errors.Default.Wrap(err, fmt.Sprintf("%s expects a time", databaseType))
+			}
+			return t, nil
+		}
+		return nil, errors.Default.New(fmt.Sprintf("%s expects a time string", databaseType))
+	case strings.Contains(databaseType, "JSON"):
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "unable to encode json")
+		}
+		return string(b), nil
+	case strings.Contains(databaseType, "CHAR") || strings.Contains(databaseType, "TEXT"):
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return nil, errors.Default.New(fmt.Sprintf("%s expects a string", databaseType))
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return nil, errors.Default.New(fmt.Sprintf("%s doesn't accept objects or arrays", databaseType))
+	}
+	return value, nil
+}
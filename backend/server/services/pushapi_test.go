@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockColumn(name string, databaseType string, primaryKey bool) dal.ColumnMeta {
+	column := new(mockdal.ColumnMeta)
+	column.On("Name").Return(name)
+	column.On("DatabaseTypeName").Return(databaseType)
+	column.On("PrimaryKey").Return(primaryKey, true)
+	column.On("Nullable").Return(!primaryKey, true)
+	return column
+}
+
+func mockPushDal(exists bool) (*mockdal.Dal, *mockdal.Transaction) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("GetColumns", dal.DefaultTabler{Name: "commits"}, mock.Anything).Return([]dal.ColumnMeta{
+		mockColumn("sha", "VARCHAR", true),
+		mockColumn("additions", "BIGINT", false),
+		mockColumn("authored_date", "DATETIME", false),
+		mockColumn("_raw_data_table", "VARCHAR", false),
+		mockColumn("_raw_data_params", "VARCHAR", false),
+	}, nil)
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil)
+	tx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(tx)
+	if exists {
+		tx.On("First", mock.Anything, mock.Anything).Return(nil)
+	} else {
+		tx.On("First", mock.Anything, mock.Anything).Return(errors.NotFound.New("record not found"))
+		tx.On("IsErrorNotFound", mock.Anything).Return(true)
+	}
+	tx.On("Create", mock.Anything, mock.Anything).Return(nil)
+	tx.On("UpdateColumns", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tx.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	tx.On("Commit").Return(nil)
+	tx.On("Rollback").Return(nil)
+	return mockDal, tx
+}
+
+func TestPushRowsInsert(t *testing.T) {
+	mockDal, _ := mockPushDal(false)
+	useMockDal(t, mockDal)
+
+	result, err := PushRows("commits", []map[string]interface{}{
+		{"sha": "a", "additions": float64(3), "authored_date": "2023-03-01T10:00:00Z"},
+		{"sha": "b", "additons": float64(3)},
+		{"sha": "c", "additions": 1.5},
+	}, &PushQuery{Source: "deploy-tool"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	assert.Equal(t, []*PushRowError{
+		{Index: 1, Message: "unknown column additons"},
+		{Index: 2, Message: "invalid value of column additions\ncaused by: BIGINT expects an integer"},
+	}, result.Errors)
+	mockDal.AssertCalled(t, "Create", map[string]interface{}{
+		"sha":              "a",
+		"additions":        int64(3),
+		"authored_date":    time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC),
+		"_raw_data_table":  pushRawDataTable,
+		"_raw_data_params": "deploy-tool",
+	}, []dal.Clause{dal.From("commits")})
+}
+
+func TestPushRowsUpsert(t *testing.T) {
+	mockDal, tx := mockPushDal(true)
+	useMockDal(t, mockDal)
+
+	result, err := PushRows("commits", []map[string]interface{}{
+		{"sha": "a", "additions": float64(3)},
+		{"additions": float64(3)},
+	}, &PushQuery{Mode: PushModeUpsert})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, "primary key sha is missing", result.Errors[0].Message)
+	// the row is locked before it is updated
+	tx.AssertCalled(t, "First", mock.Anything, []dal.Clause{
+		dal.From("commits"), dal.Where("sha = ?", "a"), dal.Lock(true, false),
+	})
+	tx.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	tx.AssertNumberOfCalls(t, "UpdateColumns", 1)
+	tx.AssertNumberOfCalls(t, "Commit", 1)
+	mockDal.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPushRowsUpsertCreates(t *testing.T) {
+	mockDal, tx := mockPushDal(false)
+	useMockDal(t, mockDal)
+
+	result, err := PushRows("commits", []map[string]interface{}{{"sha": "a"}}, &PushQuery{Mode: PushModeUpsert})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	tx.AssertCalled(t, "Create", map[string]interface{}{
+		"sha":              "a",
+		"_raw_data_table":  pushRawDataTable,
+		"_raw_data_params": "",
+	}, []dal.Clause{dal.From("commits")})
+	tx.AssertNumberOfCalls(t, "Commit", 1)
+}
+
+func TestPushRowsDelete(t *testing.T) {
+	mockDal, tx := mockPushDal(true)
+	useMockDal(t, mockDal)
+
+	result, err := PushRows("commits", []map[string]interface{}{{"sha": "a"}}, &PushQuery{Mode: PushModeDelete})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	tx.AssertCalled(t, "Delete", &dal.DefaultTabler{Name: "commits"}, []dal.Clause{dal.From("commits"), dal.Where("sha = ?", "a")})
+	tx.AssertNumberOfCalls(t, "Commit", 1)
+}
+
+func TestPushRowsRollback(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("GetColumns", mock.Anything, mock.Anything).Return([]dal.ColumnMeta{mockColumn("sha", "VARCHAR", true)}, nil)
+	tx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(tx)
+	tx.On("First", mock.Anything, mock.Anything).Return(errors.Default.New("lock wait timeout"))
+	tx.On("IsErrorNotFound", mock.Anything).Return(false)
+	tx.On("Rollback").Return(nil)
+	useMockDal(t, mockDal)
+
+	result, err := PushRows("commits", []map[string]interface{}{{"sha": "a"}}, &PushQuery{Mode: PushModeUpsert})
+	assert.Nil(t, err)
+	assert.Len(t, result.Errors, 1)
+	tx.AssertNumberOfCalls(t, "Rollback", 1)
+	tx.AssertNotCalled(t, "Commit")
+}
+
+func TestPushRowsRejectsFrameworkTables(t *testing.T) {
+	useMockDal(t, new(mockdal.Dal))
+	_, err := PushRows("_devlake_pipelines", []map[string]interface{}{{"id": float64(1)}}, &PushQuery{})
+	assert.NotNil(t, err)
+	assert.Equal(t, errors.Forbidden, err.GetType())
+	_, err = PushRows("commits", nil, &PushQuery{Mode: "merge"})
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
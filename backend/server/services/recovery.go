@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+const (
+	STRANDED_PIPELINE_ACTION_FAIL    = "fail"
+	STRANDED_PIPELINE_ACTION_REQUEUE = "requeue"
+)
+
+const strandedMessage = "interrupted since the DevLake server was terminated unexpectedly"
+
+// recoverStrandedPipelines reconciles the pipelines, tasks and subtasks left in TASK_RUNNING by a terminated
+// process, it must be called before the pipeline queue gets started. STRANDED_PIPELINE_ACTION decides what to do:
+//
+//   - fail (default): mark them failed along with the subtasks being interrupted
+//   - requeue: put the pipelines back to the queue, their tasks would be resumed from the interrupted subtasks
+//     since the subtasks finished before are skipped
+func recoverStrandedPipelines() errors.Error {
+	action := strings.ToLower(strings.TrimSpace(cfg.GetString("STRANDED_PIPELINE_ACTION")))
+	requeue := false
+	switch action {
+	case "", STRANDED_PIPELINE_ACTION_FAIL:
+	case STRANDED_PIPELINE_ACTION_REQUEUE:
+		requeue = true
+	default:
+		return errors.BadInput.New(fmt.Sprintf("unknown STRANDED_PIPELINE_ACTION %s", action))
+	}
+	now := time.Now()
+
+	// tasks, with the subtasks being interrupted
+	var tasks []*models.Task
+	err := db.All(&tasks, dal.Where("status = ?", models.TASK_RUNNING))
+	if err != nil {
+		return errors.Default.Wrap(err, "error loading stranded tasks")
+	}
+	for _, task := range tasks {
+		var interrupted []string
+		err = db.Pluck("name", &interrupted,
+			dal.From(&models.Subtask{}),
+			dal.Where("task_id = ? AND status = ?", task.ID, models.TASK_RUNNING),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error loading interrupted subtasks of task #%d", task.ID))
+		}
+		message := strandedMessage
+		if len(interrupted) > 0 {
+			message = fmt.Sprintf("subtask %s was %s", strings.Join(interrupted, ","), strandedMessage)
+		}
+		set := []dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_RERUN},
+			{ColumnName: "message", Value: "re-queued, " + message},
+			{ColumnName: "resuming", Value: true},
+		}
+		if !requeue {
+			set = []dal.DalSet{
+				{ColumnName: "status", Value: models.TASK_FAILED},
+				{ColumnName: "message", Value: message},
+				{ColumnName: "failed_sub_task", Value: strings.Join(interrupted, ",")},
+				{ColumnName: "finished_at", Value: now},
+			}
+		}
+		err = db.UpdateColumns(task, set)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error recovering stranded task #%d", task.ID))
+		}
+	}
+
+	// subtasks are marked failed either way, the interrupted ones would be executed again once resumed
+	err = db.UpdateColumns(
+		&models.Subtask{},
+		[]dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_FAILED},
+			{ColumnName: "message", Value: strandedMessage},
+			{ColumnName: "finished_at", Value: now},
+		},
+		dal.Where("status = ?", models.TASK_RUNNING),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "error recovering stranded subtasks")
+	}
+
+	// pipelines
+	set := []dal.DalSet{
+		{ColumnName: "status", Value: models.TASK_RERUN},
+		{ColumnName: "message", Value: "re-queued, " + strandedMessage},
+	}
+	if !requeue {
+		set = []dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_FAILED},
+			{ColumnName: "message", Value: strandedMessage + ", set STRANDED_PIPELINE_ACTION=requeue to resume such pipelines automatically"},
+			{ColumnName: "finished_at", Value: now},
+		}
+	}
+	count, err := db.Count(dal.From(&models.Pipeline{}), dal.Where("status = ?", models.TASK_RUNNING))
+	if err != nil {
+		return errors.Default.Wrap(err, "error counting stranded pipelines")
+	}
+	if count == 0 {
+		return nil
+	}
+	globalPipelineLog.Warn(nil, "%d pipelines were %s, mark them as %s", count, strandedMessage, set[0].Value)
+	err = db.UpdateColumns(&models.Pipeline{}, set, dal.Where("status = ?", models.TASK_RUNNING))
+	if err != nil {
+		return errors.Default.Wrap(err, "error recovering stranded pipelines")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockStrandedDal(updates map[string][]dal.DalSet) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("All", mock.AnythingOfType("*[]*models.Task"), mock.Anything).Run(func(args mock.Arguments) {
+		task := &models.Task{Plugin: "jira", Status: models.TASK_RUNNING}
+		task.ID = 1
+		*args.Get(0).(*[]*models.Task) = []*models.Task{task}
+	}).Return(nil)
+	mockDal.On("Pluck", "name", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]string) = []string{"collectIssues"}
+	}).Return(nil)
+	mockDal.On("Count", mock.Anything).Return(int64(1), nil)
+	mockDal.On("UpdateColumns", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		updates[args.Get(0).(dal.Tabler).TableName()] = args.Get(1).([]dal.DalSet)
+	}).Return(nil)
+	return mockDal
+}
+
+func getDalSetValue(set []dal.DalSet, column string) interface{} {
+	for _, s := range set {
+		if s.ColumnName == column {
+			return s.Value
+		}
+	}
+	return nil
+}
+
+func TestRecoverStrandedPipelinesFail(t *testing.T) {
+	useConfig(t, viper.New())
+	updates := make(map[string][]dal.DalSet)
+	useMockDal(t, mockStrandedDal(updates))
+
+	assert.Nil(t, recoverStrandedPipelines())
+	task := updates[models.Task{}.TableName()]
+	assert.Equal(t, models.TASK_FAILED, getDalSetValue(task, "status"))
+	assert.Equal(t, "collectIssues", getDalSetValue(task, "failed_sub_task"))
+	assert.Contains(t, getDalSetValue(task, "message"), "subtask collectIssues was interrupted")
+	assert.Equal(t, models.TASK_FAILED, getDalSetValue(updates[models.Subtask{}.TableName()], "status"))
+	assert.Equal(t, models.TASK_FAILED, getDalSetValue(updates[models.Pipeline{}.TableName()], "status"))
+}
+
+func TestRecoverStrandedPipelinesRequeue(t *testing.T) {
+	v := viper.New()
+	v.Set("STRANDED_PIPELINE_ACTION", "requeue")
+	useConfig(t, v)
+	updates := make(map[string][]dal.DalSet)
+	useMockDal(t, mockStrandedDal(updates))
+
+	assert.Nil(t, recoverStrandedPipelines())
+	task := updates[models.Task{}.TableName()]
+	assert.Equal(t, models.TASK_RERUN, getDalSetValue(task, "status"))
+	assert.Nil(t, getDalSetValue(task, "failed_sub_task"))
+	// the interrupted subtask is executed again while the finished ones are skipped
+	assert.Equal(t, models.TASK_FAILED, getDalSetValue(updates[models.Subtask{}.TableName()], "status"))
+	assert.Equal(t, models.TASK_RERUN, getDalSetValue(updates[models.Pipeline{}.TableName()], "status"))
+}
+
+func TestRecoverStrandedPipelinesInvalidAction(t *testing.T) {
+	v := viper.New()
+	v.Set("STRANDED_PIPELINE_ACTION", "ignore")
+	useConfig(t, v)
+	err := recoverStrandedPipelines()
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
@@ -20,11 +20,13 @@ package bridge
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/tracing"
 	"github.com/apache/incubator-devlake/core/utils"
 )
 
@@ -51,6 +53,8 @@ func (c *CmdInvoker) Call(methodName string, ctx plugin.ExecContext, args ...any
 	executable, inputArgs := c.resolveCmd(methodName, serializedArgs...)
 	cmdCtx := DefaultContext.GetContext()
 	cmd := exec.CommandContext(cmdCtx, executable, inputArgs...)
+	// the remote plugin picks up the trace context from the environment
+	cmd.Env = append(os.Environ(), tracing.Environ(ctx.GetContext())...)
 	if c.workingPath != "" {
 		cmd.Dir = c.workingPath
 	}
@@ -91,6 +95,8 @@ func (c *CmdInvoker) Stream(methodName string, ctx plugin.ExecContext, args ...a
 	executable, inputArgs := c.resolveCmd(methodName, serializedArgs...)
 	cmdCtx := DefaultContext.GetContext() // grabbing context off of ctx kills the cmd after a couple of seconds... why?
 	cmd := exec.CommandContext(cmdCtx, executable, inputArgs...)
+	// the remote plugin picks up the trace context from the environment
+	cmd.Env = append(os.Environ(), tracing.Environ(ctx.GetContext())...)
 	if c.workingPath != "" {
 		cmd.Dir = c.workingPath
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/runner"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"regexp"
 	"strings"
@@ -150,6 +151,30 @@ func GetTasksWithLastStatus(pipelineId uint64) ([]*models.Task, errors.Error) {
 	return result, nil
 }
 
+// FillSubtaskDetails loads execution records of subtasks, including their metrics, into the tasks
+func FillSubtaskDetails(tasks []*models.Task) errors.Error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	taskIds := make([]uint64, 0, len(tasks))
+	tasksById := make(map[uint64]*models.Task, len(tasks))
+	for _, task := range tasks {
+		taskIds = append(taskIds, task.ID)
+		tasksById[task.ID] = task
+		task.SubtaskDetails = make([]*models.Subtask, 0)
+	}
+	var subtasks []*models.Subtask
+	err := db.All(&subtasks, dal.Where("task_id IN ?", taskIds), dal.Orderby("task_id, number, id"))
+	if err != nil {
+		return errors.Default.Wrap(err, "error getting subtasks")
+	}
+	for _, subtask := range subtasks {
+		task := tasksById[subtask.TaskID]
+		task.SubtaskDetails = append(task.SubtaskDetails, subtask)
+	}
+	return nil
+}
+
 // GetTask FIXME ...
 func GetTask(taskId uint64) (*models.Task, errors.Error) {
 	task := &models.Task{}
@@ -174,7 +199,7 @@ func CancelTask(taskId uint64) errors.Error {
 }
 
 // RunTasksStandalone run tasks in parallel
-func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error {
+func RunTasksStandalone(ctx context.Context, parentLogger log.Logger, taskIds []uint64) errors.Error {
 	if len(taskIds) == 0 {
 		return nil
 	}
@@ -183,9 +208,12 @@ func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error
 		go func(id uint64) {
 			taskLog.Info("run task #%d in background ", id)
 			var err errors.Error
-			taskErr := runTaskStandalone(parentLogger, id)
+			taskErr := runTaskStandalone(ctx, parentLogger, id)
 			if taskErr != nil {
 				err = errors.Default.Wrap(taskErr, fmt.Sprintf("Error running task %d.", id))
+				if !errors.Is(taskErr, context.Canceled) && !errors.Is(taskErr, runner.ErrTaskPaused) {
+					_ = NotifyTaskFailed(id)
+				}
 			}
 			results <- err
 		}(taskId)
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/log"
+)
+
+// useMockDal replaces the package-level db during the test and restores it afterward
+func useMockDal(t *testing.T, mockDal dal.Dal) {
+	origin := db
+	db = mockDal
+	t.Cleanup(func() {
+		db = origin
+	})
+}
+
+// useConfig replaces the package-level cfg during the test and restores it afterward
+func useConfig(t *testing.T, configReader config.ConfigReader) {
+	origin := cfg
+	cfg = configReader
+	t.Cleanup(func() {
+		cfg = origin
+	})
+}
+
+// useLogger replaces the package-level logger during the test and restores it afterward
+func useLogger(t *testing.T, l log.Logger) {
+	origin := logger
+	logger = l
+	t.Cleanup(func() {
+		logger = origin
+	})
+}
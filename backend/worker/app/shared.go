@@ -50,7 +50,7 @@ func loadResources(configJson []byte, loggerConfig *log.LoggerConfig) (context.B
 }
 
 func getWorkerLogger(logger log.Logger, logConfig *log.LoggerConfig) (log.Logger, errors.Error) {
-	newLogger := logger.Nested(logConfig.Prefix)
+	newLogger := logger.Nested(logConfig.Prefix).WithFields(logConfig.Fields)
 	stream, err := logruslog.GetFileStream(logConfig.Path)
 	if err != nil {
 		return nil, err
@@ -45,6 +45,11 @@ func DevLakeTaskActivity(ctx context.Context, configJson []byte, taskId uint64,
 		}
 	}()
 	err = runner.RunTask(ctx, basicRes, progChan, taskId)
+	if errors.Is(err, runner.ErrTaskPaused) {
+		// the status was saved already, there is nothing for temporal to retry
+		logger.Info("paused task #%d", taskId)
+		return nil
+	}
 	if err != nil {
 		logger.Error(err, "failed to execute task #%d", taskId)
 	}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+
+	"github.com/apache/incubator-devlake/core/tracing"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+)
+
+const tracingHeaderKey = "devlake-tracing"
+
+type tracingContextKey struct{}
+
+// TracingPropagator passes the trace context from the server to the workflows and activities executed by workers,
+// so the spans of tasks would be children of the span of the pipeline
+type TracingPropagator struct{}
+
+// NewTracingPropagator returns the propagator to be set to both the temporal client and the worker
+func NewTracingPropagator() workflow.ContextPropagator {
+	return &TracingPropagator{}
+}
+
+// Inject injects the trace context carried by the go context into the headers
+func (p *TracingPropagator) Inject(ctx context.Context, writer workflow.HeaderWriter) error {
+	return writeTracingHeader(tracing.Inject(ctx), writer)
+}
+
+// Extract extracts the trace context from the headers into the go context
+func (p *TracingPropagator) Extract(ctx context.Context, reader workflow.HeaderReader) (context.Context, error) {
+	carrier, err := readTracingHeader(reader)
+	if err != nil || carrier == nil {
+		return ctx, err
+	}
+	return tracing.Extract(ctx, carrier), nil
+}
+
+// InjectFromWorkflow injects the trace context kept by the workflow context into the headers
+func (p *TracingPropagator) InjectFromWorkflow(ctx workflow.Context, writer workflow.HeaderWriter) error {
+	carrier, ok := ctx.Value(tracingContextKey{}).(map[string]string)
+	if !ok {
+		return nil
+	}
+	return writeTracingHeader(carrier, writer)
+}
+
+// ExtractToWorkflow keeps the trace context from the headers in the workflow context, the workflow doesn't start
+// spans itself since it might be replayed
+func (p *TracingPropagator) ExtractToWorkflow(ctx workflow.Context, reader workflow.HeaderReader) (workflow.Context, error) {
+	carrier, err := readTracingHeader(reader)
+	if err != nil || carrier == nil {
+		return ctx, err
+	}
+	return workflow.WithValue(ctx, tracingContextKey{}, carrier), nil
+}
+
+func writeTracingHeader(carrier map[string]string, writer workflow.HeaderWriter) error {
+	if len(carrier) == 0 {
+		return nil
+	}
+	payload, err := converter.GetDefaultDataConverter().ToPayload(carrier)
+	if err != nil {
+		return err
+	}
+	writer.Set(tracingHeaderKey, payload)
+	return nil
+}
+
+func readTracingHeader(reader workflow.HeaderReader) (map[string]string, error) {
+	payload, ok := reader.Get(tracingHeaderKey)
+	if !ok {
+		return nil, nil
+	}
+	var carrier map[string]string
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &carrier); err != nil {
+		return nil, err
+	}
+	return carrier, nil
+}
+
+var _ workflow.ContextPropagator = (*TracingPropagator)(nil)
@@ -20,16 +20,19 @@ package main
 import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/core/tracing"
 	_ "github.com/apache/incubator-devlake/core/version"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"github.com/apache/incubator-devlake/worker/app"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
 	"log"
 )
 
 func main() {
 	basicRes := runner.CreateAppBasicRes()
+	defer tracing.Shutdown()
 	err := runner.LoadPlugins(basicRes)
 	if err != nil {
 		panic(err)
@@ -39,8 +42,9 @@ func main() {
 	TASK_QUEUE := basicRes.GetConfig("TEMPORAL_TASK_QUEUE")
 	// Create the client object just once per process
 	c, err := errors.Convert01(client.NewClient(client.Options{
-		HostPort: basicRes.GetConfig("TEMPORAL_URL"),
-		Logger:   app.NewTemporalLogger(logruslog.Global),
+		HostPort:           basicRes.GetConfig("TEMPORAL_URL"),
+		Logger:             app.NewTemporalLogger(logruslog.Global),
+		ContextPropagators: []workflow.ContextPropagator{app.NewTracingPropagator()},
 	}))
 	if err != nil {
 		log.Fatalln("unable to create Temporal client", err)